@@ -5,15 +5,23 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"telegram-reminder-bot/internal/api"
 	"telegram-reminder-bot/internal/bot"
 	"telegram-reminder-bot/internal/config"
+	"telegram-reminder-bot/internal/eventbus"
+	"telegram-reminder-bot/internal/metrics"
 	"telegram-reminder-bot/internal/repository/postgres"
 	"telegram-reminder-bot/internal/scheduler"
+	"telegram-reminder-bot/internal/server"
 	"telegram-reminder-bot/internal/service"
+	"telegram-reminder-bot/internal/tracing"
+	"telegram-reminder-bot/internal/webhook"
 )
 
 func main() {
@@ -38,27 +46,84 @@ func main() {
 		zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	}
 
+	log.Logger = log.Logger.Hook(tracing.LogHook{})
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingEndpoint,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to set up tracing")
+	}
+
 	db, err := postgres.New(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to connect to database")
 	}
 	defer db.Close()
 
+	prometheus.MustRegister(metrics.NewPoolCollector(db.Pool))
+
 	userRepo := postgres.NewUserRepository(db)
 	taskRepo := postgres.NewTaskRepository(db)
+	eventRepo := postgres.NewEventRepository(db)
+	tokenRepo := postgres.NewAPITokenRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	inviteRepo := postgres.NewInviteRepository(db)
+	chatRepo := postgres.NewChatRepository(db)
+	reminderRepo := postgres.NewReminderRepository(db)
+	quietRepo := postgres.NewQuietPeriodRepository(db)
+	dependencyRepo := postgres.NewDependencyRepository(db)
+	attachmentRepo := postgres.NewAttachmentRepository(db)
+
+	bus := eventbus.New()
+	webhookCfg := webhook.DefaultConfig()
+	webhookCfg.AllowPrivate = cfg.WebhookAllowPrivate
+	dispatcher := webhook.NewDispatcher(webhookRepo, webhookCfg)
+	bus.Subscribe(dispatcher.Handle)
+
+	userService := service.NewUserService(userRepo, tokenRepo)
+	taskService := service.NewTaskService(taskRepo, eventRepo, bus, service.TaskServiceConfig{
+		ArchiveRetention: time.Duration(cfg.ArchiveRetentionDays) * 24 * time.Hour,
+		UndoWindow:       cfg.UndoWindow,
+	})
+	reminderService := service.NewReminderService(reminderRepo, taskRepo)
+	quietService := service.NewQuietService(quietRepo, userRepo, taskRepo)
+	dependencyService := service.NewDependencyService(dependencyRepo, taskRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo)
+	statsService := service.NewStatsService(eventRepo, taskRepo)
+	accountService := service.NewAccountService(userRepo, taskRepo, eventRepo, tokenRepo, webhookRepo, reminderRepo, quietRepo, attachmentRepo, inviteRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	adminService := service.NewAdminService(userRepo, taskRepo, inviteRepo)
+	chatService := service.NewChatService(chatRepo)
+
+	telegramBot, err := bot.New(bot.Config{
+		Token:      cfg.TelegramBotToken,
+		PublicURL:  cfg.PublicURL,
+		AdminIDs:   cfg.AdminIDs,
+		AccessMode: cfg.AccessMode,
+		AllowedIDs: cfg.AllowedIDs,
+	}, userService, taskService, reminderService, quietService, dependencyService, attachmentService, statsService, accountService, webhookService, adminService, chatService)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create telegram bot")
+	}
 
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo)
+	bus.Subscribe(scheduler.NewNotifier(userRepo, chatRepo, telegramBot).Handle)
 
-	telegramBot, err := bot.New(cfg.TelegramBotToken, userService, taskService)
+	curve, err := scheduler.ParseEscalationCurve(cfg.EscalationCurve)
 	if err != nil {
-		log.Fatal().Err(err).Msg("failed to create telegram bot")
+		log.Fatal().Err(err).Msg("failed to parse escalation curve")
+	}
+	policy, err := scheduler.NewPolicy(cfg.ReminderPolicy, curve)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create reminder policy")
 	}
 
-	reminderScheduler, err := scheduler.New(taskService, userRepo, telegramBot)
+	reminderScheduler, err := scheduler.New(taskService, reminderService, quietService, userRepo, chatRepo, telegramBot, policy)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create scheduler")
 	}
@@ -69,16 +134,47 @@ func main() {
 
 	go telegramBot.Start(ctx)
 
+	var httpServer *server.Server
+	if cfg.HTTPAddr != "" {
+		httpServer = server.New(cfg.HTTPAddr)
+		httpServer.RegisterCalendarFeed(userService, taskService)
+		httpServer.Mux.Handle(api.Prefix, api.NewHandler(userService, taskService))
+		httpServer.Mux.Handle("GET /metrics", metrics.Handler())
+		httpServer.RegisterHealth(
+			map[string]server.Check{"scheduler": reminderScheduler.Check},
+			map[string]server.Check{"database": db.Ping},
+		)
+		httpServer.Start()
+	}
+
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
 	log.Info().Msg("shutting down...")
 
+	if httpServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("failed to stop http server")
+		}
+		shutdownCancel()
+	}
+
 	if err := reminderScheduler.Stop(); err != nil {
 		log.Error().Err(err).Msg("failed to stop scheduler")
 	}
 
+	dispatchCtx, dispatchCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	dispatcher.Close(dispatchCtx)
+	dispatchCancel()
+
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		log.Error().Err(err).Msg("failed to flush traces")
+	}
+	tracingCancel()
+
 	cancel()
 	log.Info().Msg("shutdown complete")
 }
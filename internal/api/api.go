@@ -0,0 +1,144 @@
+// Package api serves the HTTP JSON API for tasks and settings. Requests are
+// authenticated with per-user tokens issued by the bot's /token command and
+// sent as "Authorization: Bearer <token>".
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/service"
+	"telegram-reminder-bot/internal/tracing"
+)
+
+// Prefix is the path all API routes live under.
+const Prefix = "/api/v1/"
+
+const maxBodySize = 64 << 10
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+type Handler struct {
+	userService *service.UserService
+	taskService *service.TaskService
+	mux         *http.ServeMux
+}
+
+func NewHandler(userService *service.UserService, taskService *service.TaskService) *Handler {
+	h := &Handler{
+		userService: userService,
+		taskService: taskService,
+		mux:         http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /api/v1/openapi.json", h.handleOpenAPI)
+
+	h.mux.HandleFunc("GET /api/v1/tasks", h.authed(h.listTasks))
+	h.mux.HandleFunc("POST /api/v1/tasks", h.authed(h.createTask))
+	h.mux.HandleFunc("GET /api/v1/tasks/{id}", h.authed(h.getTask))
+	h.mux.HandleFunc("PATCH /api/v1/tasks/{id}", h.authed(h.updateTask))
+	h.mux.HandleFunc("DELETE /api/v1/tasks/{id}", h.authed(h.deleteTask))
+
+	h.mux.HandleFunc("GET /api/v1/settings", h.authed(h.getSettings))
+	h.mux.HandleFunc("PATCH /api/v1/settings", h.authed(h.updateSettings))
+
+	h.mux.HandleFunc(Prefix, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found")
+	})
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Start(r.Context(), "HTTP "+r.Method, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	r = r.WithContext(ctx)
+	h.mux.ServeHTTP(w, r)
+
+	// The route is known only after routing. Unlike the path, it contains
+	// no IDs, which keeps span names low-cardinality.
+	if r.Pattern != "" {
+		span.SetName(r.Pattern)
+	}
+}
+
+type authedFunc func(w http.ResponseWriter, r *http.Request, user *domain.User)
+
+// authed resolves the bearer token to a user before calling next.
+func (h *Handler) authed(next authedFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		user, err := h.userService.GetByAPIToken(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			internalError(w, r, err, "failed to authenticate api request")
+			return
+		}
+		if user == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		if user.IsBanned() {
+			writeError(w, http.StatusForbidden, "account is blocked")
+			return
+		}
+
+		next(w, r, user)
+	}
+}
+
+func (h *Handler) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("failed to write api response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func internalError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	log.Error().Ctx(r.Context()).Err(err).Msg(msg)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+// decodeBody reads a JSON request body into v, rejecting unknown fields.
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("request body is empty")
+		}
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
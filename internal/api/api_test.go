@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/service"
+)
+
+type testEnv struct {
+	handler    http.Handler
+	users      *memUsers
+	tasks      *memTasks
+	aliceToken string
+	bobToken   string
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	users := &memUsers{users: make(map[int64]*domain.User)}
+	tasks := &memTasks{tasks: make(map[int64]*domain.Task)}
+	userService := service.NewUserService(users, &memTokens{})
+	taskService := service.NewTaskService(tasks, &memEvents{}, nil, service.TaskServiceConfig{UndoWindow: time.Minute})
+
+	env := &testEnv{
+		handler: NewHandler(userService, taskService),
+		users:   users,
+		tasks:   tasks,
+	}
+	env.aliceToken = issueToken(t, userService, 100, "alice")
+	env.bobToken = issueToken(t, userService, 200, "bob")
+	return env
+}
+
+func issueToken(t *testing.T, userService *service.UserService, telegramID int64, username string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	user, err := userService.GetOrCreate(ctx, telegramID, username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := userService.IssueAPIToken(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// do sends a request and decodes a JSON response into out when it is not nil.
+func (e *testEnv) do(t *testing.T, token, method, path, body string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, req)
+
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON response %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func futureDate(days int) string {
+	return time.Now().AddDate(0, 0, days).Format(time.DateOnly)
+}
+
+func TestAuthentication(t *testing.T) {
+	env := newTestEnv(t)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"no header", "", http.StatusUnauthorized},
+		{"wrong scheme", "Basic " + env.aliceToken, http.StatusUnauthorized},
+		{"unknown token", "Bearer rb_nope", http.StatusUnauthorized},
+		{"valid token", "Bearer " + env.aliceToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			env.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestBannedUser(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	bob, _ := env.users.GetByTelegramID(ctx, 200)
+	if err := env.users.SetBanned(ctx, bob.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := env.do(t, env.bobToken, http.MethodGet, "/api/v1/tasks", "", nil); rec.Code != http.StatusForbidden {
+		t.Errorf("banned user: status = %d, want 403", rec.Code)
+	}
+	if rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks", "", nil); rec.Code != http.StatusOK {
+		t.Errorf("other user: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	env := newTestEnv(t)
+
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	rec := env.do(t, "", http.MethodGet, "/api/v1/openapi.json", "", &doc)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	for _, path := range []string{"/tasks", "/tasks/{id}", "/settings"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("document has no path %s", path)
+		}
+	}
+}
+
+func TestCreateTask(t *testing.T) {
+	env := newTestEnv(t)
+	deadline := futureDate(3)
+
+	var created taskResponse
+	rec := env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks",
+		`{"description": "Deploy", "deadline": "`+deadline+`"}`, &created)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body %s", rec.Code, rec.Body)
+	}
+	if created.Description != "Deploy" || created.Deadline != deadline {
+		t.Errorf("created = %+v", created)
+	}
+	if created.Importance != 3 || created.Frequency != "daily" {
+		t.Errorf("defaults: importance %d, frequency %q; want 3, daily", created.Importance, created.Frequency)
+	}
+
+	var got taskResponse
+	rec = env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks/"+itoa(created.ID), "", &got)
+	if rec.Code != http.StatusOK || got.ID != created.ID {
+		t.Errorf("GET status = %d, task = %+v", rec.Code, got)
+	}
+}
+
+func TestCreateTask_Validation(t *testing.T) {
+	env := newTestEnv(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty body", ``},
+		{"malformed", `{"description":`},
+		{"unknown field", `{"description": "a", "deadline": "` + futureDate(1) + `", "owner": 1}`},
+		{"no description", `{"deadline": "` + futureDate(1) + `"}`},
+		{"no deadline", `{"description": "a"}`},
+		{"bad deadline", `{"description": "a", "deadline": "15.01.2030"}`},
+		{"past deadline", `{"description": "a", "deadline": "2001-01-01"}`},
+		{"importance", `{"description": "a", "deadline": "` + futureDate(1) + `", "importance": 6}`},
+		{"frequency", `{"description": "a", "deadline": "` + futureDate(1) + `", "frequency": "hourly"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp errorResponse
+			rec := env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks", tt.body, &resp)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+			if resp.Error == "" {
+				t.Error("error message is empty")
+			}
+		})
+	}
+
+	if len(env.tasks.tasks) != 0 {
+		t.Errorf("%d tasks created by invalid requests", len(env.tasks.tasks))
+	}
+}
+
+func TestTaskOwnership(t *testing.T) {
+	env := newTestEnv(t)
+
+	var created taskResponse
+	env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks",
+		`{"description": "Private", "deadline": "`+futureDate(1)+`"}`, &created)
+	path := "/api/v1/tasks/" + itoa(created.ID)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete} {
+		rec := env.do(t, env.bobToken, method, path, `{"importance": 1}`, nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s by another user: status = %d, want 404", method, rec.Code)
+		}
+	}
+
+	var list taskListResponse
+	env.do(t, env.bobToken, http.MethodGet, "/api/v1/tasks", "", &list)
+	if list.Total != 0 {
+		t.Errorf("other user sees %d tasks", list.Total)
+	}
+
+	if task := env.tasks.tasks[created.ID]; task.Importance != 3 || task.DeletedAt != nil {
+		t.Errorf("task was modified by another user: %+v", task)
+	}
+}
+
+func TestSharedTaskHidden(t *testing.T) {
+	env := newTestEnv(t)
+
+	alice, _ := env.users.GetByTelegramID(context.Background(), 100)
+	chatID := int64(7)
+	shared := domain.NewTask(alice.ID, "Water plants", time.Now().AddDate(0, 0, 1), 3, domain.FrequencyDaily)
+	shared.ChatID = &chatID
+	if err := env.tasks.Create(context.Background(), shared); err != nil {
+		t.Fatal(err)
+	}
+
+	path := "/api/v1/tasks/" + itoa(shared.ID)
+	for _, method := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete} {
+		rec := env.do(t, env.aliceToken, method, path, `{"importance": 1}`, nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s of a group task: status = %d, want 404", method, rec.Code)
+		}
+	}
+
+	var list taskListResponse
+	env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks", "", &list)
+	if list.Total != 0 {
+		t.Errorf("group task listed as personal: total = %d", list.Total)
+	}
+}
+
+func TestUpdateTask(t *testing.T) {
+	env := newTestEnv(t)
+
+	var created taskResponse
+	env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks",
+		`{"description": "Write docs", "deadline": "`+futureDate(2)+`"}`, &created)
+	path := "/api/v1/tasks/" + itoa(created.ID)
+
+	var updated taskResponse
+	rec := env.do(t, env.aliceToken, http.MethodPatch, path,
+		`{"importance": 5, "frequency": "weekly", "completed": true}`, &updated)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	if updated.Description != "Write docs" {
+		t.Errorf("description changed to %q", updated.Description)
+	}
+	if updated.Importance != 5 || updated.Frequency != "weekly" {
+		t.Errorf("updated = %+v", updated)
+	}
+	if !updated.IsCompleted || updated.CompletedAt == nil {
+		t.Errorf("task not completed: %+v", updated)
+	}
+
+	var completed taskListResponse
+	env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks?status=completed", "", &completed)
+	if completed.Total != 1 {
+		t.Errorf("completed total = %d, want 1", completed.Total)
+	}
+
+	rec = env.do(t, env.aliceToken, http.MethodPatch, path, `{"completed": false}`, &updated)
+	if rec.Code != http.StatusOK || updated.IsCompleted {
+		t.Errorf("reopen: status = %d, task = %+v", rec.Code, updated)
+	}
+
+	rec = env.do(t, env.aliceToken, http.MethodPatch, path, `{"description": ""}`, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("empty description: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestDeleteTask(t *testing.T) {
+	env := newTestEnv(t)
+
+	var created taskResponse
+	env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks",
+		`{"description": "Temp", "deadline": "`+futureDate(1)+`"}`, &created)
+	path := "/api/v1/tasks/" + itoa(created.ID)
+
+	if rec := env.do(t, env.aliceToken, http.MethodDelete, path, "", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want 204", rec.Code)
+	}
+	if rec := env.do(t, env.aliceToken, http.MethodGet, path, "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("GET after delete: status = %d, want 404", rec.Code)
+	}
+	if rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks/abc", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("non-numeric id: status = %d, want 404", rec.Code)
+	}
+}
+
+func TestListTasks(t *testing.T) {
+	env := newTestEnv(t)
+
+	for i := 1; i <= 3; i++ {
+		env.do(t, env.aliceToken, http.MethodPost, "/api/v1/tasks",
+			`{"description": "Task `+itoa(int64(i))+`", "deadline": "`+futureDate(i)+`"}`, nil)
+	}
+
+	var list taskListResponse
+	rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks?limit=2&offset=1&sort=importance&filter=week", "", &list)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	if list.Total != 3 || len(list.Tasks) != 2 {
+		t.Errorf("total = %d, page = %d; want 3, 2", list.Total, len(list.Tasks))
+	}
+
+	for _, query := range []string{"limit=0", "limit=1000", "offset=-1", "sort=random", "filter=soon", "status=deleted"} {
+		if rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/tasks?"+query, "", nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestSettings(t *testing.T) {
+	env := newTestEnv(t)
+
+	var settings settingsResponse
+	rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/settings", "", &settings)
+	if rec.Code != http.StatusOK || settings.Timezone != "Europe/Moscow" || settings.WorkStartHour != 9 {
+		t.Fatalf("GET status = %d, settings = %+v", rec.Code, settings)
+	}
+
+	rec = env.do(t, env.aliceToken, http.MethodPatch, "/api/v1/settings",
+		`{"timezone": "Asia/Tokyo", "work_start_hour": 10, "work_end_hour": 19}`, &settings)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH status = %d; body %s", rec.Code, rec.Body)
+	}
+	want := settingsResponse{Timezone: "Asia/Tokyo", WorkHoursPerDay: 8, WorkStartHour: 10, WorkEndHour: 19}
+	if settings != want {
+		t.Errorf("settings = %+v, want %+v", settings, want)
+	}
+
+	// The change is persisted, not only echoed.
+	env.do(t, env.aliceToken, http.MethodGet, "/api/v1/settings", "", &settings)
+	if settings != want {
+		t.Errorf("stored settings = %+v, want %+v", settings, want)
+	}
+
+	for _, body := range []string{
+		`{"timezone": "Mars/Olympus"}`,
+		`{"work_hours_per_day": 0}`,
+		`{"work_start_hour": 20}`,
+		`{"work_end_hour": 25}`,
+		`{"language": "en"}`,
+	} {
+		if rec := env.do(t, env.aliceToken, http.MethodPatch, "/api/v1/settings", body, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	env := newTestEnv(t)
+
+	var resp errorResponse
+	rec := env.do(t, env.aliceToken, http.MethodGet, "/api/v1/projects", "", &resp)
+	if rec.Code != http.StatusNotFound || resp.Error == "" {
+		t.Errorf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
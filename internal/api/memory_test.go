@@ -0,0 +1,336 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+// In-memory repositories backing the real services in handler tests.
+
+type memUsers struct {
+	mu    sync.Mutex
+	users map[int64]*domain.User
+}
+
+func (r *memUsers) Create(_ context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user.ID = int64(len(r.users) + 1)
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memUsers) GetByID(_ context.Context, id int64) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		copied := *u
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memUsers) GetByTelegramID(_ context.Context, telegramID int64) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.TelegramID == telegramID {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memUsers) GetByUsername(context.Context, string) (*domain.User, error) {
+	return nil, nil
+}
+
+func (r *memUsers) GetByCalendarToken(context.Context, string) (*domain.User, error) {
+	return nil, nil
+}
+
+func (r *memUsers) Update(_ context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *memUsers) SetCalendarToken(context.Context, int64, string) error {
+	return nil
+}
+
+func (r *memUsers) SetBanned(_ context.Context, id int64, banned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		u.BannedAt = nil
+		if banned {
+			now := time.Now()
+			u.BannedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *memUsers) Count(context.Context, time.Time) (domain.UserCounts, error) {
+	return domain.UserCounts{}, nil
+}
+
+func (r *memUsers) ListTelegramIDs(context.Context) ([]int64, error) {
+	return nil, nil
+}
+
+func (r *memUsers) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, id)
+	return nil
+}
+
+type memTasks struct {
+	mu     sync.Mutex
+	nextID int64
+	tasks  map[int64]*domain.Task
+}
+
+func (r *memTasks) Create(_ context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	task.ID = r.nextID
+	task.CreatedAt = time.Now()
+	task.UpdatedAt = task.CreatedAt
+	copied := *task
+	r.tasks[task.ID] = &copied
+	return nil
+}
+
+func (r *memTasks) GetByID(_ context.Context, id int64) (*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tasks[id]; ok && t.DeletedAt == nil {
+		copied := *t
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memTasks) filter(keep func(*domain.Task) bool) []*domain.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.Task
+	for _, t := range r.tasks {
+		if t.DeletedAt == nil && keep(t) {
+			copied := *t
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func page(tasks []*domain.Task, limit, offset int) ([]*domain.Task, int) {
+	total := len(tasks)
+	if offset > total {
+		offset = total
+	}
+	end := min(offset+limit, total)
+	return tasks[offset:end], total
+}
+
+func (r *memTasks) GetActiveByUserID(_ context.Context, userID int64) ([]*domain.Task, error) {
+	return r.filter(func(t *domain.Task) bool { return t.UserID == userID && !t.IsCompleted }), nil
+}
+
+func (r *memTasks) GetActiveByChatID(_ context.Context, chatID int64) ([]*domain.Task, error) {
+	return r.filter(func(t *domain.Task) bool { return t.ChatID != nil && *t.ChatID == chatID && !t.IsCompleted }), nil
+}
+
+func (r *memTasks) ListByUserID(_ context.Context, userID int64) ([]*domain.Task, error) {
+	return r.filter(func(t *domain.Task) bool { return t.UserID == userID }), nil
+}
+
+func (r *memTasks) ListActiveByUserID(_ context.Context, userID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	tasks, total := page(r.filter(func(t *domain.Task) bool { return t.UserID == userID && t.ChatID == nil && !t.IsCompleted }), opts.Limit, opts.Offset)
+	return tasks, total, nil
+}
+
+func (r *memTasks) ListActiveByChatID(_ context.Context, chatID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	tasks, total := page(r.filter(func(t *domain.Task) bool { return t.ChatID != nil && *t.ChatID == chatID && !t.IsCompleted }), opts.Limit, opts.Offset)
+	return tasks, total, nil
+}
+
+func (r *memTasks) ListCompletedByUserID(_ context.Context, userID int64, limit, offset int) ([]*domain.Task, int, error) {
+	tasks, total := page(r.filter(func(t *domain.Task) bool { return t.UserID == userID && t.ChatID == nil && t.IsCompleted }), limit, offset)
+	return tasks, total, nil
+}
+
+func (r *memTasks) GetTasksForReminder(context.Context) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) ListOverdue(context.Context, time.Time) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) CountByState(context.Context) (map[string]int, error) {
+	return nil, nil
+}
+
+func (r *memTasks) Update(_ context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task.UpdatedAt = time.Now()
+	copied := *task
+	r.tasks[task.ID] = &copied
+	return nil
+}
+
+func (r *memTasks) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tasks, id)
+	return nil
+}
+
+func (r *memTasks) SoftDelete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tasks[id]; ok {
+		now := time.Now()
+		t.DeletedAt = &now
+	}
+	return nil
+}
+
+func (r *memTasks) GetDeletedByID(context.Context, int64, time.Time) (*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) Restore(context.Context, int64, int64, *int64, time.Time) (bool, error) {
+	return false, nil
+}
+
+func (r *memTasks) DeleteSoftDeletedBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memTasks) DeleteCompletedBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memTasks) ResetDailyReminders(context.Context) error {
+	return nil
+}
+
+func (r *memTasks) ListPausedUntil(context.Context, time.Time) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) ListReadyDependents(context.Context, int64) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) RecordReminderSent(context.Context, int64, time.Time) error {
+	return nil
+}
+
+func (r *memTasks) Snooze(context.Context, int64, time.Time, bool) error {
+	return nil
+}
+
+func (r *memTasks) MarkOverdue(context.Context, int64, time.Time) (bool, error) {
+	return false, nil
+}
+
+func (r *memTasks) SetPaused(context.Context, int64, *time.Time, *time.Time) error {
+	return nil
+}
+
+func (r *memTasks) DetachShared(context.Context, int64) error {
+	return nil
+}
+
+func (r *memTasks) ReleaseDeferred(context.Context, int64) error {
+	return nil
+}
+
+type memEvents struct {
+	mu     sync.Mutex
+	events []*domain.TaskEvent
+}
+
+func (r *memEvents) Create(_ context.Context, event *domain.TaskEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.CreatedAt = time.Now()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memEvents) ListByUserID(context.Context, int64, time.Time) ([]*domain.TaskEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events, nil
+}
+
+type memTokens struct {
+	mu     sync.Mutex
+	tokens []*domain.APIToken
+}
+
+func (r *memTokens) Create(_ context.Context, token *domain.APIToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token.ID = int64(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memTokens) GetByHash(_ context.Context, hash string) (*domain.APIToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.tokens {
+		if t.TokenHash == hash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memTokens) ListByUserID(_ context.Context, userID int64) ([]*domain.APIToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.APIToken
+	for _, t := range r.tokens {
+		if t.UserID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (r *memTokens) Touch(context.Context, int64) error {
+	return nil
+}
+
+func (r *memTokens) DeleteByUserID(_ context.Context, userID int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.tokens[:0]
+	for _, t := range r.tokens {
+		if t.UserID != userID {
+			kept = append(kept, t)
+		}
+	}
+	deleted := int64(len(r.tokens) - len(kept))
+	r.tokens = kept
+	return deleted, nil
+}
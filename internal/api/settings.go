@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type settingsResponse struct {
+	Timezone        string `json:"timezone"`
+	WorkHoursPerDay int    `json:"work_hours_per_day"`
+	WorkStartHour   int    `json:"work_start_hour"`
+	WorkEndHour     int    `json:"work_end_hour"`
+}
+
+type updateSettingsRequest struct {
+	Timezone        *string `json:"timezone"`
+	WorkHoursPerDay *int    `json:"work_hours_per_day"`
+	WorkStartHour   *int    `json:"work_start_hour"`
+	WorkEndHour     *int    `json:"work_end_hour"`
+}
+
+func newSettingsResponse(user *domain.User) settingsResponse {
+	return settingsResponse{
+		Timezone:        user.Timezone,
+		WorkHoursPerDay: user.WorkHoursPerDay,
+		WorkStartHour:   user.WorkStartHour,
+		WorkEndHour:     user.WorkEndHour,
+	}
+}
+
+func (h *Handler) getSettings(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	writeJSON(w, http.StatusOK, newSettingsResponse(user))
+}
+
+func (h *Handler) updateSettings(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	var req updateSettingsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			writeError(w, http.StatusBadRequest, "unknown timezone")
+			return
+		}
+		user.Timezone = *req.Timezone
+	}
+	if req.WorkHoursPerDay != nil {
+		user.WorkHoursPerDay = *req.WorkHoursPerDay
+	}
+	if req.WorkStartHour != nil {
+		user.WorkStartHour = *req.WorkStartHour
+	}
+	if req.WorkEndHour != nil {
+		user.WorkEndHour = *req.WorkEndHour
+	}
+
+	if user.WorkHoursPerDay < 1 || user.WorkHoursPerDay > 24 {
+		writeError(w, http.StatusBadRequest, "work_hours_per_day must be between 1 and 24")
+		return
+	}
+	if user.WorkStartHour < 0 || user.WorkEndHour > 24 || user.WorkStartHour >= user.WorkEndHour {
+		writeError(w, http.StatusBadRequest, "work hours must satisfy 0 <= work_start_hour < work_end_hour <= 24")
+		return
+	}
+
+	if err := h.userService.UpdateSettings(r.Context(), user); err != nil {
+		internalError(w, r, err, "failed to update settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSettingsResponse(user))
+}
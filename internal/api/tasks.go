@@ -0,0 +1,327 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+type taskResponse struct {
+	ID          int64      `json:"id"`
+	Description string     `json:"description"`
+	Deadline    string     `json:"deadline"`
+	Importance  int        `json:"importance"`
+	Frequency   string     `json:"frequency"`
+	IsCompleted bool       `json:"is_completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	PausedAt    *time.Time `json:"paused_at,omitempty"`
+	PausedUntil string     `json:"paused_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type taskListResponse struct {
+	Tasks []taskResponse `json:"tasks"`
+	Total int            `json:"total"`
+}
+
+type createTaskRequest struct {
+	Description string `json:"description"`
+	Deadline    string `json:"deadline"`
+	Importance  int    `json:"importance"`
+	Frequency   string `json:"frequency"`
+}
+
+// updateTaskRequest holds the fields to change; absent fields are kept.
+type updateTaskRequest struct {
+	Description *string `json:"description"`
+	Deadline    *string `json:"deadline"`
+	Importance  *int    `json:"importance"`
+	Frequency   *string `json:"frequency"`
+	Completed   *bool   `json:"completed"`
+}
+
+func newTaskResponse(t *domain.Task) taskResponse {
+	resp := taskResponse{
+		ID:          t.ID,
+		Description: t.Description,
+		Deadline:    t.Deadline.Format(time.DateOnly),
+		Importance:  t.Importance,
+		Frequency:   t.Frequency.String(),
+		IsCompleted: t.IsCompleted,
+		CompletedAt: t.CompletedAt,
+		PausedAt:    t.PausedAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	if t.PausedUntil != nil {
+		resp.PausedUntil = t.PausedUntil.Format(time.DateOnly)
+	}
+	return resp
+}
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"), defaultPageSize)
+	if err != nil || limit < 1 || limit > maxPageSize {
+		writeError(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxPageSize))
+		return
+	}
+	offset, err := intParam(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, "offset must not be negative")
+		return
+	}
+
+	var (
+		tasks []*domain.Task
+		total int
+	)
+
+	switch status := query.Get("status"); status {
+	case "", "active":
+		opts := domain.TaskListOptions{
+			Sort:   domain.TaskSortDeadline,
+			Filter: domain.TaskFilterAll,
+			Today:  today(user),
+			Limit:  limit,
+			Offset: offset,
+		}
+		if s := query.Get("sort"); s != "" {
+			sort, ok := domain.ParseTaskSort(s)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "unknown sort "+strconv.Quote(s))
+				return
+			}
+			opts.Sort = sort
+		}
+		if f := query.Get("filter"); f != "" {
+			filter, ok := domain.ParseTaskFilter(f)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "unknown filter "+strconv.Quote(f))
+				return
+			}
+			opts.Filter = filter
+		}
+		tasks, total, err = h.taskService.ListActive(r.Context(), user.ID, opts)
+	case "completed":
+		tasks, total, err = h.taskService.ListCompleted(r.Context(), user.ID, limit, offset)
+	default:
+		writeError(w, http.StatusBadRequest, "status must be active or completed")
+		return
+	}
+	if err != nil {
+		internalError(w, r, err, "failed to list tasks")
+		return
+	}
+
+	resp := taskListResponse{Tasks: make([]taskResponse, 0, len(tasks)), Total: total}
+	for _, t := range tasks {
+		resp.Tasks = append(resp.Tasks, newTaskResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	var req createTaskRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Description == "" {
+		writeError(w, http.StatusBadRequest, "description is required")
+		return
+	}
+	deadline, msg := parseDeadline(req.Deadline, user)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	importance := req.Importance
+	if importance == 0 {
+		importance = 3
+	}
+	if importance < 1 || importance > 5 {
+		writeError(w, http.StatusBadRequest, "importance must be between 1 and 5")
+		return
+	}
+
+	frequency := domain.FrequencyDaily
+	if req.Frequency != "" {
+		f, ok := domain.ParseFrequency(req.Frequency)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "frequency must be daily, every_other_day or weekly")
+			return
+		}
+		frequency = f
+	}
+
+	task, err := h.taskService.Create(r.Context(), user.ID, req.Description, deadline, importance, frequency)
+	if err != nil {
+		internalError(w, r, err, "failed to create task")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newTaskResponse(task))
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	task, ok := h.loadTask(w, r, user)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	task, ok := h.loadTask(w, r, user)
+	if !ok {
+		return
+	}
+
+	var req updateTaskRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changed := false
+	if req.Description != nil {
+		if *req.Description == "" {
+			writeError(w, http.StatusBadRequest, "description must not be empty")
+			return
+		}
+		task.Description = *req.Description
+		changed = true
+	}
+	if req.Deadline != nil {
+		deadline, msg := parseDeadline(*req.Deadline, user)
+		if msg != "" {
+			writeError(w, http.StatusBadRequest, msg)
+			return
+		}
+		task.Deadline = deadline
+		changed = true
+	}
+	if req.Importance != nil {
+		if *req.Importance < 1 || *req.Importance > 5 {
+			writeError(w, http.StatusBadRequest, "importance must be between 1 and 5")
+			return
+		}
+		task.Importance = *req.Importance
+		changed = true
+	}
+	if req.Frequency != nil {
+		f, ok := domain.ParseFrequency(*req.Frequency)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "frequency must be daily, every_other_day or weekly")
+			return
+		}
+		task.Frequency = f
+		changed = true
+	}
+
+	if changed {
+		if err := h.taskService.Update(r.Context(), task); err != nil {
+			internalError(w, r, err, "failed to update task")
+			return
+		}
+	}
+
+	if req.Completed != nil && *req.Completed != task.IsCompleted {
+		var err error
+		if *req.Completed {
+			err = h.taskService.Complete(r.Context(), task.ID)
+		} else {
+			_, err = h.taskService.Reopen(r.Context(), task.ID)
+		}
+		if err != nil {
+			internalError(w, r, err, "failed to change task status")
+			return
+		}
+	}
+
+	updated, err := h.taskService.GetByID(r.Context(), task.ID)
+	if err != nil || updated == nil {
+		internalError(w, r, err, "failed to reload task")
+		return
+	}
+	writeJSON(w, http.StatusOK, newTaskResponse(updated))
+}
+
+// deleteTask moves the task to the bin. Like deletions from the chat, it
+// is purged once the undo window passes.
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request, user *domain.User) {
+	task, ok := h.loadTask(w, r, user)
+	if !ok {
+		return
+	}
+
+	if err := h.taskService.Delete(r.Context(), task.ID); err != nil {
+		internalError(w, r, err, "failed to delete task")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadTask reads the {id} path parameter and returns the task if it belongs
+// to user. Tasks of other users and shared group tasks, which are managed in
+// their group, are reported as not found.
+func (h *Handler) loadTask(w http.ResponseWriter, r *http.Request, user *domain.User) (*domain.Task, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "task not found")
+		return nil, false
+	}
+
+	task, err := h.taskService.GetByID(r.Context(), id)
+	if err != nil {
+		internalError(w, r, err, "failed to get task")
+		return nil, false
+	}
+	if task == nil || task.UserID != user.ID || task.ChatID != nil {
+		writeError(w, http.StatusNotFound, "task not found")
+		return nil, false
+	}
+
+	return task, true
+}
+
+// parseDeadline parses a YYYY-MM-DD date that is not in the past for the
+// user. It returns an error message for the client on failure.
+func parseDeadline(s string, user *domain.User) (time.Time, string) {
+	if s == "" {
+		return time.Time{}, "deadline is required"
+	}
+	deadline, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, "deadline must be a date in YYYY-MM-DD format"
+	}
+	if deadline.Before(today(user)) {
+		return time.Time{}, "deadline must not be in the past"
+	}
+	return deadline, ""
+}
+
+// today returns the current date in the user's timezone as midnight UTC.
+func today(user *domain.User) time.Time {
+	now := time.Now().In(user.Location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func intParam(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+)
+
+// Access modes decide who may register. Registered users keep access until
+// they are banned; admins always have access.
+const (
+	AccessOpen      = "open"
+	AccessAllowlist = "allowlist"
+	AccessInvite    = "invite"
+)
+
+type accessPolicy struct {
+	mode    string
+	admins  map[int64]bool
+	allowed map[int64]bool
+}
+
+func newAccessPolicy(mode string, adminIDs, allowedIDs []int64) (accessPolicy, error) {
+	switch mode {
+	case "":
+		mode = AccessOpen
+	case AccessOpen, AccessAllowlist, AccessInvite:
+	default:
+		return accessPolicy{}, fmt.Errorf("unknown access mode %q", mode)
+	}
+
+	p := accessPolicy{
+		mode:    mode,
+		admins:  make(map[int64]bool, len(adminIDs)),
+		allowed: make(map[int64]bool, len(allowedIDs)),
+	}
+	for _, id := range adminIDs {
+		p.admins[id] = true
+	}
+	for _, id := range allowedIDs {
+		p.allowed[id] = true
+	}
+	return p, nil
+}
+
+func (h *Handler) isAdmin(telegramID int64) bool {
+	return h.access.admins[telegramID]
+}
+
+// checkAccess drops updates from banned users and, outside open mode, from
+// people who have not registered and may not do so. Buttons on shared tasks
+// work for every member of a group the bot is used in. Registered users who
+// have no language yet get the one their Telegram client reports.
+func (h *Handler) checkAccess(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		from := updateSender(update)
+		if from == nil || h.isAdmin(from.ID) {
+			next(ctx, b, update)
+			return
+		}
+
+		user, err := h.userService.GetByTelegramID(ctx, from.ID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("telegram_id", from.ID).Msg("failed to check access")
+			return
+		}
+
+		l := i18n.Parse(from.LanguageCode)
+		if user != nil && user.Language != "" {
+			l = userLang(user)
+		}
+
+		switch {
+		case user != nil && user.IsBanned():
+			denyAccess(ctx, b, update, l.T("access.banned"))
+			return
+		case user != nil:
+			h.detectLanguage(ctx, user, from)
+		case h.isSharedTaskCallback(ctx, update):
+		case h.access.mode == AccessAllowlist && !h.access.allowed[from.ID]:
+			denyAccess(ctx, b, update, l.T("access.allowlist"))
+			return
+		case h.access.mode == AccessInvite && !isStartWithPayload(update):
+			denyAccess(ctx, b, update, l.T("access.invite_only"))
+			return
+		}
+
+		next(ctx, b, update)
+	}
+}
+
+// adminOnly ignores the command unless it comes from an admin, so that
+// admin commands look unknown to everybody else.
+func (h *Handler) adminOnly(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		from := updateSender(update)
+		if from == nil || !h.isAdmin(from.ID) {
+			return
+		}
+		next(ctx, b, update)
+	}
+}
+
+// isSharedTaskCallback reports whether the update is a button press in a
+// group that has shared tasks.
+func (h *Handler) isSharedTaskCallback(ctx context.Context, update *models.Update) bool {
+	if update.CallbackQuery == nil || update.CallbackQuery.Message.Message == nil {
+		return false
+	}
+	chat := update.CallbackQuery.Message.Message.Chat
+	if !isGroupChat(chat) {
+		return false
+	}
+
+	registered, err := h.chatService.GetByTelegramID(ctx, chat.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("chat_id", chat.ID).Msg("failed to get chat")
+		return false
+	}
+	return registered != nil
+}
+
+// detectLanguage stores the language of from's Telegram client for a user
+// who has not got one yet. Users who picked a language keep it.
+func (h *Handler) detectLanguage(ctx context.Context, user *domain.User, from *models.User) {
+	if user.Language != "" || from.LanguageCode == "" {
+		return
+	}
+	if err := h.userService.SetLanguage(ctx, user, string(i18n.Parse(from.LanguageCode))); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("telegram_id", from.ID).Msg("failed to set language")
+	}
+}
+
+// userLang is the language to talk to user in.
+func userLang(user *domain.User) i18n.Lang {
+	return i18n.Parse(user.Language)
+}
+
+// userStyle is the style user's task messages are rendered in.
+func userStyle(user *domain.User) render.Style {
+	return render.ParseStyle(user.MessageStyle)
+}
+
+// langFor is the language to answer from in when their user is not at hand:
+// their setting, or their client's language before they register.
+func (h *Handler) langFor(ctx context.Context, from *models.User) i18n.Lang {
+	user, err := h.userService.GetByTelegramID(ctx, from.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("telegram_id", from.ID).Msg("failed to get user")
+	}
+	if user == nil || user.Language == "" {
+		return i18n.Parse(from.LanguageCode)
+	}
+	return userLang(user)
+}
+
+func updateSender(update *models.Update) *models.User {
+	switch {
+	case update.Message != nil:
+		return update.Message.From
+	case update.CallbackQuery != nil:
+		return &update.CallbackQuery.From
+	}
+	return nil
+}
+
+// isStartWithPayload reports whether the update is "/start <code>", the
+// message a deep link from an invite sends.
+func isStartWithPayload(update *models.Update) bool {
+	if update.Message == nil {
+		return false
+	}
+	code, ok := commandArgs(update.Message.Text, "/start")
+	return ok && code != ""
+}
+
+func denyAccess(ctx context.Context, b *bot.Bot, update *models.Update, text string) {
+	if update.CallbackQuery != nil {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: update.CallbackQuery.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   text,
+	})
+}
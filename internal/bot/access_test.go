@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/service"
+)
+
+// memInvites redeems invite codes like the repository does: once, and
+// before they expire.
+type memInvites struct {
+	repository.InviteRepository
+	invites map[string]*domain.Invite
+}
+
+func (r memInvites) Redeem(_ context.Context, code string, telegramID int64) (bool, error) {
+	invite := r.invites[code]
+	if invite == nil || invite.UsedAt != nil || !invite.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	now := time.Now()
+	invite.UsedBy, invite.UsedAt = &telegramID, &now
+	return true, nil
+}
+
+func TestCheckAccess(t *testing.T) {
+	const (
+		admin    = 1
+		newcomer = 70
+		member   = 71
+		banned   = 72
+	)
+	en := i18n.Parse("en")
+
+	tests := []struct {
+		name       string
+		mode       string
+		from       int64
+		text       string
+		want       string
+		wantMember bool
+	}{
+		{"open to newcomers", AccessOpen, newcomer, "/start", en.T("start.help"), true},
+		{"banned user", AccessOpen, banned, "/start", en.T("access.banned"), false},
+		{"banned user with an invite", AccessInvite, banned, "/start valid", en.T("access.banned"), false},
+		{"allowlist without the user", AccessAllowlist, newcomer, "/start", en.T("access.allowlist"), false},
+		{"invite only without a code", AccessInvite, newcomer, "/start", en.T("access.invite_only"), false},
+		{"invite only with other messages", AccessInvite, newcomer, "/list", en.T("access.invite_only"), false},
+		{"valid invite", AccessInvite, newcomer, "/start valid", en.T("start.help"), true},
+		{"used invite", AccessInvite, newcomer, "/start used", en.T("access.invite_invalid"), false},
+		{"expired invite", AccessInvite, newcomer, "/start expired", en.T("access.invite_invalid"), false},
+		{"registered user in invite mode", AccessInvite, member, "/start", en.T("start.help"), true},
+		{"admin without an invite", AccessInvite, admin, "/start", en.T("start.help") + en.T("admin.help"), true},
+		{"admin outside the allowlist", AccessAllowlist, admin, "/start", en.T("start.help") + en.T("admin.help"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bannedAt := time.Now().Add(-time.Hour)
+			usedBy, usedAt := int64(99), time.Now().Add(-time.Hour)
+			users := knownUsers{users: map[int64]*domain.User{
+				member: {ID: 2, TelegramID: member, Language: "en"},
+				banned: {ID: 3, TelegramID: banned, Language: "en", BannedAt: &bannedAt},
+			}}
+			invites := memInvites{invites: map[string]*domain.Invite{
+				"valid":   {Code: "valid", ExpiresAt: time.Now().Add(time.Hour)},
+				"used":    {Code: "used", ExpiresAt: time.Now().Add(time.Hour), UsedBy: &usedBy, UsedAt: &usedAt},
+				"expired": {Code: "expired", ExpiresAt: time.Now().Add(-time.Hour)},
+			}}
+			access, err := newAccessPolicy(tt.mode, []int64{admin}, []int64{member})
+			if err != nil {
+				t.Fatalf("newAccessPolicy() error = %v", err)
+			}
+			h := &Handler{
+				userService:  service.NewUserService(users, nil),
+				adminService: service.NewAdminService(users, nil, invites),
+				access:       access,
+				stateManager: NewStateManager(),
+			}
+			b, api := newTestBot(t, h, h.checkAccess)
+
+			b.ProcessUpdate(context.Background(), &models.Update{Message: &models.Message{
+				Chat: models.Chat{ID: tt.from, Type: "private"},
+				From: &models.User{ID: tt.from, LanguageCode: "en"},
+				Text: tt.text,
+			}})
+
+			if sent := api.sent("sendMessage"); len(sent) != 1 || sent[0].params["text"] != tt.want {
+				t.Errorf("sent %v, want %q", sent, tt.want)
+			}
+			if registered := users.users[tt.from] != nil && users.users[tt.from].BannedAt == nil; registered != tt.wantMember {
+				t.Errorf("registered = %v, want %v", registered, tt.wantMember)
+			}
+		})
+	}
+}
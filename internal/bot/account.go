@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/i18n"
+)
+
+func (h *Handler) HandleExport(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	telegramID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	l := userLang(user)
+	export, err := h.accountService.Export(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to export account")
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("account.export_failed"),
+		})
+		return
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to encode export")
+		return
+	}
+
+	b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID:   chatID,
+		Document: &models.InputFileUpload{Filename: "export.json", Data: bytes.NewReader(data)},
+		Caption:  l.T("account.export_caption"),
+	})
+}
+
+func (h *Handler) HandleDeleteAccount(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	l := h.langFor(ctx, update.Message.From)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        l.T("account.delete_confirm"),
+		ReplyMarkup: deleteAccountKeyboard(l),
+	})
+}
+
+func (h *Handler) handleAccountCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, from *models.User, value string) {
+	if value != "delete" {
+		return
+	}
+
+	userID := from.ID
+	user, err := h.userService.GetByTelegramID(ctx, userID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	// The language is gone with the account, so it is read beforehand.
+	l := i18n.Parse(from.LanguageCode)
+	if user != nil && user.Language != "" {
+		l = userLang(user)
+	}
+
+	if user != nil {
+		if err := h.accountService.Delete(ctx, user); err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to delete account")
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   l.T("account.delete_failed"),
+			})
+			return
+		}
+		log.Info().Ctx(ctx).Int64("user_id", user.ID).Msg("account deleted")
+	}
+
+	h.stateManager.Delete(userID)
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      l.T("account.deleted"),
+	})
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        l.T("account.come_back"),
+		ReplyMarkup: &models.ReplyKeyboardRemove{RemoveKeyboard: true},
+	})
+}
@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+)
+
+// broadcastInterval keeps broadcasts under Telegram's limit of about 30
+// messages per second.
+const broadcastInterval = 40 * time.Millisecond
+
+func (h *Handler) HandleAdminStats(ctx context.Context, b *bot.Bot, update *models.Update) {
+	stats, err := h.adminService.Stats(ctx, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get admin stats")
+		return
+	}
+
+	l := h.langFor(ctx, update.Message.From)
+	text := l.T("admin.stats",
+		stats.Users.Total, stats.Users.Recent, stats.Users.Banned,
+		stats.Tasks["active"], stats.Tasks["overdue"], stats.Tasks["completed"], stats.Tasks["deleted"],
+	)
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    update.Message.Chat.ID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func (h *Handler) HandleAdminUser(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	l := h.langFor(ctx, update.Message.From)
+
+	telegramID, ok := telegramIDArg(update.Message.Text, "/admin_user")
+	if !ok {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.usage", "/admin_user <telegram id>")})
+		return
+	}
+
+	info, err := h.adminService.UserInfo(ctx, telegramID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user info")
+		return
+	}
+	if info == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.user_not_found")})
+		return
+	}
+
+	user := info.User
+	username := "—"
+	if user.Username != "" {
+		username = "@" + render.Escape(user.Username)
+	}
+	status := l.T("admin.status_active")
+	if user.IsBanned() {
+		status = l.T("admin.status_banned", user.BannedAt.Format("02.01.2006"))
+	}
+	if h.isAdmin(user.TelegramID) {
+		status += l.T("admin.status_admin")
+	}
+
+	text := l.T("admin.user",
+		user.TelegramID,
+		username,
+		user.CreatedAt.Format("02.01.2006"),
+		render.Escape(user.Timezone),
+		user.WorkStartHour, user.WorkEndHour,
+		info.ActiveTasks, info.CompletedTasks,
+		status,
+	)
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func (h *Handler) HandleBan(ctx context.Context, b *bot.Bot, update *models.Update) {
+	h.setBanned(ctx, b, update, "/ban", true)
+}
+
+func (h *Handler) HandleUnban(ctx context.Context, b *bot.Bot, update *models.Update) {
+	h.setBanned(ctx, b, update, "/unban", false)
+}
+
+func (h *Handler) setBanned(ctx context.Context, b *bot.Bot, update *models.Update, command string, banned bool) {
+	chatID := update.Message.Chat.ID
+	l := h.langFor(ctx, update.Message.From)
+
+	telegramID, ok := telegramIDArg(update.Message.Text, command)
+	if !ok {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.usage", command+" <telegram id>")})
+		return
+	}
+	if banned && h.isAdmin(telegramID) {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.ban_admin")})
+		return
+	}
+
+	user, err := h.adminService.SetBanned(ctx, telegramID, banned)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("telegram_id", telegramID).Msg("failed to change ban")
+		return
+	}
+	if user == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.user_not_found")})
+		return
+	}
+
+	text := l.T("admin.banned", telegramID)
+	if !banned {
+		text = l.T("admin.unbanned", telegramID)
+	}
+	log.Info().Ctx(ctx).Int64("admin_id", update.Message.From.ID).Int64("telegram_id", telegramID).Bool("banned", banned).Msg("user ban changed")
+
+	b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+}
+
+// HandleBroadcast shows the message and the number of recipients and waits
+// for confirmation.
+func (h *Handler) HandleBroadcast(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	l := h.langFor(ctx, update.Message.From)
+
+	text, ok := commandArgs(update.Message.Text, "/broadcast")
+	if !ok {
+		return
+	}
+	if text == "" {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("admin.usage", l.T("admin.broadcast_syntax"))})
+		return
+	}
+
+	recipients, err := h.adminService.BroadcastRecipients(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list broadcast recipients")
+		return
+	}
+
+	h.stateManager.Set(update.Message.From.ID, &UserState{Step: StateWaitingBroadcastConfirm, Broadcast: text})
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        l.T("admin.broadcast_preview", l.N("recipients", len(recipients)), text),
+		ReplyMarkup: broadcastKeyboard(l),
+	})
+}
+
+func (h *Handler) handleBroadcastCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, from *models.User, value string) {
+	userID := from.ID
+	state := h.stateManager.Get(userID)
+	if value != "confirm" || !h.isAdmin(userID) || state == nil || state.Step != StateWaitingBroadcastConfirm {
+		return
+	}
+	h.stateManager.Delete(userID)
+
+	recipients, err := h.adminService.BroadcastRecipients(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list broadcast recipients")
+		return
+	}
+
+	l := h.langFor(ctx, from)
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      l.T("admin.broadcast_started", l.N("recipients", len(recipients))),
+	})
+
+	go h.broadcast(context.WithoutCancel(ctx), b, l, chatID, recipients, state.Broadcast)
+}
+
+// broadcast sends text to every recipient at a rate Telegram accepts and
+// reports the result to the admin.
+func (h *Handler) broadcast(ctx context.Context, b *bot.Bot, l i18n.Lang, adminChatID int64, recipients []int64, text string) {
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	sent, failed := 0, 0
+	for _, telegramID := range recipients {
+		<-ticker.C
+		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{ChatID: telegramID, Text: text}); err != nil {
+			log.Warn().Ctx(ctx).Err(err).Int64("telegram_id", telegramID).Msg("failed to deliver broadcast")
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	log.Info().Ctx(ctx).Int("sent", sent).Int("failed", failed).Msg("broadcast finished")
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: adminChatID,
+		Text:   l.T("admin.broadcast_finished", sent, failed),
+	})
+}
+
+// HandleInvite issues a single-use deep link that registers a new user in
+// invite-only mode.
+func (h *Handler) HandleInvite(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+
+	invite, err := h.adminService.CreateInvite(ctx, update.Message.From.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to create invite")
+		return
+	}
+
+	me, err := b.GetMe(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get bot info")
+		return
+	}
+
+	l := h.langFor(ctx, update.Message.From)
+	text := l.T("admin.invite", invite.ExpiresAt.Format("02.01.2006"), me.Username, invite.Code)
+	if h.access.mode != AccessInvite {
+		text += l.T("admin.invite_not_required")
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+}
+
+// telegramIDArg parses "<command> <telegram id>".
+func telegramIDArg(text, command string) (int64, bool) {
+	args, ok := commandArgs(text, command)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(args, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
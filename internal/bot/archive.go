@@ -0,0 +1,173 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+)
+
+const archivePageSize = 8
+
+func (h *Handler) HandleArchive(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	telegramID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	text, markup, err := h.renderArchive(ctx, user, 0)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get completed tasks")
+		return
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	b.SendMessage(ctx, params)
+}
+
+func (h *Handler) renderArchive(ctx context.Context, user *domain.User, page int) (string, *models.InlineKeyboardMarkup, error) {
+	tasks, total, err := h.taskService.ListCompleted(ctx, user.ID, archivePageSize, page*archivePageSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pages := (total + archivePageSize - 1) / archivePageSize
+	if page > 0 && page >= pages {
+		page = max(pages-1, 0)
+		tasks, total, err = h.taskService.ListCompleted(ctx, user.ID, archivePageSize, page*archivePageSize)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	l := userLang(user)
+	if total == 0 {
+		return l.T("archive.empty"), nil, nil
+	}
+
+	digest := render.DigestView{
+		Icon:    "🗄",
+		Title:   l.T("archive.title"),
+		Summary: l.T("list.summary", total, page+1, pages),
+		Footer:  l.T("archive.reopen_hint"),
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+	for i, task := range tasks {
+		n := page*archivePageSize + i + 1
+
+		item := render.ItemView{
+			TaskView:  render.NewTaskView(task, user.WorkHoursPerDay),
+			Number:    n,
+			Completed: true,
+		}
+		if task.CompletedAt != nil {
+			completedAt := task.CompletedAt.In(user.Location())
+			item.CompletedAt = &completedAt
+		}
+		digest.Items = append(digest.Items, item)
+
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("↩️ %d", n),
+			CallbackData: fmt.Sprintf("reopen:%d:%d", task.ID, page),
+		})
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	if pages > 1 {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "◀", CallbackData: fmt.Sprintf("archive:%d", (page-1+pages)%pages)},
+			{Text: fmt.Sprintf("%d/%d", page+1, pages), CallbackData: "noop"},
+			{Text: "▶", CallbackData: fmt.Sprintf("archive:%d", (page+1)%pages)},
+		})
+	}
+
+	return render.Digest(l, userStyle(user), digest), &models.InlineKeyboardMarkup{InlineKeyboard: rows}, nil
+}
+
+func (h *Handler) handleArchiveCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 0 {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	h.editArchive(ctx, b, chatID, messageID, user, page)
+}
+
+func (h *Handler) handleReopenCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	taskID, pageValue, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	page, err := strconv.Atoi(pageValue)
+	if err != nil || page < 0 {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, taskID)
+	if task == nil || scope.user == nil {
+		return
+	}
+	if task.IsCompleted {
+		if _, err := h.taskService.Reopen(ctx, task.ID); err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to reopen task")
+			return
+		}
+	}
+
+	h.editArchive(ctx, b, chat.ID, messageID, scope.user, page)
+}
+
+func (h *Handler) editArchive(ctx context.Context, b *bot.Bot, chatID int64, messageID int, user *domain.User, page int) {
+	text, markup, err := h.renderArchive(ctx, user, page)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get completed tasks")
+		return
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	b.EditMessageText(ctx, params)
+}
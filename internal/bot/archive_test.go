@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/service"
+)
+
+// archivedTasks holds tasks by ID and keeps the updates made to them.
+type archivedTasks struct {
+	repository.TaskRepository
+	tasks map[int64]*domain.Task
+}
+
+func (r archivedTasks) GetByID(_ context.Context, id int64) (*domain.Task, error) {
+	if task, ok := r.tasks[id]; ok {
+		copied := *task
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r archivedTasks) Update(_ context.Context, task *domain.Task) error {
+	r.tasks[task.ID] = task
+	return nil
+}
+
+func (r archivedTasks) ListCompletedByUserID(context.Context, int64, int, int) ([]*domain.Task, int, error) {
+	return nil, 0, nil
+}
+
+// Only the user's own personal tasks can be reopened from the archive.
+func TestReopenCallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantReopen bool
+	}{
+		{"own task", "reopen:1:0", true},
+		{"another user's task", "reopen:2:0", false},
+		{"group task created by the user", "reopen:3:0", false},
+		{"unknown task", "reopen:4:0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completedAt := time.Now().Add(-time.Hour)
+			chatID := int64(-100)
+			tasks := archivedTasks{tasks: map[int64]*domain.Task{
+				1: {ID: 1, UserID: 7, IsCompleted: true, CompletedAt: &completedAt},
+				2: {ID: 2, UserID: 8, IsCompleted: true, CompletedAt: &completedAt},
+				3: {ID: 3, UserID: 7, ChatID: &chatID, IsCompleted: true, CompletedAt: &completedAt},
+			}}
+			users := knownUsers{users: map[int64]*domain.User{70: {ID: 7, TelegramID: 70}}}
+			h := &Handler{
+				userService:  service.NewUserService(users, nil),
+				taskService:  service.NewTaskService(tasks, noEvents{}, nil, service.TaskServiceConfig{}),
+				stateManager: NewStateManager(),
+			}
+			b, api := newTestBot(t, h)
+
+			b.ProcessUpdate(context.Background(), &models.Update{CallbackQuery: &models.CallbackQuery{
+				ID:   "1",
+				From: models.User{ID: 70},
+				Data: tt.data,
+				Message: models.MaybeInaccessibleMessage{
+					Type:    models.MaybeInaccessibleMessageTypeMessage,
+					Message: &models.Message{ID: 5, Chat: models.Chat{ID: 70, Type: "private"}},
+				},
+			}})
+
+			for id, task := range tasks.tasks {
+				if want := !(tt.wantReopen && id == 1); task.IsCompleted != want {
+					t.Errorf("task %d completed = %v, want %v", id, task.IsCompleted, want)
+				}
+			}
+			if edits := len(api.sent("editMessageText")); tt.wantReopen && edits != 1 {
+				t.Errorf("archive edited %d times, want once", edits)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+)
+
+// HandleAssign creates a task for another registered user. "/assign
+// @username" may be followed by quick-add text; "/assign" alone asks for the
+// assignee, who can also be picked by sharing a contact.
+func (h *Handler) HandleAssign(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	args, ok := commandArgs(update.Message.Text, "/assign")
+	if !ok {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, userID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if args == "" {
+		l := userLang(user)
+		h.stateManager.Set(userID, &UserState{Step: StateWaitingAssignee})
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("assign.ask_assignee"),
+			ReplyMarkup: cancelKeyboard(l),
+		})
+		return
+	}
+
+	username, rest, _ := strings.Cut(args, " ")
+	assignee, err := h.userService.GetByUsername(ctx, username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to find assignee")
+		return
+	}
+	if !h.checkAssignee(ctx, b, chatID, user, assignee) {
+		return
+	}
+
+	state := h.quickAddState(ctx, b, chatID, strings.TrimSpace(rest), user)
+	state.Assignee = assignee
+	h.continueAddFlow(ctx, b, chatID, user, state)
+}
+
+// handleAssigneeInput takes the assignee as a @username or a shared contact
+// and continues with the usual task wizard.
+func (h *Handler) handleAssigneeInput(ctx context.Context, b *bot.Bot, update *models.Update, user *domain.User, state *UserState) {
+	msg := update.Message
+
+	var assignee *domain.User
+	var err error
+	switch {
+	case msg.Contact != nil && msg.Contact.UserID != 0:
+		assignee, err = h.userService.GetByTelegramID(ctx, msg.Contact.UserID)
+	case strings.HasPrefix(msg.Text, "@"):
+		assignee, err = h.userService.GetByUsername(ctx, msg.Text)
+	default:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: msg.Chat.ID,
+			Text:   userLang(user).T("assign.ask_assignee"),
+		})
+		return
+	}
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to find assignee")
+		return
+	}
+	if !h.checkAssignee(ctx, b, msg.Chat.ID, user, assignee) {
+		return
+	}
+
+	state.Assignee = assignee
+	h.continueAddFlow(ctx, b, msg.Chat.ID, user, state)
+}
+
+// checkAssignee tells the user why a task cannot be assigned to assignee.
+// Only registered users who are not banned can receive tasks.
+func (h *Handler) checkAssignee(ctx context.Context, b *bot.Bot, chatID int64, user, assignee *domain.User) bool {
+	var key string
+	switch {
+	case assignee == nil || assignee.IsBanned():
+		key = "assign.not_found"
+	case assignee.ID == user.ID:
+		key = "assign.self"
+	default:
+		return true
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   userLang(user).T(key),
+	})
+	return false
+}
+
+func (h *Handler) assignTaskFromState(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User, state *UserState) {
+	assignee := state.Assignee
+	l, assigneeLang := userLang(user), userLang(assignee)
+
+	task, err := h.taskService.Assign(ctx, user.ID, assignee.ID, state.Description, state.Deadline, state.Importance, state.Frequency)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to assign task")
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("add.failed"),
+		})
+		return
+	}
+
+	h.stateManager.Delete(user.TelegramID)
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      assignee.TelegramID,
+		Text:        confirmation(assigneeLang, userStyle(assignee), assigneeLang.T("assign.received", user.Mention()), task, assignee.WorkHoursPerDay),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: assignmentKeyboard(assigneeLang, task.ID),
+	})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to send assignment")
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        confirmation(l, userStyle(user), l.T("assign.sent", assignee.Mention()), task, user.WorkHoursPerDay),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: mainMenuKeyboard(l),
+	})
+}
+
+func (h *Handler) handleAssignCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	answer, id, _ := strings.Cut(value, ":")
+	if answer != "accept" && answer != "decline" {
+		return
+	}
+	taskID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	accept := answer == "accept"
+	task, err := h.taskService.RespondToAssignment(ctx, taskID, user.ID, accept)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to answer assignment")
+		return
+	}
+	if task == nil {
+		b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+		})
+		return
+	}
+
+	l := userLang(user)
+	text, notice := l.T("assign.declined"), "assign.declined_notice"
+	var markup models.ReplyMarkup
+	if accept {
+		text = confirmation(l, userStyle(user), l.T("assign.accepted"), task, user.WorkHoursPerDay)
+		notice = "assign.accepted_notice"
+		markup = reminderKeyboard(l, task.ID)
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+
+	assigner, err := h.userService.GetByID(ctx, *task.AssignedBy)
+	if err != nil || assigner == nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get assigner")
+		return
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    assigner.TelegramID,
+		Text:      userLang(assigner).T(notice, render.Escape(user.Mention()), render.Escape(task.Description)),
+		ParseMode: models.ParseModeHTML,
+	})
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/service"
+)
+
+// Tasks can only be assigned to other registered users who are not banned.
+func TestAssignCommand(t *testing.T) {
+	en := i18n.Parse("en")
+
+	tests := []struct {
+		name         string
+		text         string
+		want         string
+		wantAssignee int64
+	}{
+		{"registered user", "/assign @bob Fix the sink", en.T("add.ask_deadline"), 8},
+		{"unknown user", "/assign @nobody Fix the sink", en.T("assign.not_found"), 0},
+		{"banned user", "/assign @mallory Fix the sink", en.T("assign.not_found"), 0},
+		{"oneself", "/assign @alice Fix the sink", en.T("assign.self"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bannedAt := time.Now().Add(-time.Hour)
+			users := knownUsers{users: map[int64]*domain.User{
+				70: {ID: 7, TelegramID: 70, Username: "alice", Timezone: "UTC", Language: "en"},
+				80: {ID: 8, TelegramID: 80, Username: "bob"},
+				90: {ID: 9, TelegramID: 90, Username: "mallory", BannedAt: &bannedAt},
+			}}
+			h := &Handler{userService: service.NewUserService(users, nil), stateManager: NewStateManager()}
+			b, api := newTestBot(t, h)
+
+			b.ProcessUpdate(context.Background(), &models.Update{Message: &models.Message{
+				Chat: models.Chat{ID: 70, Type: "private"},
+				From: &models.User{ID: 70, Username: "alice"},
+				Text: tt.text,
+			}})
+
+			if sent := api.sent("sendMessage"); len(sent) != 1 || sent[0].params["text"] != tt.want {
+				t.Errorf("sent %v, want %q", sent, tt.want)
+			}
+
+			var assignee int64
+			if state := h.stateManager.Get(70); state != nil && state.Assignee != nil {
+				assignee = state.Assignee.ID
+			}
+			if assignee != tt.wantAssignee {
+				t.Errorf("assignee = %d, want %d", assignee, tt.wantAssignee)
+			}
+		})
+	}
+}
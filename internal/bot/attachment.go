@@ -0,0 +1,377 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+)
+
+// attachmentIcons are shown next to attachments in the list.
+var attachmentIcons = map[domain.AttachmentKind]string{
+	domain.AttachmentPhoto:    "🖼",
+	domain.AttachmentDocument: "📄",
+	domain.AttachmentVoice:    "🎤",
+	domain.AttachmentVideo:    "🎬",
+	domain.AttachmentAudio:    "🎵",
+	domain.AttachmentMessage:  "✉️",
+	domain.AttachmentLink:     "🔗",
+}
+
+// handleAttachmentsCallback shows a task's attachments; value is
+// "<task id>:<list view>".
+func (h *Handler) handleAttachmentsCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil {
+		return
+	}
+
+	h.editAttachments(ctx, b, chat.ID, messageID, scope, task, view)
+}
+
+func (h *Handler) editAttachments(ctx context.Context, b *bot.Bot, chatID int64, messageID int, scope *taskScope, task *domain.Task, view listView) {
+	attachments, err := h.attachmentService.List(ctx, task.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to list attachments")
+		return
+	}
+
+	l := scope.lang()
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        formatAttachments(l, task, attachments),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: attachmentsKeyboard(l, task, attachments, view, scope.chat == nil),
+	})
+}
+
+// handleAttachmentSendCallback sends attachments to the chat: "att_all" all
+// of a task's, value being the task ID, and "att_get" one, value being the
+// attachment ID.
+func (h *Handler) handleAttachmentSendCallback(ctx context.Context, b *bot.Bot, chat models.Chat, from *models.User, action, value string) {
+	var (
+		scope       *taskScope
+		attachments []*domain.Attachment
+	)
+	switch action {
+	case "att_all":
+		var task *domain.Task
+		scope, task = h.taskForCallback(ctx, chat, from, value)
+		if task == nil {
+			return
+		}
+		var err error
+		attachments, err = h.attachmentService.List(ctx, task.ID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to list attachments")
+			return
+		}
+	case "att_get":
+		var attachment *domain.Attachment
+		scope, attachment = h.attachmentForCallback(ctx, chat, from, value)
+		if attachment == nil {
+			return
+		}
+		attachments = []*domain.Attachment{attachment}
+	}
+
+	l := scope.lang()
+	for _, attachment := range attachments {
+		if err := sendAttachment(ctx, b, chat.ID, attachment); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("attachment_id", attachment.ID).Msg("failed to send attachment")
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chat.ID,
+				Text:   l.T("attachment.send_failed", attachmentLabel(l, attachment)),
+			})
+		}
+	}
+}
+
+// handleAttachmentDeleteCallback removes an attachment; value is
+// "<attachment id>:<list view>".
+func (h *Handler) handleAttachmentDeleteCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, attachment := h.attachmentForCallback(ctx, chat, from, id)
+	if attachment == nil {
+		return
+	}
+	task, err := h.taskService.GetByID(ctx, attachment.TaskID)
+	if err != nil || task == nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", attachment.TaskID).Msg("failed to get task")
+		return
+	}
+
+	if err := h.attachmentService.Delete(ctx, attachment.ID); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("attachment_id", attachment.ID).Msg("failed to delete attachment")
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: scope.lang().T("attachment.failed")})
+		return
+	}
+
+	h.editAttachments(ctx, b, chat.ID, messageID, scope, task, view)
+}
+
+// handleAttachCallback asks for files to attach to a task; value is the
+// task ID. Attaching needs replies, so it only works in private chats.
+func (h *Handler) handleAttachCallback(ctx context.Context, b *bot.Bot, chat models.Chat, from *models.User, value string) {
+	scope, task := h.taskForCallback(ctx, chat, from, value)
+	if task == nil || scope.chat != nil {
+		return
+	}
+	l := scope.lang()
+
+	h.stateManager.Set(from.ID, &UserState{Step: StateWaitingAttachment, TaskID: task.ID})
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chat.ID,
+		Text:        l.T("attachment.ask", task.Description),
+		ReplyMarkup: attachDoneKeyboard(l, task.ID),
+	})
+}
+
+// handleAttachDoneCallback ends attaching and shows the task's attachments;
+// value is the task ID.
+func (h *Handler) handleAttachDoneCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	if state := h.stateManager.Get(from.ID); state != nil && state.Step == StateWaitingAttachment {
+		h.stateManager.Delete(from.ID)
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, value)
+	if task == nil {
+		return
+	}
+	h.editAttachments(ctx, b, chat.ID, messageID, scope, task, defaultListView())
+}
+
+// handleAttachmentInput attaches what the message holds to the task from
+// state. It returns false for a message with nothing to attach, such as
+// plain text or a menu button.
+func (h *Handler) handleAttachmentInput(ctx context.Context, b *bot.Bot, msg *models.Message, state *UserState) bool {
+	attachments := attachmentsFromMessage(msg)
+	if len(attachments) == 0 {
+		return false
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, msg.From.ID, msg.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return true
+	}
+	l := userLang(user)
+	scope := &taskScope{user: user}
+
+	task, err := h.taskService.GetByID(ctx, state.TaskID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", state.TaskID).Msg("failed to get task")
+		return true
+	}
+	if task == nil || !scope.owns(task) {
+		h.stateManager.Delete(user.TelegramID)
+		return true
+	}
+
+	for _, attachment := range attachments {
+		err := h.attachmentService.Add(ctx, task, attachment)
+		if errors.Is(err, domain.ErrTooManyAttachments) {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:      msg.Chat.ID,
+				Text:        l.T("attachment.too_many", domain.MaxAttachmentsPerTask),
+				ReplyMarkup: attachDoneKeyboard(l, task.ID),
+			})
+			return true
+		}
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to add attachment")
+			b.SendMessage(ctx, &bot.SendMessageParams{ChatID: msg.Chat.ID, Text: l.T("attachment.failed")})
+			return true
+		}
+	}
+
+	count, err := h.attachmentService.Count(ctx, task.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to count attachments")
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      msg.Chat.ID,
+		Text:        l.T("attachment.added", attachmentLabel(l, attachments[0]), count, domain.MaxAttachmentsPerTask),
+		ReplyMarkup: attachDoneKeyboard(l, task.ID),
+	})
+	return true
+}
+
+// attachmentForCallback loads the attachment a button refers to, or returns
+// nil when it or its task is gone or the task is not the scope's.
+func (h *Handler) attachmentForCallback(ctx context.Context, chat models.Chat, from *models.User, value string) (*taskScope, *domain.Attachment) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	attachment, err := h.attachmentService.GetByID(ctx, id)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("attachment_id", id).Msg("failed to get attachment")
+		return nil, nil
+	}
+	if attachment == nil {
+		return nil, nil
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, strconv.FormatInt(attachment.TaskID, 10))
+	if task == nil {
+		return nil, nil
+	}
+	return scope, attachment
+}
+
+// attachmentsFromMessage returns what a message sent to the bot can attach:
+// a forwarded message as a whole, a file, or the links in a text.
+func attachmentsFromMessage(msg *models.Message) []*domain.Attachment {
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+
+	if msg.ForwardOrigin != nil {
+		return []*domain.Attachment{{
+			Kind:      domain.AttachmentMessage,
+			Caption:   render.Truncate(strings.Join(strings.Fields(text), " "), 100),
+			ChatID:    msg.Chat.ID,
+			MessageID: msg.ID,
+		}}
+	}
+
+	switch {
+	case len(msg.Photo) > 0:
+		// Sizes come smallest first.
+		photo := msg.Photo[len(msg.Photo)-1]
+		return []*domain.Attachment{{Kind: domain.AttachmentPhoto, FileID: photo.FileID, Caption: msg.Caption}}
+	case msg.Document != nil:
+		return []*domain.Attachment{{Kind: domain.AttachmentDocument, FileID: msg.Document.FileID, FileName: msg.Document.FileName, Caption: msg.Caption}}
+	case msg.Voice != nil:
+		return []*domain.Attachment{{Kind: domain.AttachmentVoice, FileID: msg.Voice.FileID, Caption: msg.Caption}}
+	case msg.Video != nil:
+		return []*domain.Attachment{{Kind: domain.AttachmentVideo, FileID: msg.Video.FileID, FileName: msg.Video.FileName, Caption: msg.Caption}}
+	case msg.Audio != nil:
+		return []*domain.Attachment{{Kind: domain.AttachmentAudio, FileID: msg.Audio.FileID, FileName: msg.Audio.FileName, Caption: msg.Caption}}
+	}
+
+	var attachments []*domain.Attachment
+	for _, link := range domain.ExtractLinks(text) {
+		attachments = append(attachments, &domain.Attachment{Kind: domain.AttachmentLink, URL: link})
+	}
+	return attachments
+}
+
+// sendAttachment sends an attachment back: files by file ID, forwarded
+// messages by copying them from the chat they were forwarded to.
+func sendAttachment(ctx context.Context, b *bot.Bot, chatID int64, a *domain.Attachment) error {
+	file := &models.InputFileString{Data: a.FileID}
+
+	var err error
+	switch a.Kind {
+	case domain.AttachmentPhoto:
+		_, err = b.SendPhoto(ctx, &bot.SendPhotoParams{ChatID: chatID, Photo: file, Caption: a.Caption})
+	case domain.AttachmentDocument:
+		_, err = b.SendDocument(ctx, &bot.SendDocumentParams{ChatID: chatID, Document: file, Caption: a.Caption})
+	case domain.AttachmentVoice:
+		_, err = b.SendVoice(ctx, &bot.SendVoiceParams{ChatID: chatID, Voice: file, Caption: a.Caption})
+	case domain.AttachmentVideo:
+		_, err = b.SendVideo(ctx, &bot.SendVideoParams{ChatID: chatID, Video: file, Caption: a.Caption})
+	case domain.AttachmentAudio:
+		_, err = b.SendAudio(ctx, &bot.SendAudioParams{ChatID: chatID, Audio: file, Caption: a.Caption})
+	case domain.AttachmentMessage:
+		_, err = b.CopyMessage(ctx, &bot.CopyMessageParams{
+			ChatID:     chatID,
+			FromChatID: strconv.FormatInt(a.ChatID, 10),
+			MessageID:  a.MessageID,
+		})
+	case domain.AttachmentLink:
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: a.URL})
+	default:
+		err = fmt.Errorf("unknown attachment kind %q", a.Kind)
+	}
+	return err
+}
+
+// attachmentLabel is the attachment's label, or what kind of attachment it
+// is when it has none.
+func attachmentLabel(l i18n.Lang, a *domain.Attachment) string {
+	if label := a.Label(); label != "" {
+		return label
+	}
+	return l.T("attachment.kind." + string(a.Kind))
+}
+
+func formatAttachments(l i18n.Lang, task *domain.Task, attachments []*domain.Attachment) string {
+	var sb strings.Builder
+	sb.WriteString(l.T("attachment.header", render.Escape(render.Truncate(task.Description, 60))))
+
+	if len(attachments) == 0 {
+		sb.WriteString(l.T("attachment.none"))
+	}
+	for i, a := range attachments {
+		sb.WriteString(l.T("attachment.entry", i+1, attachmentIcons[a.Kind], render.Escape(render.Truncate(attachmentLabel(l, a), 60))))
+	}
+	return sb.String()
+}
+
+// attachmentsKeyboard has a button to send and one to remove each
+// attachment. Attaching is offered only in private chats.
+func attachmentsKeyboard(l i18n.Lang, task *domain.Task, attachments []*domain.Attachment, view listView, canAttach bool) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	for i, a := range attachments {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: fmt.Sprintf("📤 %d. %s", i+1, render.Truncate(attachmentLabel(l, a), 30)), CallbackData: fmt.Sprintf("att_get:%d", a.ID)},
+			{Text: "❌", CallbackData: fmt.Sprintf("att_del:%d:%s", a.ID, view)},
+		})
+	}
+
+	var actions []models.InlineKeyboardButton
+	if len(attachments) > 1 {
+		actions = append(actions, models.InlineKeyboardButton{Text: l.T("button.send_all"), CallbackData: fmt.Sprintf("att_all:%d", task.ID)})
+	}
+	if canAttach && len(attachments) < domain.MaxAttachmentsPerTask {
+		actions = append(actions, models.InlineKeyboardButton{Text: l.T("button.attach"), CallbackData: fmt.Sprintf("att_add:%d", task.ID)})
+	}
+	if len(actions) > 0 {
+		rows = append(rows, actions)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: l.T("button.back"), CallbackData: fmt.Sprintf("task:%d:%s", task.ID, view)},
+	})
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func attachDoneKeyboard(l i18n.Lang, taskID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("button.attach_done"), CallbackData: fmt.Sprintf("att_done:%d", taskID)}},
+		},
+	}
+}
@@ -2,28 +2,63 @@ package bot
 
 import (
 	"context"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"github.com/rs/zerolog/log"
 
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/metrics"
 	"telegram-reminder-bot/internal/service"
+	"telegram-reminder-bot/internal/tracing"
 )
 
+// pollTimeout matches the library default; the HTTP client is replaced only
+// to measure and trace Bot API calls.
+const pollTimeout = time.Minute
+
+type Config struct {
+	Token string
+	// PublicURL is the external base URL of the HTTP server, used for links
+	// such as the calendar feed. Empty when the server is not exposed.
+	PublicURL string
+	// AdminIDs are the Telegram IDs allowed to use admin commands.
+	AdminIDs []int64
+	// AccessMode is AccessOpen, AccessAllowlist or AccessInvite.
+	AccessMode string
+	// AllowedIDs are the Telegram IDs that may register in allowlist mode.
+	AllowedIDs []int64
+}
+
 type Bot struct {
 	bot     *bot.Bot
 	handler *Handler
 }
 
-func New(token string, userService *service.UserService, taskService *service.TaskService) (*Bot, error) {
-	handler := NewHandler(userService, taskService)
+func New(cfg Config, userService *service.UserService, taskService *service.TaskService, reminderService *service.ReminderService, quietService *service.QuietService, dependencyService *service.DependencyService, attachmentService *service.AttachmentService, statsService *service.StatsService, accountService *service.AccountService, webhookService *service.WebhookService, adminService *service.AdminService, chatService *service.ChatService) (*Bot, error) {
+	access, err := newAccessPolicy(cfg.AccessMode, cfg.AdminIDs, cfg.AllowedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := NewHandler(userService, taskService, reminderService, quietService, dependencyService, attachmentService, statsService, accountService, webhookService, adminService, chatService)
+	handler.publicURL = strings.TrimRight(cfg.PublicURL, "/")
+	handler.access = access
 
 	opts := []bot.Option{
 		bot.WithDefaultHandler(handler.defaultHandler),
 		bot.WithDebug(),
+		bot.WithMiddlewares(traceUpdate, handler.checkAccess, handler.routeGroup),
+		bot.WithHTTPClient(pollTimeout, &http.Client{
+			Timeout:   pollTimeout,
+			Transport: tracing.TelegramTransport(metrics.TelegramTransport(nil)),
+		}),
 	}
 
-	b, err := bot.New(token, opts...)
+	b, err := bot.New(cfg.Token, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -31,11 +66,7 @@ func New(token string, userService *service.UserService, taskService *service.Ta
 	// Delete webhook to ensure long polling works
 	b.DeleteWebhook(context.Background(), &bot.DeleteWebhookParams{})
 
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, handler.HandleStart)
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/add", bot.MatchTypeExact, handler.HandleAdd)
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/list", bot.MatchTypeExact, handler.HandleList)
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/settings", bot.MatchTypeExact, handler.HandleSettings)
-	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "", bot.MatchTypePrefix, handler.HandleCallback)
+	handler.register(b)
 
 	return &Bot{
 		bot:     b,
@@ -43,23 +74,69 @@ func New(token string, userService *service.UserService, taskService *service.Ta
 	}, nil
 }
 
+// register routes commands and button presses to their handlers.
+func (h *Handler) register(b *bot.Bot) {
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypePrefix, h.HandleStart)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/add", bot.MatchTypePrefix, h.HandleAdd)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/assign", bot.MatchTypePrefix, h.HandleAssign)
+	b.RegisterHandlerMatchFunc(exactCommand("/list"), h.HandleList)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/archive", bot.MatchTypeExact, h.HandleArchive)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/done", bot.MatchTypeExact, h.HandleArchive)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/stats", bot.MatchTypeExact, h.HandleStats)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/chart", bot.MatchTypeExact, h.HandleChart)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/export_ics", bot.MatchTypeExact, h.HandleExportICS)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/import", bot.MatchTypeExact, h.HandleImport)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/export", bot.MatchTypeExact, h.HandleExport)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/delete_account", bot.MatchTypeExact, h.HandleDeleteAccount)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/token", bot.MatchTypePrefix, h.HandleToken)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/webhook", bot.MatchTypePrefix, h.HandleWebhook)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/settings", bot.MatchTypeExact, h.HandleSettings)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/dnd", bot.MatchTypePrefix, h.HandleDND)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/quiet", bot.MatchTypePrefix, h.HandleQuiet)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/admin_stats", bot.MatchTypeExact, h.adminOnly(h.HandleAdminStats))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/admin_user", bot.MatchTypePrefix, h.adminOnly(h.HandleAdminUser))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/ban", bot.MatchTypePrefix, h.adminOnly(h.HandleBan))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/unban", bot.MatchTypePrefix, h.adminOnly(h.HandleUnban))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/broadcast", bot.MatchTypePrefix, h.adminOnly(h.HandleBroadcast))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/invite", bot.MatchTypeExact, h.adminOnly(h.HandleInvite))
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "", bot.MatchTypePrefix, h.HandleCallback)
+}
+
 func (b *Bot) Start(ctx context.Context) {
 	log.Info().Msg("starting telegram bot")
 	b.bot.Start(ctx)
 }
 
-func (b *Bot) SendReminder(ctx context.Context, telegramID int64, message string, taskID int64) error {
-	_, err := b.bot.SendMessage(ctx, &bot.SendMessageParams{
+func (b *Bot) SendReminder(ctx context.Context, telegramID int64, lang i18n.Lang, message string, taskID int64) error {
+	attachments, err := b.handler.attachmentService.Count(ctx, taskID)
+	if err != nil {
+		// The reminder matters more than its attachments button.
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", taskID).Msg("failed to count attachments")
+	}
+
+	_, err = b.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      telegramID,
 		Text:        message,
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: reminderKeyboard(taskID),
+		ReplyMarkup: sentReminderKeyboard(lang, taskID, attachments),
+	})
+	return err
+}
+
+func (b *Bot) SendNotification(ctx context.Context, telegramID int64, message string) error {
+	_, err := b.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    telegramID,
+		Text:      message,
+		ParseMode: models.ParseModeHTML,
 	})
 	return err
 }
 
 func (h *Handler) defaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.Message != nil {
+	switch {
+	case update.Message != nil:
 		h.HandleMessage(ctx, b, update)
+	case update.MyChatMember != nil:
+		h.handleMyChatMember(ctx, b, update)
 	}
 }
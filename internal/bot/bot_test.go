@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-telegram/bot"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// telegramCall is a Bot API request the bot made: the method and its
+// form fields.
+type telegramCall struct {
+	method string
+	params map[string]string
+}
+
+// fakeTelegram stands in for the Bot API. It records every request and
+// answers with a generic message, or true for methods that return a bool.
+type fakeTelegram struct {
+	mu    sync.Mutex
+	calls []telegramCall
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	call := telegramCall{method: method, params: map[string]string{}}
+	if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+		form, err := multipart.NewReader(r.Body, params["boundary"]).ReadForm(1 << 20)
+		if err == nil {
+			for k, v := range form.Value {
+				call.params[k] = v[0]
+			}
+		}
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch method {
+	case "getMe":
+		w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"Reminders","username":"ReminderBot"}}`))
+	case "answerCallbackQuery", "deleteMessage":
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	default:
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`))
+	}
+}
+
+// sent returns the requests made with method.
+func (f *fakeTelegram) sent(method string) []telegramCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []telegramCall
+	for _, c := range f.calls {
+		if c.method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// newTestBot returns a bot with h's handlers and group routing, after the
+// given middlewares, that talks to a fake Bot API.
+func newTestBot(t *testing.T, h *Handler, middlewares ...bot.Middleware) (*bot.Bot, *fakeTelegram) {
+	t.Helper()
+
+	api := &fakeTelegram{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("1:test",
+		bot.WithServerURL(srv.URL),
+		bot.WithSkipGetMe(),
+		bot.WithDefaultHandler(h.defaultHandler),
+		bot.WithMiddlewares(append(middlewares, h.routeGroup)...),
+	)
+	if err != nil {
+		t.Fatalf("bot.New() error = %v", err)
+	}
+	h.register(b)
+	return b, api
+}
+
+// knownUsers keeps users by their Telegram ID.
+type knownUsers struct {
+	repository.UserRepository
+	users map[int64]*domain.User
+}
+
+func (r knownUsers) GetByTelegramID(_ context.Context, telegramID int64) (*domain.User, error) {
+	return r.users[telegramID], nil
+}
+
+func (r knownUsers) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r knownUsers) Create(_ context.Context, user *domain.User) error {
+	r.users[user.TelegramID] = user
+	return nil
+}
+
+func (r knownUsers) Update(_ context.Context, user *domain.User) error {
+	r.users[user.TelegramID] = user
+	return nil
+}
+
+type noEvents struct{ repository.EventRepository }
+
+func (noEvents) Create(context.Context, *domain.TaskEvent) error {
+	return nil
+}
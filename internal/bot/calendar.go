@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/ical"
+	"telegram-reminder-bot/internal/render"
+)
+
+func (h *Handler) HandleExportICS(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	telegramID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	l := userLang(user)
+	tasks, err := h.taskService.GetActiveByUserID(ctx, user.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get tasks")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, ical.TaskCalendar(tasks, user, time.Now())); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to encode calendar")
+		return
+	}
+
+	_, err = b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID:   chatID,
+		Document: &models.InputFileUpload{Filename: "tasks.ics", Data: &buf},
+		Caption:  l.T("calendar.caption", len(tasks)),
+	})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to send calendar")
+		return
+	}
+
+	if h.publicURL == "" {
+		return
+	}
+
+	token, err := h.userService.CalendarToken(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get calendar token")
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        h.calendarFeedMessage(l, token),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: calendarKeyboard(l),
+	})
+}
+
+func (h *Handler) handleCalendarCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	if value != "rotate" || h.publicURL == "" {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	token, err := h.userService.RotateCalendarToken(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to rotate calendar token")
+		return
+	}
+
+	l := userLang(user)
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        l.T("calendar.rotated") + h.calendarFeedMessage(l, token),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: calendarKeyboard(l),
+	})
+}
+
+func (h *Handler) calendarFeedMessage(l i18n.Lang, token string) string {
+	return l.T("calendar.feed", render.Escape(calendarFeedURL(h.publicURL, token)))
+}
+
+func calendarFeedURL(publicURL, token string) string {
+	return publicURL + "/calendar/" + token + ".ics"
+}
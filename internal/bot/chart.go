@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/chart"
+	"telegram-reminder-bot/internal/domain"
+)
+
+const (
+	chartDays         = 14
+	chartReminderDays = 30
+)
+
+func (h *Handler) HandleChart(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	l := h.langFor(ctx, update.Message.From)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        l.T("chart.ask"),
+		ReplyMarkup: chartKeyboard(l),
+	})
+}
+
+func (h *Handler) handleChartCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	c, caption, err := h.buildChart(ctx, user, value)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Str("chart", value).Msg("failed to build chart")
+		return
+	}
+	if c == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := chart.Render(&buf, *c); err != nil {
+		log.Error().Ctx(ctx).Err(err).Str("chart", value).Msg("failed to render chart")
+		return
+	}
+
+	_, err = b.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID:  chatID,
+		Photo:   &models.InputFileUpload{Filename: value + ".png", Data: &buf},
+		Caption: caption,
+	})
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to send chart")
+	}
+}
+
+func (h *Handler) buildChart(ctx context.Context, user *domain.User, kind string) (*chart.Chart, string, error) {
+	l := userLang(user)
+	today := time.Now().In(user.Location())
+
+	dayLabels := make([]string, chartDays)
+	for i := range dayLabels {
+		dayLabels[i] = today.AddDate(0, 0, i-(chartDays-1)).Format("02.01")
+	}
+
+	switch kind {
+	case "completions":
+		counts, err := h.statsService.CompletionsPerDay(ctx, user, chartDays)
+		if err != nil {
+			return nil, "", err
+		}
+		return &chart.Chart{Kind: chart.KindBar, Labels: dayLabels, Values: toFloats(counts)},
+			l.T("chart.completions", l.N("days", chartDays)), nil
+
+	case "burndown":
+		counts, err := h.statsService.OpenTasksPerDay(ctx, user, chartDays)
+		if err != nil {
+			return nil, "", err
+		}
+		return &chart.Chart{Kind: chart.KindLine, Labels: dayLabels, Values: toFloats(counts)},
+			l.T("chart.burndown", l.N("days", chartDays)), nil
+
+	case "hours":
+		counts, err := h.statsService.RemindersPerHour(ctx, user, chartReminderDays)
+		if err != nil {
+			return nil, "", err
+		}
+		labels := make([]string, len(counts))
+		for i := range labels {
+			labels[i] = fmt.Sprintf("%02d", i)
+		}
+		return &chart.Chart{Kind: chart.KindBar, Labels: labels, Values: toFloats(counts[:])},
+			l.T("chart.hours", l.N("days", chartReminderDays), user.Timezone), nil
+	}
+
+	return nil, "", nil
+}
+
+func toFloats(values []int) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = float64(v)
+	}
+	return result
+}
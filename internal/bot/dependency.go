@@ -0,0 +1,199 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+)
+
+// maxDependencyCandidates is how many tasks the dependencies screen offers
+// to wait for, the ones with the nearest deadlines.
+const maxDependencyCandidates = 8
+
+// dependencies returns the dependency graph of the scope's tasks.
+func (h *Handler) dependencies(ctx context.Context, scope *taskScope) (*domain.Dependencies, error) {
+	if scope.chat != nil {
+		return h.dependencyService.ForChat(ctx, scope.chat.ID)
+	}
+	return h.dependencyService.ForUser(ctx, scope.user.ID)
+}
+
+// taskView is render.NewTaskView with the chains of tasks the task waits for
+// taken from deps, which may be nil.
+func taskView(task *domain.Task, deps *domain.Dependencies, workHoursPerDay int) render.TaskView {
+	view := render.NewTaskView(task, workHoursPerDay)
+	if deps == nil {
+		return view
+	}
+	for _, chain := range deps.Chains(task.ID) {
+		descriptions := make([]string, len(chain))
+		for i, blocker := range chain {
+			descriptions[i] = blocker.Description
+		}
+		view.BlockedBy = append(view.BlockedBy, descriptions)
+	}
+	return view
+}
+
+// handleDependenciesCallback shows what a task waits for with buttons to
+// change it; value is "<task id>:<list view>".
+func (h *Handler) handleDependenciesCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil {
+		return
+	}
+
+	h.editDependencies(ctx, b, chat.ID, messageID, scope, task, view)
+}
+
+// handleDependencyCallback adds or removes a dependency; action is
+// "dep_add" or "dep_del" and value is "<task id>:<blocker id>:<list view>".
+func (h *Handler) handleDependencyCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, action, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	blockerValue, rest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return
+	}
+	blockerID, err := strconv.ParseInt(blockerValue, 10, 64)
+	if err != nil {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil {
+		return
+	}
+	l := scope.lang()
+
+	switch action {
+	case "dep_add":
+		blocker, err := h.taskService.GetByID(ctx, blockerID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", blockerID).Msg("failed to get task")
+			return
+		}
+		if blocker == nil || !scope.owns(blocker) {
+			return
+		}
+
+		err = h.dependencyService.Add(ctx, task, blocker)
+		switch {
+		case errors.Is(err, domain.ErrDependencyCycle):
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chat.ID,
+				Text:   l.T("dependency.cycle", blocker.Description),
+			})
+			return
+		case errors.Is(err, domain.ErrInvalidDependency):
+			return
+		case err != nil:
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to add dependency")
+			b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: l.T("dependency.failed")})
+			return
+		}
+	case "dep_del":
+		if err := h.dependencyService.Remove(ctx, task, blockerID); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to remove dependency")
+			b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: l.T("dependency.failed")})
+			return
+		}
+	}
+
+	h.editDependencies(ctx, b, chat.ID, messageID, scope, task, view)
+}
+
+func (h *Handler) editDependencies(ctx context.Context, b *bot.Bot, chatID int64, messageID int, scope *taskScope, task *domain.Task, view listView) {
+	deps, err := h.dependencies(ctx, scope)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get dependencies")
+		return
+	}
+
+	l := scope.lang()
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        formatDependencies(l, task, deps),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: dependenciesKeyboard(l, task, deps, view),
+	})
+}
+
+func formatDependencies(l i18n.Lang, task *domain.Task, deps *domain.Dependencies) string {
+	var sb strings.Builder
+	sb.WriteString(l.T("dependency.header", render.Escape(render.Truncate(task.Description, 60))))
+
+	chains := taskView(task, deps, 0).BlockedBy
+	if len(chains) == 0 {
+		sb.WriteString(l.T("dependency.none"))
+	} else {
+		sb.WriteString(l.T("dependency.waits_for"))
+		for _, chain := range chains {
+			for i, description := range chain {
+				chain[i] = render.Escape(render.Truncate(description, 40))
+			}
+			sb.WriteString("• " + strings.Join(chain, " ← ") + "\n")
+		}
+	}
+
+	sb.WriteString(l.T("dependency.hint"))
+	return sb.String()
+}
+
+// dependenciesKeyboard has a button to remove each task the task waits for
+// directly and to add tasks it could wait for without closing a cycle.
+func dependenciesKeyboard(l i18n.Lang, task *domain.Task, deps *domain.Dependencies, view listView) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	for _, blocker := range deps.Blockers(task.ID) {
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         "❌ " + render.Truncate(blocker.Description, 40),
+			CallbackData: fmt.Sprintf("dep_del:%d:%d:%s", task.ID, blocker.ID, view),
+		}})
+	}
+
+	candidates := 0
+	for _, other := range deps.Tasks() {
+		if candidates == maxDependencyCandidates {
+			break
+		}
+		if deps.Has(task.ID, other.ID) || deps.WouldCycle(task.ID, other.ID) {
+			continue
+		}
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         "➕ " + render.Truncate(other.Description, 40),
+			CallbackData: fmt.Sprintf("dep_add:%d:%d:%s", task.ID, other.ID, view),
+		}})
+		candidates++
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: l.T("button.back"), CallbackData: fmt.Sprintf("task:%d:%s", task.ID, view)},
+	})
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+// isTaskForward reports whether a message forwarded to the bot should become
+// a task. A forwarded file without a caption is still a file to import.
+func isTaskForward(msg *models.Message) bool {
+	if msg.ForwardOrigin == nil || isGroupChat(msg.Chat) {
+		return false
+	}
+	return msg.Document == nil || msg.Caption != ""
+}
+
+// handleForward starts the add-task flow from a forwarded message: its text
+// is read as quick-add text, and the message itself and a link to where it
+// came from are attached to the task once it is created.
+func (h *Handler) handleForward(ctx context.Context, b *bot.Bot, msg *models.Message) {
+	user, err := h.userService.GetOrCreate(ctx, msg.From.ID, msg.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+
+	state := &UserState{}
+	if text = strings.TrimSpace(text); text != "" {
+		state = h.quickAddState(ctx, b, msg.Chat.ID, text, user)
+	}
+
+	state.Attachments = attachmentsFromMessage(msg)
+	if url, name := forwardSource(msg.ForwardOrigin); url != "" {
+		state.Attachments = append(state.Attachments, &domain.Attachment{Kind: domain.AttachmentLink, URL: url, Caption: name})
+	}
+
+	h.continueAddFlow(ctx, b, msg.Chat.ID, user, state)
+}
+
+// forwardSource returns a t.me link to where a forwarded message came from
+// and the name of its chat or sender, or an empty link when the origin
+// cannot be linked to: a sender hiding their account or without a username.
+// Only channel posts carry the ID of the original message.
+func forwardSource(origin *models.MessageOrigin) (url, name string) {
+	switch origin.Type {
+	case models.MessageOriginTypeChannel:
+		channel := origin.MessageOriginChannel
+		if channel.Chat.Username != "" {
+			return fmt.Sprintf("https://t.me/%s/%d", channel.Chat.Username, channel.MessageID), channel.Chat.Title
+		}
+		// Private channels are linked to by their ID without the -100 prefix.
+		id := strings.TrimPrefix(strconv.FormatInt(channel.Chat.ID, 10), "-100")
+		return fmt.Sprintf("https://t.me/c/%s/%d", id, channel.MessageID), channel.Chat.Title
+	case models.MessageOriginTypeChat:
+		chat := origin.MessageOriginChat.SenderChat
+		if chat.Username != "" {
+			return "https://t.me/" + chat.Username, chat.Title
+		}
+	case models.MessageOriginTypeUser:
+		sender := origin.MessageOriginUser.SenderUser
+		if sender.Username != "" {
+			return "https://t.me/" + sender.Username, displayName(&sender)
+		}
+	}
+	return "", ""
+}
+
+// attachFromState attaches what the add flow collected, such as a forwarded
+// message, to the task it created.
+func (h *Handler) attachFromState(ctx context.Context, task *domain.Task, state *UserState) {
+	for _, attachment := range state.Attachments {
+		if err := h.attachmentService.Add(ctx, task, attachment); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to add attachment")
+			return
+		}
+	}
+}
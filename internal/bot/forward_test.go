@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/service"
+)
+
+func TestForwardSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		origin   *models.MessageOrigin
+		wantURL  string
+		wantName string
+	}{
+		{
+			name: "public channel",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeChannel, MessageOriginChannel: &models.MessageOriginChannel{
+				Chat: models.Chat{ID: -1001234567890, Title: "News", Username: "news"}, MessageID: 42,
+			}},
+			wantURL:  "https://t.me/news/42",
+			wantName: "News",
+		},
+		{
+			name: "private channel",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeChannel, MessageOriginChannel: &models.MessageOriginChannel{
+				Chat: models.Chat{ID: -1001234567890, Title: "Team"}, MessageID: 42,
+			}},
+			wantURL:  "https://t.me/c/1234567890/42",
+			wantName: "Team",
+		},
+		{
+			name: "public group",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeChat, MessageOriginChat: &models.MessageOriginChat{
+				SenderChat: models.Chat{ID: -100, Title: "Go", Username: "golang"},
+			}},
+			wantURL:  "https://t.me/golang",
+			wantName: "Go",
+		},
+		{
+			name: "private group",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeChat, MessageOriginChat: &models.MessageOriginChat{
+				SenderChat: models.Chat{ID: -100, Title: "Family"},
+			}},
+		},
+		{
+			name: "user with a username",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeUser, MessageOriginUser: &models.MessageOriginUser{
+				SenderUser: models.User{ID: 8, FirstName: "Anna", Username: "anna"},
+			}},
+			wantURL:  "https://t.me/anna",
+			wantName: "@anna",
+		},
+		{
+			name: "user without a username",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeUser, MessageOriginUser: &models.MessageOriginUser{
+				SenderUser: models.User{ID: 8, FirstName: "Anna"},
+			}},
+		},
+		{
+			name: "hidden user",
+			origin: &models.MessageOrigin{Type: models.MessageOriginTypeHiddenUser, MessageOriginHiddenUser: &models.MessageOriginHiddenUser{
+				SenderUserName: "Anna",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, name := forwardSource(tt.origin)
+			if url != tt.wantURL || name != tt.wantName {
+				t.Errorf("forwardSource() = %q, %q, want %q, %q", url, name, tt.wantURL, tt.wantName)
+			}
+		})
+	}
+}
+
+// A forwarded message's text is read as quick-add text, so the add flow
+// goes on with the first field it does not give instead of asking for the
+// description.
+func TestForwardStartsAddFlow(t *testing.T) {
+	en := i18n.Parse("en")
+	fromAnna := &models.MessageOrigin{Type: models.MessageOriginTypeUser, MessageOriginUser: &models.MessageOriginUser{
+		SenderUser: models.User{ID: 8, FirstName: "Anna", Username: "anna"},
+	}}
+	hidden := &models.MessageOrigin{Type: models.MessageOriginTypeHiddenUser, MessageOriginHiddenUser: &models.MessageOriginHiddenUser{
+		SenderUserName: "Anna",
+	}}
+
+	tests := []struct {
+		name            string
+		msg             models.Message
+		wantStep        string
+		wantDescription string
+		wantAsk         string
+		wantLinks       []string
+	}{
+		{
+			name:            "text",
+			msg:             models.Message{Text: "Pay the invoice !4 daily", ForwardOrigin: fromAnna},
+			wantStep:        StateWaitingDeadline,
+			wantDescription: "Pay the invoice",
+			wantAsk:         en.T("add.ask_deadline"),
+			wantLinks:       []string{"https://t.me/anna"},
+		},
+		{
+			name:            "caption",
+			msg:             models.Message{Caption: "Renew the passport", Photo: []models.PhotoSize{{FileID: "p"}}, ForwardOrigin: hidden},
+			wantStep:        StateWaitingDeadline,
+			wantDescription: "Renew the passport",
+			wantAsk:         en.T("add.ask_deadline"),
+		},
+		{
+			name:     "no text",
+			msg:      models.Message{Photo: []models.PhotoSize{{FileID: "p"}}, ForwardOrigin: hidden},
+			wantStep: StateWaitingDescription,
+			wantAsk:  en.T("add.ask_description"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := knownUsers{users: map[int64]*domain.User{70: {ID: 7, TelegramID: 70, Timezone: "UTC", Language: "en"}}}
+			h := &Handler{userService: service.NewUserService(users, nil), stateManager: NewStateManager()}
+			b, api := newTestBot(t, h)
+
+			msg := tt.msg
+			msg.ID = 5
+			msg.Chat = models.Chat{ID: 70, Type: "private"}
+			msg.From = &models.User{ID: 70}
+			b.ProcessUpdate(context.Background(), &models.Update{Message: &msg})
+
+			state := h.stateManager.Get(70)
+			if state == nil {
+				t.Fatal("no add flow started")
+			}
+			if state.Step != tt.wantStep || state.Description != tt.wantDescription {
+				t.Errorf("state = %q, %q, want %q, %q", state.Step, state.Description, tt.wantStep, tt.wantDescription)
+			}
+
+			if sent := api.sent("sendMessage"); len(sent) != 1 || sent[0].params["text"] != tt.wantAsk {
+				t.Errorf("sent %v, want %q", sent, tt.wantAsk)
+			}
+
+			if len(state.Attachments) == 0 || state.Attachments[0].Kind != domain.AttachmentMessage || state.Attachments[0].MessageID != 5 {
+				t.Fatalf("attachments = %+v, want the forwarded message first", state.Attachments)
+			}
+			var links []string
+			for _, a := range state.Attachments[1:] {
+				links = append(links, a.URL)
+			}
+			if len(links) != len(tt.wantLinks) || len(links) > 0 && links[0] != tt.wantLinks[0] {
+				t.Errorf("links = %q, want %q", links, tt.wantLinks)
+			}
+		})
+	}
+}
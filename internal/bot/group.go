@@ -0,0 +1,322 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/quickadd"
+	"telegram-reminder-bot/internal/render"
+)
+
+// Shared tasks created by quick-add in a group get these when the text does
+// not say otherwise; groups have no step-by-step wizard.
+const (
+	groupDefaultImportance = 3
+	groupDefaultFrequency  = domain.FrequencyDaily
+)
+
+// groupCommands and groupCallbacks are what the bot handles in groups.
+// Everything else needs a private chat.
+var (
+	groupCommands  = map[string]bool{"/start": true, "/add": true, "/list": true}
+	groupCallbacks = map[string]bool{
+		"done": true, "snooze": true, "delete": true, "undo_done": true, "undo_delete": true,
+		"list": true, "task": true, "pause": true, "pause_for": true, "resume": true,
+		"deps": true, "dep_add": true, "dep_del": true, "noop": true,
+	}
+)
+
+func isGroupChat(chat models.Chat) bool {
+	return chat.Type == "group" || chat.Type == "supergroup"
+}
+
+// taskScope is whose tasks a message works with: the user's personal tasks
+// in a private chat, or the shared tasks of a group.
+type taskScope struct {
+	user *domain.User
+	chat *domain.Chat
+}
+
+// scopeFor resolves the scope of an update sent in chat by from. It returns
+// nil for a group that nobody has added a task in yet.
+func (h *Handler) scopeFor(ctx context.Context, chat models.Chat, from *models.User) (*taskScope, error) {
+	if isGroupChat(chat) {
+		c, err := h.chatService.GetByTelegramID(ctx, chat.ID)
+		if err != nil || c == nil {
+			return nil, err
+		}
+		return &taskScope{chat: c}, nil
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, from.ID, from.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &taskScope{user: user}, nil
+}
+
+func (s *taskScope) location() *time.Location {
+	if s.chat != nil {
+		return s.chat.Location()
+	}
+	return s.user.Location()
+}
+
+// lang is the language of the user, or of the group for shared tasks.
+func (s *taskScope) lang() i18n.Lang {
+	if s.chat != nil {
+		return i18n.Parse(s.chat.Language)
+	}
+	return userLang(s.user)
+}
+
+// style is the user's message style; groups use the default one.
+func (s *taskScope) style() render.Style {
+	if s.chat != nil {
+		return render.DefaultStyle
+	}
+	return userStyle(s.user)
+}
+
+func (s *taskScope) workHoursPerDay() int {
+	if s.chat != nil {
+		return s.chat.WorkHoursPerDay
+	}
+	return s.user.WorkHoursPerDay
+}
+
+// owner returns the user and chat whose tasks the scope works with: the
+// chat for shared tasks, the user otherwise.
+func (s *taskScope) owner() (userID int64, chatID *int64) {
+	if s.chat != nil {
+		return 0, &s.chat.ID
+	}
+	return s.user.ID, nil
+}
+
+// owns reports whether task may be shown and changed from this scope.
+func (s *taskScope) owns(task *domain.Task) bool {
+	if s.chat != nil {
+		return task.ChatID != nil && *task.ChatID == s.chat.ID
+	}
+	return task.ChatID == nil && task.UserID == s.user.ID
+}
+
+// displayName is how a member is credited in a group: "@username", or the
+// name when there is no username.
+func displayName(user *models.User) string {
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// routeGroup keeps group chats to the commands and buttons that work with
+// shared tasks. Commands for other bots and ordinary chatter are ignored.
+// Commands addressed to the bot as "/add@name" are rewritten to plain "/add"
+// for the handler to read, but the handler has been chosen by then: commands
+// without arguments match with exactCommand to be found with the mention.
+func (h *Handler) routeGroup(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		switch {
+		case update.Message != nil && isGroupChat(update.Message.Chat):
+			command, rest, ok := h.groupCommand(ctx, b, update.Message.Text)
+			if !ok {
+				return
+			}
+			if !groupCommands[command] {
+				b.SendMessage(ctx, &bot.SendMessageParams{
+					ChatID: update.Message.Chat.ID,
+					Text:   h.langFor(ctx, update.Message.From).T("group.private_only"),
+				})
+				return
+			}
+			update.Message.Text = command + rest
+
+		case update.CallbackQuery != nil && update.CallbackQuery.Message.Message != nil && isGroupChat(update.CallbackQuery.Message.Message.Chat):
+			action, _, _ := strings.Cut(update.CallbackQuery.Data, ":")
+			if !groupCallbacks[action] {
+				b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: update.CallbackQuery.ID})
+				return
+			}
+		}
+
+		next(ctx, b, update)
+	}
+}
+
+// groupCommand splits "/cmd@botname rest" into "/cmd" and " rest". ok is
+// false for text that is not a command or is addressed to another bot.
+func (h *Handler) groupCommand(ctx context.Context, b *bot.Bot, text string) (command, rest string, ok bool) {
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+
+	end := strings.IndexAny(text, " \n")
+	if end < 0 {
+		end = len(text)
+	}
+	command, rest = text[:end], text[end:]
+
+	command, mention, addressed := strings.Cut(command, "@")
+	if addressed {
+		me, err := b.GetMe(ctx)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to get bot info")
+			return "", "", false
+		}
+		if !strings.EqualFold(mention, me.Username) {
+			return "", "", false
+		}
+	}
+
+	return command, rest, true
+}
+
+// exactCommand matches a message that is the command alone, also when it is
+// addressed to a bot as "/list@name". Which bot is left to routeGroup.
+func exactCommand(command string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		if update.Message == nil {
+			return false
+		}
+		text, mention, addressed := strings.Cut(update.Message.Text, "@")
+		return text == command && (!addressed || mention != "" && !strings.ContainsAny(mention, " \n"))
+	}
+}
+
+func (h *Handler) handleGroupStart(ctx context.Context, b *bot.Bot, update *models.Update) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   h.groupLang(ctx, update.Message.Chat, update.Message.From).T("group.help"),
+	})
+}
+
+// groupLang is the language of a group, or of the member talking to the bot
+// in a group that has not been registered yet.
+func (h *Handler) groupLang(ctx context.Context, chat models.Chat, from *models.User) i18n.Lang {
+	registered, err := h.chatService.GetByTelegramID(ctx, chat.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("chat_id", chat.ID).Msg("failed to get chat")
+	}
+	if registered == nil || registered.Language == "" {
+		return h.langFor(ctx, from)
+	}
+	return i18n.Parse(registered.Language)
+}
+
+// handleGroupAdd creates a shared task from quick-add text. The description
+// and deadline are required, everything else has group defaults.
+func (h *Handler) handleGroupAdd(ctx context.Context, b *bot.Bot, update *models.Update, args string) {
+	msg := update.Message
+
+	user, err := h.userService.GetOrCreate(ctx, msg.From.ID, msg.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	chat, err := h.chatService.GetOrCreate(ctx, msg.Chat.ID, msg.Chat.Title, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to register chat")
+		return
+	}
+
+	l := i18n.Parse(chat.Language)
+	now := time.Now().In(chat.Location())
+	parsed := quickadd.Parse(args, now)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if parsed.Description == "" || parsed.Deadline == nil || parsed.Deadline.Before(today) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: msg.Chat.ID,
+			Text:   l.T("group.add_usage"),
+		})
+		return
+	}
+
+	importance := parsed.Importance
+	if importance == 0 {
+		importance = groupDefaultImportance
+	}
+	frequency := parsed.Frequency
+	if frequency == "" {
+		frequency = groupDefaultFrequency
+	}
+
+	task, err := h.taskService.CreateInChat(ctx, chat.ID, user.ID, parsed.Description, *parsed.Deadline, importance, frequency)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to create task")
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: msg.Chat.ID,
+			Text:   l.T("add.failed"),
+		})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      msg.Chat.ID,
+		Text:        confirmation(l, render.DefaultStyle, l.T("group.created"), task, chat.WorkHoursPerDay),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: reminderKeyboard(l, task.ID),
+	})
+}
+
+// canDelete reports whether from may delete a shared task: its author or a
+// group admin.
+func (h *Handler) canDelete(ctx context.Context, b *bot.Bot, chat *domain.Chat, task *domain.Task, from *models.User) (bool, error) {
+	user, err := h.userService.GetByTelegramID(ctx, from.ID)
+	if err != nil {
+		return false, err
+	}
+	if user != nil && user.ID == task.UserID {
+		return true, nil
+	}
+
+	member, err := b.GetChatMember(ctx, &bot.GetChatMemberParams{ChatID: chat.TelegramID, UserID: from.ID})
+	if err != nil {
+		return false, err
+	}
+	return member.Type == models.ChatMemberTypeOwner || member.Type == models.ChatMemberTypeAdministrator, nil
+}
+
+// handleMyChatMember tracks whether the bot is still in a group so that
+// reminders stop when it is removed.
+func (h *Handler) handleMyChatMember(ctx context.Context, b *bot.Bot, update *models.Update) {
+	change := update.MyChatMember
+	if !isGroupChat(change.Chat) {
+		return
+	}
+
+	left := change.NewChatMember.Type == models.ChatMemberTypeLeft || change.NewChatMember.Type == models.ChatMemberTypeBanned
+	joined := !left && (change.OldChatMember.Type == models.ChatMemberTypeLeft || change.OldChatMember.Type == models.ChatMemberTypeBanned)
+
+	chat, err := h.chatService.GetByTelegramID(ctx, change.Chat.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("chat_id", change.Chat.ID).Msg("failed to get chat")
+		return
+	}
+	if chat != nil && (left || joined) {
+		if err := h.chatService.SetLeft(ctx, chat, left); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("chat_id", change.Chat.ID).Msg("failed to update chat membership")
+			return
+		}
+	}
+
+	if joined {
+		l := h.langFor(ctx, &change.From)
+		if chat != nil && chat.Language != "" {
+			l = i18n.Parse(chat.Language)
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: change.Chat.ID,
+			Text:   l.T("group.help"),
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/service"
+)
+
+// noChats is a chat repository for groups nobody has added a task in.
+type noChats struct{ repository.ChatRepository }
+
+func (noChats) GetByTelegramID(context.Context, int64) (*domain.Chat, error) {
+	return nil, nil
+}
+
+func TestGroupCommandRouting(t *testing.T) {
+	noTasks := i18n.Parse("en").T("group.no_tasks")
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"plain command", "/list", []string{noTasks}},
+		{"addressed to the bot", "/list@ReminderBot", []string{noTasks}},
+		{"addressed to the bot in another case", "/list@reminderbot", []string{noTasks}},
+		{"addressed to another bot", "/list@OtherBot", nil},
+		{"chatter", "list", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{chatService: service.NewChatService(noChats{}), stateManager: NewStateManager()}
+			b, api := newTestBot(t, h)
+
+			b.ProcessUpdate(context.Background(), &models.Update{Message: &models.Message{
+				Chat: models.Chat{ID: -100, Type: "group"},
+				From: &models.User{ID: 7, LanguageCode: "en"},
+				Text: tt.text,
+			}})
+
+			var got []string
+			for _, c := range api.sent("sendMessage") {
+				got = append(got, c.params["text"])
+			}
+			if len(got) != len(tt.want) || len(got) > 0 && got[0] != tt.want[0] {
+				t.Errorf("sent %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
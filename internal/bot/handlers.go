@@ -2,7 +2,7 @@ package bot
 
 import (
 	"context"
-	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -12,20 +12,43 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/quickadd"
+	"telegram-reminder-bot/internal/render"
 	"telegram-reminder-bot/internal/service"
 )
 
 type Handler struct {
-	userService *service.UserService
-	taskService *service.TaskService
-	stateManager *StateManager
+	userService       *service.UserService
+	taskService       *service.TaskService
+	reminderService   *service.ReminderService
+	quietService      *service.QuietService
+	dependencyService *service.DependencyService
+	attachmentService *service.AttachmentService
+	statsService      *service.StatsService
+	accountService    *service.AccountService
+	webhookService    *service.WebhookService
+	adminService      *service.AdminService
+	chatService       *service.ChatService
+	stateManager      *StateManager
+	access            accessPolicy
+	publicURL         string
 }
 
-func NewHandler(userService *service.UserService, taskService *service.TaskService) *Handler {
+func NewHandler(userService *service.UserService, taskService *service.TaskService, reminderService *service.ReminderService, quietService *service.QuietService, dependencyService *service.DependencyService, attachmentService *service.AttachmentService, statsService *service.StatsService, accountService *service.AccountService, webhookService *service.WebhookService, adminService *service.AdminService, chatService *service.ChatService) *Handler {
 	return &Handler{
-		userService:  userService,
-		taskService:  taskService,
-		stateManager: NewStateManager(),
+		userService:       userService,
+		taskService:       taskService,
+		reminderService:   reminderService,
+		quietService:      quietService,
+		dependencyService: dependencyService,
+		attachmentService: attachmentService,
+		statsService:      statsService,
+		accountService:    accountService,
+		webhookService:    webhookService,
+		adminService:      adminService,
+		chatService:       chatService,
+		stateManager:      NewStateManager(),
 	}
 }
 
@@ -34,35 +57,61 @@ func (h *Handler) HandleStart(ctx context.Context, b *bot.Bot, update *models.Up
 		return
 	}
 
-	telegramID := update.Message.From.ID
-	username := update.Message.From.Username
+	payload, ok := commandArgs(update.Message.Text, "/start")
+	if !ok {
+		return
+	}
 
-	_, err := h.userService.GetOrCreate(ctx, telegramID, username)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to create user")
+	if isGroupChat(update.Message.Chat) {
+		h.handleGroupStart(ctx, b, update)
 		return
 	}
 
-	text := `Привет! Я бот для напоминаний о задачах.
+	telegramID := update.Message.From.ID
+	username := update.Message.From.Username
 
-Я помогу тебе не забыть о важных делах. Вот что я умею:
+	if h.access.mode == AccessInvite && !h.isAdmin(telegramID) {
+		existing, err := h.userService.GetByTelegramID(ctx, telegramID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+			return
+		}
+		if existing == nil {
+			redeemed, err := h.adminService.RedeemInvite(ctx, payload, telegramID)
+			if err != nil {
+				log.Error().Ctx(ctx).Err(err).Msg("failed to redeem invite")
+				return
+			}
+			if !redeemed {
+				b.SendMessage(ctx, &bot.SendMessageParams{
+					ChatID: update.Message.Chat.ID,
+					Text:   i18n.Parse(update.Message.From.LanguageCode).T("access.invite_invalid"),
+				})
+				return
+			}
+		}
+	}
 
-📌 Добавить задачу - создать новое напоминание
-📋 Мои задачи - посмотреть активные задачи
-⚙️ Настройки - изменить рабочие часы
+	user, err := h.userService.GetOrCreate(ctx, telegramID, username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to create user")
+		return
+	}
+	h.detectLanguage(ctx, user, update.Message.From)
+	l := userLang(user)
 
-Используй кнопки меню или команды:
-/add - добавить задачу
-/list - список задач
-/settings - настройки`
+	text := l.T("start.help")
+	if h.isAdmin(telegramID) {
+		text += l.T("admin.help")
+	}
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      update.Message.Chat.ID,
 		Text:        text,
-		ReplyMarkup: mainMenuKeyboard(),
+		ReplyMarkup: mainMenuKeyboard(l),
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to send start message")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to send start message")
 	}
 }
 
@@ -71,15 +120,146 @@ func (h *Handler) HandleAdd(ctx context.Context, b *bot.Bot, update *models.Upda
 		return
 	}
 
+	// The menu button starts the wizard like a bare /add.
+	args, ok := commandArgs(update.Message.Text, "/add")
+	if !ok && !i18n.Matches("menu.add", update.Message.Text) {
+		return
+	}
+
 	chatID := update.Message.Chat.ID
 	userID := update.Message.From.ID
 
-	h.stateManager.Set(userID, &UserState{Step: StateWaitingDescription})
+	if isGroupChat(update.Message.Chat) {
+		h.handleGroupAdd(ctx, b, update, args)
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if args == "" {
+		h.continueAddFlow(ctx, b, chatID, user, &UserState{})
+		return
+	}
+
+	state := h.quickAddState(ctx, b, chatID, args, user)
+	h.continueAddFlow(ctx, b, chatID, user, state)
+}
+
+// quickAddState fills a wizard state from quick-add text and warns about
+// the parts that could not be parsed and a deadline already in the past,
+// which the wizard then asks for again.
+func (h *Handler) quickAddState(ctx context.Context, b *bot.Bot, chatID int64, args string, user *domain.User) *UserState {
+	parsed := quickadd.Parse(args, time.Now().In(user.Location()))
+	state := &UserState{
+		Description: parsed.Description,
+		Importance:  parsed.Importance,
+		Frequency:   parsed.Frequency,
+	}
+	if parsed.Deadline != nil {
+		if parsed.Deadline.Before(scopeToday(&taskScope{user: user})) {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   userLang(user).T("add.quick_past_deadline", parsed.Deadline.Format("02.01.2006")),
+			})
+		} else {
+			state.Deadline = *parsed.Deadline
+		}
+	}
+
+	if len(parsed.Unknown) > 0 {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      userLang(user).T("add.unparsed", render.Escape(strings.Join(parsed.Unknown, ", "))),
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+
+	return state
+}
+
+// continueAddFlow asks for the first field still missing from state, or
+// creates the task once everything is filled in. Both the step-by-step
+// wizard and quick-add syntax go through here.
+func (h *Handler) continueAddFlow(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User, state *UserState) {
+	userID := user.TelegramID
+	l := userLang(user)
+
+	switch {
+	case state.Description == "":
+		state.Step = StateWaitingDescription
+		h.stateManager.Set(userID, state)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("add.ask_description"),
+			ReplyMarkup: cancelKeyboard(l),
+		})
+
+	case state.Deadline.IsZero():
+		state.Step = StateWaitingDeadline
+		h.stateManager.Set(userID, state)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("add.ask_deadline"),
+			ReplyMarkup: cancelKeyboard(l),
+		})
+
+	case state.Importance == 0:
+		state.Step = StateWaitingImportance
+		h.stateManager.Set(userID, state)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("add.ask_importance"),
+			ReplyMarkup: importanceKeyboard(),
+		})
+
+	case state.Frequency == "":
+		state.Step = StateWaitingFrequency
+		h.stateManager.Set(userID, state)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("add.ask_frequency"),
+			ReplyMarkup: frequencyKeyboard(l),
+		})
+
+	default:
+		h.createTaskFromState(ctx, b, chatID, user, state)
+	}
+}
+
+func (h *Handler) createTaskFromState(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User, state *UserState) {
+	l := userLang(user)
+
+	if state.Assignee != nil {
+		h.assignTaskFromState(ctx, b, chatID, user, state)
+		return
+	}
+
+	task, err := h.taskService.Create(ctx, user.ID, state.Description, state.Deadline, state.Importance, state.Frequency)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to create task")
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("add.failed"),
+		})
+		return
+	}
+
+	h.stateManager.Delete(user.TelegramID)
+	h.attachFromState(ctx, task, state)
 
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "Введи описание задачи:",
-		ReplyMarkup: cancelKeyboard(),
+		Text:        confirmation(l, userStyle(user), l.T("add.created"), task, user.WorkHoursPerDay),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: mainMenuKeyboard(l),
 	})
 }
 
@@ -89,37 +269,32 @@ func (h *Handler) HandleList(ctx context.Context, b *bot.Bot, update *models.Upd
 	}
 
 	chatID := update.Message.Chat.ID
-	telegramID := update.Message.From.ID
-
-	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to get user")
-		return
-	}
 
-	tasks, err := h.taskService.GetActiveByUserID(ctx, user.ID)
+	scope, err := h.scopeFor(ctx, update.Message.Chat, update.Message.From)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get tasks")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
 		return
 	}
-
-	if len(tasks) == 0 {
+	if scope == nil {
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "У тебя нет активных задач. Добавь новую с помощью /add",
+			Text:   i18n.Parse(update.Message.From.LanguageCode).T("group.no_tasks"),
 		})
 		return
 	}
 
-	for _, task := range tasks {
-		text := formatTaskMessage(task, user)
-		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID:      chatID,
-			Text:        text,
-			ParseMode:   models.ParseModeHTML,
-			ReplyMarkup: taskActionsKeyboard(task.ID),
-		})
+	text, markup, err := h.renderTaskList(ctx, scope, defaultListView())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get tasks")
+		return
 	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
 }
 
 func (h *Handler) HandleSettings(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -132,22 +307,16 @@ func (h *Handler) HandleSettings(ctx context.Context, b *bot.Bot, update *models
 
 	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get user")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
 		return
 	}
 
-	text := fmt.Sprintf(`⚙️ <b>Настройки</b>
-
-Рабочие часы в день: <b>%d</b>
-Часовой пояс: <b>%s</b>
-
-Выбери что изменить:`, user.WorkHoursPerDay, user.Timezone)
-
+	l := userLang(user)
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        text,
+		Text:        l.T("settings.summary", user.WorkHoursPerDay, user.Timezone, l.Name(), styleName(l, userStyle(user)), quietModeName(l, user.QuietMode)),
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: settingsKeyboard(),
+		ReplyMarkup: settingsKeyboard(l),
 	})
 }
 
@@ -156,18 +325,35 @@ func (h *Handler) HandleMessage(ctx context.Context, b *bot.Bot, update *models.
 		return
 	}
 
+	// Files sent while attaching are attachments, not tasks to import.
+	if state := h.stateManager.Get(update.Message.From.ID); state != nil && state.Step == StateWaitingAttachment && !isGroupChat(update.Message.Chat) {
+		if h.handleAttachmentInput(ctx, b, update.Message, state) {
+			return
+		}
+	}
+
+	if isTaskForward(update.Message) {
+		h.handleForward(ctx, b, update.Message)
+		return
+	}
+
+	if update.Message.Document != nil {
+		h.HandleDocument(ctx, b, update)
+		return
+	}
+
 	text := update.Message.Text
 	chatID := update.Message.Chat.ID
 	userID := update.Message.From.ID
 
-	switch text {
-	case "Добавить задачу":
+	switch {
+	case i18n.Matches("menu.add", text):
 		h.HandleAdd(ctx, b, update)
 		return
-	case "Мои задачи":
+	case i18n.Matches("menu.list", text):
 		h.HandleList(ctx, b, update)
 		return
-	case "Настройки":
+	case i18n.Matches("menu.settings", text):
 		h.HandleSettings(ctx, b, update)
 		return
 	}
@@ -177,45 +363,51 @@ func (h *Handler) HandleMessage(ctx context.Context, b *bot.Bot, update *models.
 		return
 	}
 
+	user, err := h.userService.GetOrCreate(ctx, userID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	l := userLang(user)
+
 	switch state.Step {
-	case StateWaitingDescription:
-		state.Description = text
-		state.Step = StateWaitingDeadline
-		h.stateManager.Set(userID, state)
+	case StateWaitingAssignee:
+		h.handleAssigneeInput(ctx, b, update, user, state)
+
+	case StateWaitingPauseDate:
+		h.handlePauseDateInput(ctx, b, chatID, user, state, text)
 
+	case StateWaitingAttachment:
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Введи дедлайн в формате ДД.ММ.ГГГГ (например, 15.01.2025):",
-			ReplyMarkup: cancelKeyboard(),
+			Text:        l.T("attachment.unsupported"),
+			ReplyMarkup: attachDoneKeyboard(l, state.TaskID),
 		})
 
+	case StateWaitingDescription:
+		state.Description = text
+		h.continueAddFlow(ctx, b, chatID, user, state)
+
 	case StateWaitingDeadline:
 		deadline, err := time.Parse("02.01.2006", text)
 		if err != nil {
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "Неверный формат даты. Введи в формате ДД.ММ.ГГГГ:",
+				Text:   l.T("add.bad_deadline"),
 			})
 			return
 		}
 
-		if deadline.Before(time.Now().Truncate(24 * time.Hour)) {
+		if deadline.Before(scopeToday(&taskScope{user: user})) {
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "Дедлайн не может быть в прошлом. Введи корректную дату:",
+				Text:   l.T("add.past_deadline"),
 			})
 			return
 		}
 
 		state.Deadline = deadline
-		state.Step = StateWaitingImportance
-		h.stateManager.Set(userID, state)
-
-		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID:      chatID,
-			Text:        "Выбери важность задачи (влияет на количество напоминаний в день):",
-			ReplyMarkup: importanceKeyboard(),
-		})
+		h.continueAddFlow(ctx, b, chatID, user, state)
 	}
 }
 
@@ -225,7 +417,8 @@ func (h *Handler) HandleCallback(ctx context.Context, b *bot.Bot, update *models
 	}
 
 	callback := update.CallbackQuery
-	chatID := callback.Message.Message.Chat.ID
+	chat := callback.Message.Message.Chat
+	chatID := chat.ID
 	userID := callback.From.ID
 	data := callback.Data
 
@@ -235,10 +428,11 @@ func (h *Handler) HandleCallback(ctx context.Context, b *bot.Bot, update *models
 
 	if data == "cancel" {
 		h.stateManager.Delete(userID)
+		l := h.langFor(ctx, &callback.From)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Действие отменено.",
-			ReplyMarkup: mainMenuKeyboard(),
+			Text:        l.T("common.cancelled"),
+			ReplyMarkup: mainMenuKeyboard(l),
 		})
 		return
 	}
@@ -251,129 +445,421 @@ func (h *Handler) HandleCallback(ctx context.Context, b *bot.Bot, update *models
 	action, value := parts[0], parts[1]
 
 	switch action {
+	case "assign":
+		h.handleAssignCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
 	case "importance":
-		h.handleImportanceCallback(ctx, b, chatID, userID, value)
+		h.handleImportanceCallback(ctx, b, chatID, &callback.From, value)
 	case "frequency":
-		h.handleFrequencyCallback(ctx, b, chatID, userID, value)
+		h.handleFrequencyCallback(ctx, b, chatID, &callback.From, value)
 	case "done":
-		h.handleDoneCallback(ctx, b, chatID, callback.Message.Message.ID, value)
+		h.handleDoneCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "snooze":
+		h.handleSnoozeCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
 	case "delete":
-		h.handleDeleteCallback(ctx, b, chatID, callback.Message.Message.ID, value)
+		h.handleDeleteCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "list":
+		h.handleListCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "task":
+		h.handleTaskCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "pause":
+		h.handlePauseCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "pause_for":
+		h.handlePauseForCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "resume":
+		h.handleResumeCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "deps":
+		h.handleDependenciesCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "dep_add", "dep_del":
+		h.handleDependencyCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, action, value)
+	case "att":
+		h.handleAttachmentsCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "att_all", "att_get":
+		h.handleAttachmentSendCallback(ctx, b, chat, &callback.From, action, value)
+	case "att_del":
+		h.handleAttachmentDeleteCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "att_add":
+		h.handleAttachCallback(ctx, b, chat, &callback.From, value)
+	case "att_done":
+		h.handleAttachDoneCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
+	case "undo_done", "undo_delete":
+		h.handleUndoCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, action, value)
+	case "chart":
+		h.handleChartCallback(ctx, b, chatID, userID, value)
+	case "webhook_delete":
+		h.handleWebhookDeleteCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "account":
+		h.handleAccountCallback(ctx, b, chatID, callback.Message.Message.ID, &callback.From, value)
+	case "broadcast":
+		h.handleBroadcastCallback(ctx, b, chatID, callback.Message.Message.ID, &callback.From, value)
+	case "import":
+		h.handleImportCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "calendar":
+		h.handleCalendarCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "archive":
+		h.handleArchiveCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "reopen":
+		h.handleReopenCallback(ctx, b, chat, callback.Message.Message.ID, &callback.From, value)
 	case "settings":
 		h.handleSettingsCallback(ctx, b, chatID, userID, value)
 	case "work_hours":
 		h.handleWorkHoursCallback(ctx, b, chatID, userID, value)
+	case "language":
+		h.handleLanguageCallback(ctx, b, chatID, userID, value)
+	case "style":
+		h.handleStyleCallback(ctx, b, chatID, userID, value)
+	case "dnd":
+		h.handleDNDCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "quiet_delete":
+		h.handleQuietDeleteCallback(ctx, b, chatID, callback.Message.Message.ID, userID, value)
+	case "quiet_mode":
+		h.handleQuietModeCallback(ctx, b, chatID, userID, value)
 	}
 }
 
-func (h *Handler) handleImportanceCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+func (h *Handler) handleImportanceCallback(ctx context.Context, b *bot.Bot, chatID int64, from *models.User, value string) {
 	importance, err := strconv.Atoi(value)
 	if err != nil {
 		return
 	}
 
-	state := h.stateManager.Get(userID)
+	state := h.stateManager.Get(from.ID)
 	if state == nil || state.Step != StateWaitingImportance {
 		return
 	}
 
-	state.Importance = importance
-	state.Step = StateWaitingFrequency
-	h.stateManager.Set(userID, state)
+	user, err := h.userService.GetOrCreate(ctx, from.ID, from.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:      chatID,
-		Text:        "Выбери частоту напоминаний:",
-		ReplyMarkup: frequencyKeyboard(),
-	})
+	state.Importance = importance
+	h.continueAddFlow(ctx, b, chatID, user, state)
 }
 
-func (h *Handler) handleFrequencyCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+func (h *Handler) handleFrequencyCallback(ctx context.Context, b *bot.Bot, chatID int64, from *models.User, value string) {
 	frequency, ok := domain.ParseFrequency(value)
 	if !ok {
 		return
 	}
 
-	state := h.stateManager.Get(userID)
+	state := h.stateManager.Get(from.ID)
 	if state == nil || state.Step != StateWaitingFrequency {
 		return
 	}
 
-	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	user, err := h.userService.GetOrCreate(ctx, from.ID, from.Username)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get user")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
 		return
 	}
 
-	task, err := h.taskService.Create(ctx, user.ID, state.Description, state.Deadline, state.Importance, frequency)
+	state.Frequency = frequency
+	h.continueAddFlow(ctx, b, chatID, user, state)
+}
+
+// taskForCallback loads the task a button refers to, or returns nil when it
+// is gone or does not belong to the chat the button was pressed in.
+func (h *Handler) taskForCallback(ctx context.Context, chat models.Chat, from *models.User, value string) (*taskScope, *domain.Task) {
+	taskID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	scope, err := h.scopeFor(ctx, chat, from)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to create task")
-		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "Ошибка при создании задачи. Попробуй ещё раз.",
-		})
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return nil, nil
+	}
+	if scope == nil {
+		return nil, nil
+	}
+
+	task, err := h.taskService.GetByID(ctx, taskID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", taskID).Msg("failed to get task")
+		return nil, nil
+	}
+	if task == nil || !scope.owns(task) {
+		return nil, nil
+	}
+
+	return scope, task
+}
+
+func (h *Handler) handleDoneCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	scope, task := h.taskForCallback(ctx, chat, from, value)
+	if task == nil || task.IsCompleted {
 		return
 	}
 
-	h.stateManager.Delete(userID)
+	l := scope.lang()
+	text := l.T("task.completed")
+	var by string
+	if scope.chat != nil {
+		by = displayName(from)
+		text = l.T("group.completed_by", render.Escape(task.Description), render.Escape(by))
+	}
 
-	text := fmt.Sprintf("✅ Задача создана!\n\n%s", formatTaskMessage(task, user))
-	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:      chatID,
+	if _, err := h.taskService.CompleteBy(ctx, task.ID, by); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to complete task")
+		return
+	}
+	if err := h.reminderService.Respond(ctx, task.ID, domain.ReminderClicked); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to record reminder response")
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chat.ID,
+		MessageID:   messageID,
 		Text:        text,
 		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: mainMenuKeyboard(),
+		ReplyMarkup: undoKeyboard(l, "undo_done", task.ID),
 	})
 }
 
-func (h *Handler) handleDoneCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, value string) {
-	taskID, err := strconv.ParseInt(value, 10, 64)
+// snoozeDuration is how long the snooze button postpones a reminder.
+const snoozeDuration = time.Hour
+
+func (h *Handler) handleSnoozeCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	scope, task := h.taskForCallback(ctx, chat, from, value)
+	if task == nil || task.IsCompleted {
+		return
+	}
+
+	snoozed, err := h.reminderService.Snooze(ctx, task.ID, snoozeDuration)
 	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to snooze reminder")
 		return
 	}
 
-	if err := h.taskService.Complete(ctx, taskID); err != nil {
-		log.Error().Err(err).Msg("failed to complete task")
+	attachments, err := h.attachmentService.Count(ctx, task.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to count attachments")
+	}
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		ReplyMarkup: snoozedKeyboard(scope.lang(), task.ID, snoozed.SnoozedUntil.In(scope.location()), attachments),
+	})
+}
+
+func (h *Handler) handleDeleteCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	scope, task := h.taskForCallback(ctx, chat, from, value)
+	if task == nil {
+		return
+	}
+
+	if !h.mayDelete(ctx, b, scope, task, from) {
+		return
+	}
+
+	if err := h.taskService.Delete(ctx, task.ID); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to delete task")
 		return
 	}
 
 	b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      "✅ Задача выполнена!",
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		Text:        scope.lang().T("task.deleted"),
+		ReplyMarkup: undoKeyboard(scope.lang(), "undo_delete", task.ID),
 	})
 }
 
-func (h *Handler) handleDeleteCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, value string) {
+// mayDelete checks group permissions for deleting or restoring a shared
+// task and tells the member when they are missing.
+func (h *Handler) mayDelete(ctx context.Context, b *bot.Bot, scope *taskScope, task *domain.Task, from *models.User) bool {
+	if scope.chat == nil {
+		return true
+	}
+
+	allowed, err := h.canDelete(ctx, b, scope.chat, task, from)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to check delete permission")
+		return false
+	}
+	if !allowed {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: scope.chat.TelegramID,
+			Text:   scope.lang().T("group.delete_forbidden", displayName(from)),
+		})
+	}
+	return allowed
+}
+
+func (h *Handler) handleUndoCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, action string, value string) {
 	taskID, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
 		return
 	}
 
-	if err := h.taskService.Delete(ctx, taskID); err != nil {
-		log.Error().Err(err).Msg("failed to delete task")
+	scope, err := h.scopeFor(ctx, chat, from)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	if scope == nil {
+		return
+	}
+
+	// Restoring a task of this chat within the undo window only reverts a
+	// delete, so it needs no delete permission.
+	var restored bool
+	switch action {
+	case "undo_done":
+		task, err := h.taskService.GetByID(ctx, taskID)
+		if err != nil || task == nil || !scope.owns(task) {
+			return
+		}
+		restored, err = h.taskService.UndoComplete(ctx, taskID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Str("action", action).Msg("failed to undo task action")
+			return
+		}
+	case "undo_delete":
+		task, err := h.taskService.GetDeletedByID(ctx, taskID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", taskID).Msg("failed to get deleted task")
+			return
+		}
+		if task != nil && !scope.owns(task) {
+			return
+		}
+		userID, chatID := scope.owner()
+		restored, err = h.taskService.UndoDelete(ctx, taskID, userID, chatID)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Str("action", action).Msg("failed to undo task action")
+			return
+		}
+	}
+
+	if !restored {
+		b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      chat.ID,
+			MessageID:   messageID,
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+		})
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chat.ID,
+			Text:   scope.lang().T("undo.expired"),
+		})
+		return
+	}
+
+	task, err := h.taskService.GetByID(ctx, taskID)
+	if err != nil || task == nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", taskID).Msg("failed to get restored task")
 		return
 	}
 
 	b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      "🗑 Задача удалена.",
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		Text:        confirmation(scope.lang(), scope.style(), scope.lang().T("task.restored"), task, scope.workHoursPerDay()),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: taskActionsKeyboard(scope.lang(), task, defaultListView()),
 	})
 }
 
-func (h *Handler) handleSettingsCallback(ctx context.Context, b *bot.Bot, chatID int64, _ int64, value string) {
+func (h *Handler) handleListCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	view, ok := parseListView(value)
+	if !ok {
+		return
+	}
+
+	scope, err := h.scopeFor(ctx, chat, from)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	if scope == nil {
+		return
+	}
+
+	text, markup, err := h.renderTaskList(ctx, scope, view)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get tasks")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	})
+}
+
+func (h *Handler) handleTaskCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil {
+		h.handleListCallback(ctx, b, chat, messageID, from, view.String())
+		return
+	}
+
+	deps, err := h.dependencies(ctx, scope)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get dependencies")
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		Text:        render.Task(scope.lang(), scope.style(), taskView(task, deps, scope.workHoursPerDay())),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: taskActionsKeyboard(scope.lang(), task, view),
+	})
+}
+
+func (h *Handler) handleSettingsCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	l := userLang(user)
+
 	switch value {
 	case "work_hours":
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Выбери количество рабочих часов в день:",
-			ReplyMarkup: workHoursKeyboard(),
+			Text:        l.T("settings.ask_work_hours"),
+			ReplyMarkup: workHoursKeyboard(l),
 		})
 	case "timezone":
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "Отправь свой часовой пояс (например, Europe/Moscow, Asia/Yekaterinburg):",
+			Text:   l.T("settings.ask_timezone"),
+		})
+	case "language":
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("settings.ask_language"),
+			ReplyMarkup: languageKeyboard(),
+		})
+	case "style":
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("settings.ask_style"),
+			ReplyMarkup: styleKeyboard(l, userStyle(user)),
+		})
+	case "quiet_mode":
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        l.T("settings.ask_quiet_mode"),
+			ReplyMarkup: quietModeKeyboard(l, user.QuietMode),
 		})
 	}
 }
@@ -386,19 +872,85 @@ func (h *Handler) handleWorkHoursCallback(ctx context.Context, b *bot.Bot, chatI
 
 	user, err := h.userService.GetOrCreate(ctx, userID, "")
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get user")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
 		return
 	}
 
 	user.WorkHoursPerDay = hours
 	if err := h.userService.UpdateSettings(ctx, user); err != nil {
-		log.Error().Err(err).Msg("failed to update user settings")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to update user settings")
+		return
+	}
+
+	l := userLang(user)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        l.T("settings.work_hours_updated", l.N("hours", hours)),
+		ReplyMarkup: mainMenuKeyboard(l),
+	})
+}
+
+// handleLanguageCallback switches the user to the chosen language and
+// resends the main menu, whose buttons are in the old one.
+func (h *Handler) handleLanguageCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+	l := i18n.Lang(value)
+	if !slices.Contains(i18n.Languages, l) {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if err := h.userService.SetLanguage(ctx, user, string(l)); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to set language")
 		return
 	}
 
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        fmt.Sprintf("✅ Рабочие часы обновлены: %d часов в день", hours),
-		ReplyMarkup: mainMenuKeyboard(),
+		Text:        l.T("settings.language_updated"),
+		ReplyMarkup: mainMenuKeyboard(l),
 	})
 }
+
+func (h *Handler) handleStyleCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+	style := render.Style(value)
+	if !slices.Contains(render.Styles, style) {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	user.MessageStyle = string(style)
+	if err := h.userService.UpdateSettings(ctx, user); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to update user settings")
+		return
+	}
+
+	l := userLang(user)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        l.T("settings.style_updated", styleName(l, style)),
+		ReplyMarkup: mainMenuKeyboard(l),
+	})
+}
+
+// commandArgs returns the text following command, e.g. "buy milk" for
+// "/add buy milk". ok is false when text is not that command.
+func commandArgs(text, command string) (args string, ok bool) {
+	rest, found := strings.CutPrefix(text, command)
+	if !found {
+		return "", false
+	}
+	if rest != "" && rest[0] != ' ' && rest[0] != '\n' {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
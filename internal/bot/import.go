@@ -0,0 +1,191 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/importer"
+	"telegram-reminder-bot/internal/render"
+)
+
+const (
+	maxImportFileSize = 1 << 20
+	importPreviewSize = 10
+)
+
+func (h *Handler) HandleImport(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    update.Message.Chat.ID,
+		ParseMode: models.ParseModeHTML,
+		Text:      h.langFor(ctx, update.Message.From).T("import.help"),
+	})
+}
+
+// HandleDocument parses an uploaded file and shows a preview of the import.
+// Nothing is created until the user confirms.
+func (h *Handler) HandleDocument(ctx context.Context, b *bot.Bot, update *models.Update) {
+	doc := update.Message.Document
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, userID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	l := userLang(user)
+
+	if doc.FileSize > maxImportFileSize {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("import.too_large"),
+		})
+		return
+	}
+
+	data, err := downloadFile(ctx, b, doc.FileID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to download document")
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("import.download_failed"),
+		})
+		return
+	}
+
+	result, err := importer.Parse(doc.FileName, data, time.Now().In(user.Location()))
+	if err != nil {
+		text := l.T("import.parse_failed", err.Error())
+		if errors.Is(err, importer.ErrUnknownFormat) {
+			text = l.T("import.unknown_format")
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+		return
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      formatImportPreview(l, result),
+		ParseMode: models.ParseModeHTML,
+	}
+	if len(result.Items) > 0 {
+		h.stateManager.Set(userID, &UserState{Step: StateWaitingImportConfirm, Import: result})
+		params.ReplyMarkup = importKeyboard(l, len(result.Items))
+	}
+	b.SendMessage(ctx, params)
+}
+
+func (h *Handler) handleImportCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	state := h.stateManager.Get(userID)
+	if value != "confirm" || state == nil || state.Step != StateWaitingImportConfirm {
+		return
+	}
+	h.stateManager.Delete(userID)
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	created := 0
+	for _, item := range state.Import.Items {
+		if _, err := h.taskService.Create(ctx, user.ID, item.Description, item.Deadline, item.Importance, item.Frequency); err != nil {
+			log.Error().Ctx(ctx).Err(err).Str("description", item.Description).Msg("failed to import task")
+			continue
+		}
+		created++
+	}
+
+	l := userLang(user)
+	text := l.T("import.done", created)
+	if failed := len(state.Import.Items) - created; failed > 0 {
+		text += l.T("import.done_failed", failed)
+	}
+	if skipped := len(state.Import.Skipped); skipped > 0 {
+		text += l.T("import.done_skipped", skipped)
+	}
+	text += l.T("import.done_footer")
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func formatImportPreview(l i18n.Lang, result *importer.Result) string {
+	var sb strings.Builder
+	sb.WriteString(l.T("import.preview_header", result.Format))
+
+	if len(result.Items) == 0 {
+		sb.WriteString(l.T("import.preview_empty"))
+	} else {
+		sb.WriteString(l.T("import.preview_items", len(result.Items)))
+		for i, item := range result.Items {
+			if i == importPreviewSize {
+				sb.WriteString(l.T("import.preview_more", len(result.Items)-importPreviewSize))
+				break
+			}
+			fmt.Fprintf(&sb, "%d. %s — %s, %d/5, %s\n",
+				i+1,
+				render.Escape(render.Truncate(item.Description, 60)),
+				item.Deadline.Format("02.01.2006"),
+				item.Importance,
+				frequencyName(l, item.Frequency),
+			)
+		}
+	}
+
+	if len(result.Skipped) > 0 {
+		sb.WriteString(l.T("import.preview_skipped", len(result.Skipped)))
+		for i, s := range result.Skipped {
+			if i == importPreviewSize {
+				sb.WriteString(l.T("import.preview_more", len(result.Skipped)-importPreviewSize))
+				break
+			}
+			fmt.Fprintf(&sb, "• %s: %s\n", render.Escape(render.Truncate(s.Ref, 40)), render.Escape(s.Reason))
+		}
+	}
+
+	return sb.String()
+}
+
+func downloadFile(ctx context.Context, b *bot.Bot, fileID string) ([]byte, error) {
+	file, err := b.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, fmt.Errorf("get file: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download file: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+}
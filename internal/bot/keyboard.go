@@ -2,18 +2,20 @@ package bot
 
 import (
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/go-telegram/bot/models"
 
 	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
 )
 
-func mainMenuKeyboard() *models.ReplyKeyboardMarkup {
+func mainMenuKeyboard(l i18n.Lang) *models.ReplyKeyboardMarkup {
 	return &models.ReplyKeyboardMarkup{
 		Keyboard: [][]models.KeyboardButton{
-			{{Text: "Добавить задачу"}, {Text: "Мои задачи"}},
-			{{Text: "Настройки"}},
+			{{Text: l.T("menu.add")}, {Text: l.T("menu.list")}},
+			{{Text: l.T("menu.settings")}},
 		},
 		ResizeKeyboard: true,
 	}
@@ -35,106 +37,254 @@ func importanceKeyboard() *models.InlineKeyboardMarkup {
 	}
 }
 
-func frequencyKeyboard() *models.InlineKeyboardMarkup {
+func frequencyKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	frequencies := []domain.Frequency{domain.FrequencyDaily, domain.FrequencyEveryOtherDay, domain.FrequencyWeekly}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(frequencies))
+	for _, f := range frequencies {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: frequencyName(l, f), CallbackData: "frequency:" + f.String()},
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func taskActionsKeyboard(l i18n.Lang, task *domain.Task, view listView) *models.InlineKeyboardMarkup {
+	pause := models.InlineKeyboardButton{Text: l.T("button.pause"), CallbackData: fmt.Sprintf("pause:%d:%s", task.ID, view)}
+	if task.IsPaused() {
+		pause = models.InlineKeyboardButton{Text: l.T("button.resume"), CallbackData: fmt.Sprintf("resume:%d:%s", task.ID, view)}
+	}
+
+	related := []models.InlineKeyboardButton{
+		{Text: l.T("button.dependencies"), CallbackData: fmt.Sprintf("deps:%d:%s", task.ID, view)},
+	}
+	// Files are attached by sending them to the bot, so only personal tasks
+	// have attachments.
+	if task.ChatID == nil {
+		related = append(related, models.InlineKeyboardButton{
+			Text: l.T("button.attachments"), CallbackData: fmt.Sprintf("att:%d:%s", task.ID, view),
+		})
+	}
+	related = append(related, models.InlineKeyboardButton{Text: l.T("button.back_to_list"), CallbackData: "list:" + view.String()})
+
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "Ежедневно", CallbackData: "frequency:daily"}},
-			{{Text: "Через день", CallbackData: "frequency:every_other_day"}},
-			{{Text: "Раз в неделю", CallbackData: "frequency:weekly"}},
+			{
+				{Text: l.T("button.done"), CallbackData: fmt.Sprintf("done:%d", task.ID)},
+				pause,
+				{Text: l.T("button.delete"), CallbackData: fmt.Sprintf("delete:%d", task.ID)},
+			},
+			related,
 		},
 	}
 }
 
-func taskActionsKeyboard(taskID int64) *models.InlineKeyboardMarkup {
+func reminderKeyboard(l i18n.Lang, taskID int64) *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "Выполнено", CallbackData: fmt.Sprintf("done:%d", taskID)},
-				{Text: "Удалить", CallbackData: fmt.Sprintf("delete:%d", taskID)},
+				{Text: l.T("button.done"), CallbackData: fmt.Sprintf("done:%d", taskID)},
+			},
+		},
+	}
+}
+
+// sentReminderKeyboard is under reminders the scheduler sends, which can
+// also be snoozed.
+func sentReminderKeyboard(l i18n.Lang, taskID int64, attachments int) *models.InlineKeyboardMarkup {
+	return withAttachmentsButton(l, &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: l.T("button.done"), CallbackData: fmt.Sprintf("done:%d", taskID)},
+				{Text: l.T("button.snooze"), CallbackData: fmt.Sprintf("snooze:%d", taskID)},
 			},
 		},
+	}, taskID, attachments)
+}
+
+// snoozedKeyboard replaces the snooze button with the time the reminder was
+// postponed to.
+func snoozedKeyboard(l i18n.Lang, taskID int64, until time.Time, attachments int) *models.InlineKeyboardMarkup {
+	return withAttachmentsButton(l, &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: l.T("button.done"), CallbackData: fmt.Sprintf("done:%d", taskID)},
+				{Text: l.T("button.snoozed", until.Format("15:04")), CallbackData: "noop"},
+			},
+		},
+	}, taskID, attachments)
+}
+
+// withAttachmentsButton adds a button sending a reminded task's attachments
+// when it has any.
+func withAttachmentsButton(l i18n.Lang, kb *models.InlineKeyboardMarkup, taskID int64, attachments int) *models.InlineKeyboardMarkup {
+	if attachments > 0 {
+		kb.InlineKeyboard = append(kb.InlineKeyboard, []models.InlineKeyboardButton{
+			{Text: l.T("button.attachments_count", attachments), CallbackData: fmt.Sprintf("att_all:%d", taskID)},
+		})
 	}
+	return kb
 }
 
-func reminderKeyboard(taskID int64) *models.InlineKeyboardMarkup {
+func assignmentKeyboard(l i18n.Lang, taskID int64) *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "Выполнено", CallbackData: fmt.Sprintf("done:%d", taskID)},
+				{Text: l.T("button.accept"), CallbackData: fmt.Sprintf("assign:accept:%d", taskID)},
+				{Text: l.T("button.decline"), CallbackData: fmt.Sprintf("assign:decline:%d", taskID)},
 			},
 		},
 	}
 }
 
-func settingsKeyboard() *models.InlineKeyboardMarkup {
+func undoKeyboard(l i18n.Lang, action string, taskID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("button.undo"), CallbackData: fmt.Sprintf("%s:%d", action, taskID)}},
+		},
+	}
+}
+
+func chartKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("chart.button.completions"), CallbackData: "chart:completions"}},
+			{{Text: l.T("chart.button.burndown"), CallbackData: "chart:burndown"}},
+			{{Text: l.T("chart.button.hours"), CallbackData: "chart:hours"}},
+		},
+	}
+}
+
+func calendarKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "Рабочие часы в день", CallbackData: "settings:work_hours"}},
-			{{Text: "Часовой пояс", CallbackData: "settings:timezone"}},
+			{{Text: l.T("calendar.button.rotate"), CallbackData: "calendar:rotate"}},
 		},
 	}
 }
 
-func workHoursKeyboard() *models.InlineKeyboardMarkup {
+func importKeyboard(l i18n.Lang, count int) *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "6 часов", CallbackData: "work_hours:6"},
-				{Text: "8 часов", CallbackData: "work_hours:8"},
-				{Text: "10 часов", CallbackData: "work_hours:10"},
+				{Text: l.T("import.button.confirm", count), CallbackData: "import:confirm"},
+				{Text: l.T("button.cancel"), CallbackData: "cancel"},
 			},
+		},
+	}
+}
+
+func broadcastKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "12 часов", CallbackData: "work_hours:12"},
+				{Text: l.T("admin.button.broadcast"), CallbackData: "broadcast:confirm"},
+				{Text: l.T("button.cancel"), CallbackData: "cancel"},
 			},
 		},
 	}
 }
 
-func cancelKeyboard() *models.InlineKeyboardMarkup {
+func deleteAccountKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("account.button.delete"), CallbackData: "account:delete"}},
+			{{Text: l.T("button.cancel"), CallbackData: "cancel"}},
+		},
+	}
+}
+
+// webhooksKeyboard has a delete button per webhook, or is nil when there are none.
+func webhooksKeyboard(hooks []*domain.Webhook) *models.InlineKeyboardMarkup {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	var row []models.InlineKeyboardButton
+	for i, hook := range hooks {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("🗑 %d", i+1),
+			CallbackData: fmt.Sprintf("webhook_delete:%d", hook.ID),
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
+
+func settingsKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "Отмена", CallbackData: "cancel"}},
+			{{Text: l.T("settings.button.work_hours"), CallbackData: "settings:work_hours"}},
+			{{Text: l.T("settings.button.timezone"), CallbackData: "settings:timezone"}},
+			{{Text: l.T("settings.button.language"), CallbackData: "settings:language"}},
+			{{Text: l.T("settings.button.style"), CallbackData: "settings:style"}},
+			{{Text: l.T("settings.button.quiet_mode"), CallbackData: "settings:quiet_mode"}},
 		},
 	}
 }
 
-func formatTaskMessage(task *domain.Task, user *domain.User) string {
-	days := task.DaysUntilDeadline()
-	hours := task.WorkHoursRemaining(user.WorkHoursPerDay)
+func workHoursKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	button := func(hours int) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{Text: l.N("hours", hours), CallbackData: fmt.Sprintf("work_hours:%d", hours)}
+	}
 
-	daysText := "дней"
-	if days == 1 {
-		daysText = "день"
-	} else if days >= 2 && days <= 4 {
-		daysText = "дня"
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{button(6), button(8), button(10)},
+			{button(12)},
+		},
 	}
+}
+
+// languageKeyboard names every language in itself, so that users can find
+// theirs whatever the current one is.
+func languageKeyboard() *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(i18n.Languages))
+	for _, l := range i18n.Languages {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: l.Name(), CallbackData: "language:" + string(l)},
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
 
-	hoursText := "часов"
-	if hours == 1 {
-		hoursText = "час"
-	} else if hours >= 2 && hours <= 4 {
-		hoursText = "часа"
+func styleKeyboard(l i18n.Lang, current render.Style) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(render.Styles))
+	for _, style := range render.Styles {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: selectedLabel(styleName(l, style), style == current), CallbackData: "style:" + string(style)},
+		})
 	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
 
-	return fmt.Sprintf(`📋 <b>%s</b>
+func styleName(l i18n.Lang, style render.Style) string {
+	return l.T("style." + string(style))
+}
 
-⏰ До дедлайна: <b>%d %s</b>
-⏱ Рабочих часов осталось: <b>%d %s</b>
-⚡ Важность: %s (%d/5)
-🔄 Частота: %s`,
-		escapeHTML(task.Description),
-		days, daysText,
-		hours, hoursText,
-		task.ImportanceStars(), task.Importance,
-		task.Frequency.DisplayName(),
-	)
+func cancelKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("button.cancel"), CallbackData: "cancel"}},
+		},
+	}
+}
+
+// frequencyName is the frequency as shown to users; unknown values are shown
+// as stored.
+func frequencyName(l i18n.Lang, f domain.Frequency) string {
+	if _, ok := domain.ParseFrequency(f.String()); !ok {
+		return f.String()
+	}
+	return l.T("frequency." + f.String())
 }
 
-func escapeHTML(s string) string {
-	s = strings.ReplaceAll(s, "&", "&amp;")
-	s = strings.ReplaceAll(s, "<", "&lt;")
-	s = strings.ReplaceAll(s, ">", "&gt;")
-	return s
+// confirmation renders task under a title saying what happened to it. The
+// title is plain text; the template escapes it.
+func confirmation(l i18n.Lang, style render.Style, title string, task *domain.Task, workHoursPerDay int) string {
+	return render.Confirmation(l, style, render.ConfirmationView{
+		Title: title,
+		Task:  render.NewTaskView(task, workHoursPerDay),
+	})
 }
 
 func escapeMarkdown(s string) string {
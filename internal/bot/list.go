@@ -0,0 +1,259 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+)
+
+const listPageSize = 8
+
+// listView is the state of the /list message. It is round-tripped through
+// callback data so that every button can re-render the same message in place.
+type listView struct {
+	Page   int
+	Sort   domain.TaskSort
+	Filter domain.TaskFilter
+}
+
+func defaultListView() listView {
+	return listView{Sort: domain.TaskSortDeadline, Filter: domain.TaskFilterAll}
+}
+
+func (v listView) String() string {
+	return fmt.Sprintf("%d:%s:%s", v.Page, v.Sort, v.Filter)
+}
+
+func parseListView(s string) (listView, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return listView{}, false
+	}
+
+	page, err := strconv.Atoi(parts[0])
+	if err != nil || page < 0 {
+		return listView{}, false
+	}
+	sort, ok := domain.ParseTaskSort(parts[1])
+	if !ok {
+		return listView{}, false
+	}
+	filter, ok := domain.ParseTaskFilter(parts[2])
+	if !ok {
+		return listView{}, false
+	}
+
+	return listView{Page: page, Sort: sort, Filter: filter}, true
+}
+
+// taskPage is one page of /list: active tasks first and then, in the
+// unfiltered list, paused ones. Paused tasks have no deadline pressure, so
+// they are listed in a section of their own that continues the pages after
+// the last active task.
+type taskPage struct {
+	Active       []*domain.Task
+	ActiveTotal  int
+	Paused       []*domain.Task
+	PausedTotal  int
+	PausedOffset int
+}
+
+func (p taskPage) pages() int {
+	return (p.ActiveTotal + p.PausedTotal + listPageSize - 1) / listPageSize
+}
+
+func (h *Handler) renderTaskList(ctx context.Context, scope *taskScope, view listView) (string, *models.InlineKeyboardMarkup, error) {
+	now := time.Now().In(scope.location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	page, err := h.listPage(ctx, scope, view, today)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The page may have disappeared after tasks were completed or deleted.
+	if pages := page.pages(); view.Page > 0 && view.Page >= pages {
+		view.Page = max(pages-1, 0)
+		page, err = h.listPage(ctx, scope, view, today)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	l := scope.lang()
+	if page.ActiveTotal == 0 && page.PausedTotal == 0 && view.Filter == domain.TaskFilterAll && scope.chat != nil {
+		return l.T("group.no_tasks"), nil, nil
+	}
+
+	deps, err := h.dependencies(ctx, scope)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return formatTaskList(l, scope.style(), page, deps, view, today, scope.workHoursPerDay()), taskListKeyboard(l, page, view), nil
+}
+
+// listPage loads the tasks on the page of view.
+func (h *Handler) listPage(ctx context.Context, scope *taskScope, view listView, today time.Time) (taskPage, error) {
+	opts := domain.TaskListOptions{
+		Sort:   view.Sort,
+		Filter: view.Filter,
+		Today:  today,
+		Limit:  listPageSize,
+		Offset: view.Page * listPageSize,
+		Paused: domain.PausedExclude,
+	}
+
+	var page taskPage
+	var err error
+	page.Active, page.ActiveTotal, err = h.listActive(ctx, scope, opts)
+	if err != nil || view.Filter != domain.TaskFilterAll {
+		return page, err
+	}
+
+	// The rest of the page is filled with paused tasks. A page full of
+	// active ones still needs their count for the number of pages.
+	page.PausedOffset = max(opts.Offset-page.ActiveTotal, 0)
+	opts.Paused = domain.PausedOnly
+	opts.Offset = page.PausedOffset
+	opts.Limit = listPageSize - len(page.Active)
+	page.Paused, page.PausedTotal, err = h.listActive(ctx, scope, opts)
+	return page, err
+}
+
+func (h *Handler) listActive(ctx context.Context, scope *taskScope, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	if scope.chat != nil {
+		return h.taskService.ListActiveInChat(ctx, scope.chat.ID, opts)
+	}
+	return h.taskService.ListActive(ctx, scope.user.ID, opts)
+}
+
+// formatTaskList renders a page of tasks, with the paused ones in a section
+// of their own and what each task waits for taken from deps.
+func formatTaskList(l i18n.Lang, style render.Style, page taskPage, deps *domain.Dependencies, view listView, today time.Time, workHoursPerDay int) string {
+	if page.ActiveTotal == 0 && page.PausedTotal == 0 {
+		if view.Filter == domain.TaskFilterAll {
+			return l.T("list.empty")
+		}
+		return l.T("list.empty_filter", filterTitle(l, view.Filter))
+	}
+
+	digest := render.DigestView{
+		Icon:    "📋",
+		Title:   l.T("list.title"),
+		Details: []string{filterTitle(l, view.Filter), sortTitle(l, view.Sort)},
+		Summary: l.T("list.summary", page.ActiveTotal, view.Page+1, max(page.pages(), 1)),
+	}
+	for i, task := range page.Active {
+		digest.Items = append(digest.Items, render.ItemView{
+			TaskView: taskView(task, deps, workHoursPerDay),
+			Number:   view.Page*listPageSize + i + 1,
+			Overdue:  task.Deadline.Before(today),
+		})
+	}
+	if len(page.Paused) > 0 {
+		section := render.DigestSection{Title: l.T("list.paused_title", page.PausedTotal)}
+		for i, task := range page.Paused {
+			section.Items = append(section.Items, render.ItemView{
+				TaskView: taskView(task, deps, workHoursPerDay),
+				Number:   page.ActiveTotal + page.PausedOffset + i + 1,
+			})
+		}
+		digest.Sections = append(digest.Sections, section)
+	}
+
+	return render.Digest(l, style, digest)
+}
+
+func taskListKeyboard(l i18n.Lang, page taskPage, view listView) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	var row []models.InlineKeyboardButton
+	addTask := func(task *domain.Task, number int) {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         strconv.Itoa(number),
+			CallbackData: fmt.Sprintf("task:%d:%s", task.ID, view),
+		})
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	for i, task := range page.Active {
+		addTask(task, view.Page*listPageSize+i+1)
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+		row = nil
+	}
+	for i, task := range page.Paused {
+		addTask(task, page.ActiveTotal+page.PausedOffset+i+1)
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	if pages := page.pages(); pages > 1 {
+		prev, next := view, view
+		prev.Page = (view.Page - 1 + pages) % pages
+		next.Page = (view.Page + 1) % pages
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "◀", CallbackData: "list:" + prev.String()},
+			{Text: fmt.Sprintf("%d/%d", view.Page+1, pages), CallbackData: "noop"},
+			{Text: "▶", CallbackData: "list:" + next.String()},
+		})
+	}
+
+	var sortRow []models.InlineKeyboardButton
+	for _, sort := range []domain.TaskSort{domain.TaskSortDeadline, domain.TaskSortImportance, domain.TaskSortCreated} {
+		v := listView{Sort: sort, Filter: view.Filter}
+		sortRow = append(sortRow, models.InlineKeyboardButton{
+			Text:         selectedLabel(sortButtonText(l, sort), sort == view.Sort),
+			CallbackData: "list:" + v.String(),
+		})
+	}
+	rows = append(rows, sortRow)
+
+	var filterRow []models.InlineKeyboardButton
+	for _, filter := range []domain.TaskFilter{domain.TaskFilterAll, domain.TaskFilterToday, domain.TaskFilterWeek, domain.TaskFilterOverdue} {
+		v := listView{Sort: view.Sort, Filter: filter}
+		filterRow = append(filterRow, models.InlineKeyboardButton{
+			Text:         selectedLabel(filterButtonText(l, filter), filter == view.Filter),
+			CallbackData: "list:" + v.String(),
+		})
+	}
+	rows = append(rows, filterRow)
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func selectedLabel(text string, selected bool) string {
+	if selected {
+		return "• " + text
+	}
+	return text
+}
+
+func sortButtonText(l i18n.Lang, sort domain.TaskSort) string {
+	return l.T("list.sort_button." + string(sort))
+}
+
+func sortTitle(l i18n.Lang, sort domain.TaskSort) string {
+	return l.T("list.sort_title." + string(sort))
+}
+
+func filterButtonText(l i18n.Lang, filter domain.TaskFilter) string {
+	return l.T("list.filter_button." + string(filter))
+}
+
+func filterTitle(l i18n.Lang, filter domain.TaskFilter) string {
+	return l.T("list.filter_title." + string(filter))
+}
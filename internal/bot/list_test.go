@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/service"
+)
+
+// listTasks pages through active and paused tasks like the repository does.
+type listTasks struct {
+	repository.TaskRepository
+	active, paused int
+}
+
+func (r listTasks) ListActiveByUserID(_ context.Context, _ int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	total, id := r.active, int64(1)
+	if opts.Paused == domain.PausedOnly {
+		total, id = r.paused, 1001
+	}
+
+	var tasks []*domain.Task
+	for i := opts.Offset; i < min(opts.Offset+opts.Limit, total); i++ {
+		tasks = append(tasks, &domain.Task{ID: id + int64(i)})
+	}
+	return tasks, total, nil
+}
+
+func TestListPage(t *testing.T) {
+	tests := []struct {
+		name           string
+		active, paused int
+		page           int
+		wantPages      int
+		wantActive     []int64
+		wantPaused     []int64
+	}{
+		{"active only", 3, 0, 0, 1, []int64{1, 2, 3}, nil},
+		{"paused after the active ones", 3, 2, 0, 1, []int64{1, 2, 3}, []int64{1001, 1002}},
+		{"page full of active tasks", 10, 9, 0, 3, []int64{1, 2, 3, 4, 5, 6, 7, 8}, nil},
+		{"paused section starts", 10, 9, 1, 3, []int64{9, 10}, []int64{1001, 1002, 1003, 1004, 1005, 1006}},
+		{"paused section continues", 10, 9, 2, 3, nil, []int64{1007, 1008, 1009}},
+		{"paused only", 0, 12, 1, 2, nil, []int64{1009, 1010, 1011, 1012}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{taskService: service.NewTaskService(listTasks{active: tt.active, paused: tt.paused}, nil, nil, service.TaskServiceConfig{})}
+			scope := &taskScope{user: &domain.User{ID: 7}}
+			view := defaultListView()
+			view.Page = tt.page
+
+			page, err := h.listPage(context.Background(), scope, view, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("listPage() error = %v", err)
+			}
+
+			if page.pages() != tt.wantPages {
+				t.Errorf("pages() = %d, want %d", page.pages(), tt.wantPages)
+			}
+			if got := taskIDs(page.Active); fmt.Sprint(got) != fmt.Sprint(tt.wantActive) {
+				t.Errorf("Active = %v, want %v", got, tt.wantActive)
+			}
+			if got := taskIDs(page.Paused); fmt.Sprint(got) != fmt.Sprint(tt.wantPaused) {
+				t.Errorf("Paused = %v, want %v", got, tt.wantPaused)
+			}
+			if page.PausedTotal != tt.paused {
+				t.Errorf("PausedTotal = %d, want %d", page.PausedTotal, tt.paused)
+			}
+		})
+	}
+}
+
+func TestTaskListKeyboardNumbersPausedTasks(t *testing.T) {
+	page := taskPage{
+		ActiveTotal:  10,
+		Paused:       []*domain.Task{{ID: 1007}, {ID: 1008}, {ID: 1009}},
+		PausedTotal:  9,
+		PausedOffset: 6,
+	}
+	view := defaultListView()
+	view.Page = 2
+
+	markup := taskListKeyboard(i18n.Parse("en"), page, view)
+
+	var numbers []string
+	for _, button := range markup.InlineKeyboard[0] {
+		numbers = append(numbers, button.Text)
+	}
+	if fmt.Sprint(numbers) != "[17 18 19]" {
+		t.Errorf("task buttons = %v, want [17 18 19]", numbers)
+	}
+	if got := markup.InlineKeyboard[1][1].Text; got != "3/3" {
+		t.Errorf("page button = %q, want 3/3", got)
+	}
+}
+
+func taskIDs(tasks []*domain.Task) []int64 {
+	var ids []int64
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/quickadd"
+)
+
+// pauseDays are the ready-made pause lengths offered under a task.
+var pauseDays = []int{1, 3, 7}
+
+// pauseUntilDate and pauseIndefinitely are the other "pause_for" options.
+const (
+	pauseUntilDate    = "date"
+	pauseIndefinitely = "0"
+)
+
+// handlePauseCallback replaces the task card buttons with pause options;
+// value is "<task id>:<list view>".
+func (h *Handler) handlePauseCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil || task.IsCompleted {
+		return
+	}
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chat.ID,
+		MessageID:   messageID,
+		ReplyMarkup: pauseKeyboard(scope.lang(), task.ID, view, scope.chat == nil),
+	})
+}
+
+// handlePauseForCallback pauses a task for one of the options of
+// pauseKeyboard; value is "<task id>:<days|date>:<list view>", where 0 days
+// pauses until the task is resumed and "date" asks for the date in a message.
+func (h *Handler) handlePauseForCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	option, rest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil || task.IsCompleted {
+		return
+	}
+	l := scope.lang()
+
+	if option == pauseUntilDate {
+		// Groups have no conversations with the bot, so only the preset
+		// options are offered there.
+		if scope.chat != nil {
+			return
+		}
+		h.stateManager.Set(from.ID, &UserState{Step: StateWaitingPauseDate, TaskID: task.ID})
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chat.ID,
+			Text:        l.T("pause.ask_date"),
+			ReplyMarkup: cancelKeyboard(l),
+		})
+		return
+	}
+
+	days, err := strconv.Atoi(option)
+	if err != nil || days < 0 {
+		return
+	}
+	var until *time.Time
+	if days > 0 {
+		date := scopeToday(scope).AddDate(0, 0, days)
+		until = &date
+	}
+
+	if err := h.taskService.Pause(ctx, task, until); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to pause task")
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: l.T("pause.failed")})
+		return
+	}
+
+	h.editTaskCard(ctx, b, chat.ID, messageID, scope, task, view, l.T("pause.paused"))
+}
+
+// handleResumeCallback resumes a paused task; value is
+// "<task id>:<list view>".
+func (h *Handler) handleResumeCallback(ctx context.Context, b *bot.Bot, chat models.Chat, messageID int, from *models.User, value string) {
+	id, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return
+	}
+	view, ok := parseListView(rest)
+	if !ok {
+		return
+	}
+
+	scope, task := h.taskForCallback(ctx, chat, from, id)
+	if task == nil || !task.IsPaused() {
+		return
+	}
+	l := scope.lang()
+
+	if err := h.taskService.Resume(ctx, task); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to resume task")
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: l.T("pause.failed")})
+		return
+	}
+
+	h.editTaskCard(ctx, b, chat.ID, messageID, scope, task, view, l.T("pause.resumed"))
+}
+
+// handlePauseDateInput pauses the task from state until the date the user
+// typed.
+func (h *Handler) handlePauseDateInput(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User, state *UserState, text string) {
+	l := userLang(user)
+
+	now := time.Now().In(user.Location())
+	date, ok := quickadd.ParseDate(text, now)
+	if !ok {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("pause.bad_date")})
+		return
+	}
+	scope := &taskScope{user: user}
+	if !date.After(scopeToday(scope)) {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("pause.past_date")})
+		return
+	}
+
+	h.stateManager.Delete(user.TelegramID)
+
+	task, err := h.taskService.GetByID(ctx, state.TaskID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", state.TaskID).Msg("failed to get task")
+		return
+	}
+	if task == nil || !scope.owns(task) || task.IsCompleted {
+		return
+	}
+
+	if err := h.taskService.Pause(ctx, task, &date); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to pause task")
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: l.T("pause.failed")})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        confirmation(l, scope.style(), l.T("pause.paused"), task, scope.workHoursPerDay()),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: taskActionsKeyboard(l, task, defaultListView()),
+	})
+}
+
+// editTaskCard shows the task card with title above it in place of the
+// message the buttons were under.
+func (h *Handler) editTaskCard(ctx context.Context, b *bot.Bot, chatID int64, messageID int, scope *taskScope, task *domain.Task, view listView, title string) {
+	l := scope.lang()
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        confirmation(l, scope.style(), title, task, scope.workHoursPerDay()),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: taskActionsKeyboard(l, task, view),
+	})
+}
+
+// scopeToday is the current date where the scope is, as a date at midnight
+// UTC like task deadlines.
+func scopeToday(scope *taskScope) time.Time {
+	now := time.Now().In(scope.location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// pauseKeyboard offers how long to pause a task for. Picking a date needs a
+// reply, so it is only offered in private chats.
+func pauseKeyboard(l i18n.Lang, taskID int64, view listView, withDate bool) *models.InlineKeyboardMarkup {
+	var days []models.InlineKeyboardButton
+	for _, d := range pauseDays {
+		days = append(days, models.InlineKeyboardButton{
+			Text:         l.N("days", d),
+			CallbackData: fmt.Sprintf("pause_for:%d:%d:%s", taskID, d, view),
+		})
+	}
+
+	rows := [][]models.InlineKeyboardButton{days}
+	if withDate {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: l.T("button.pause_until_date"), CallbackData: fmt.Sprintf("pause_for:%d:%s:%s", taskID, pauseUntilDate, view)},
+		})
+	}
+	rows = append(rows,
+		[]models.InlineKeyboardButton{
+			{Text: l.T("button.pause_indefinitely"), CallbackData: fmt.Sprintf("pause_for:%d:%s:%s", taskID, pauseIndefinitely, view)},
+		},
+		[]models.InlineKeyboardButton{
+			{Text: l.T("button.back"), CallbackData: fmt.Sprintf("task:%d:%s", taskID, view)},
+		},
+	)
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
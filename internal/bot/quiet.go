@@ -0,0 +1,334 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/service"
+)
+
+// maxDND is the longest /dnd accepts; longer breaks are better set up as
+// quiet hours.
+const maxDND = 7 * 24 * time.Hour
+
+// HandleDND manages Do Not Disturb: "/dnd" shows it with buttons, "/dnd 2h"
+// turns it on for a while, "/dnd tomorrow" until the next working day
+// starts and "/dnd off" turns it off.
+func (h *Handler) HandleDND(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	args, ok := commandArgs(update.Message.Text, "/dnd")
+	if !ok {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	user, err := h.userService.GetOrCreate(ctx, update.Message.From.ID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+	l := userLang(user)
+
+	if args == "" {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        dndStatus(l, user),
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: dndKeyboard(l),
+		})
+		return
+	}
+
+	text, ok := h.setDND(ctx, user, args)
+	if !ok {
+		text = l.T("dnd.usage")
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML})
+}
+
+func (h *Handler) handleDNDCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	text, ok := h.setDND(ctx, user, value)
+	if !ok {
+		return
+	}
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+// setDND sets Do Not Disturb as "/dnd <value>" asks and returns the answer
+// to the user; ok is false when value is not understood.
+func (h *Handler) setDND(ctx context.Context, user *domain.User, value string) (text string, ok bool) {
+	until, ok := dndUntil(user, value, time.Now())
+	if !ok {
+		return "", false
+	}
+
+	l := userLang(user)
+	if err := h.quietService.SetDND(ctx, user, until); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to set do not disturb")
+		return l.T("dnd.failed"), true
+	}
+	if until == nil {
+		return l.T("dnd.disabled"), true
+	}
+	return l.T("dnd.enabled", formatQuietTime(user, *until)), true
+}
+
+// dndUntil parses the argument of /dnd: "off", "tomorrow", meaning the start
+// of the user's next working day, or a duration such as "2h" or "30m". A nil
+// time turns Do Not Disturb off.
+func dndUntil(user *domain.User, value string, now time.Time) (until *time.Time, ok bool) {
+	switch strings.ToLower(value) {
+	case "off":
+		return nil, true
+	case "tomorrow":
+		local := now.In(user.Location())
+		t := time.Date(local.Year(), local.Month(), local.Day()+1, user.WorkStartHour, 0, 0, 0, local.Location())
+		return &t, true
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Minute || d > maxDND {
+		return nil, false
+	}
+	t := now.Add(d)
+	return &t, true
+}
+
+func dndStatus(l i18n.Lang, user *domain.User) string {
+	if user.DNDUntil == nil || !user.DNDUntil.After(time.Now()) {
+		return l.T("dnd.status_off")
+	}
+	return l.T("dnd.status_on", formatQuietTime(user, *user.DNDUntil))
+}
+
+// formatQuietTime shows t in the user's timezone, with the date unless it
+// is today.
+func formatQuietTime(user *domain.User, t time.Time) string {
+	t = t.In(user.Location())
+	if now := time.Now().In(user.Location()); t.YearDay() == now.YearDay() && t.Year() == now.Year() {
+		return t.Format("15:04")
+	}
+	return t.Format("02.01 15:04")
+}
+
+// HandleQuiet manages quiet hours: "/quiet" lists them with buttons to
+// remove them and "/quiet mon-fri 13:00-14:00" adds one.
+func (h *Handler) HandleQuiet(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	args, ok := commandArgs(update.Message.Text, "/quiet")
+	if !ok {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	user, err := h.userService.GetOrCreate(ctx, update.Message.From.ID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if args == "" {
+		text, markup, err := h.renderQuietPeriods(ctx, user)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to list quiet periods")
+			return
+		}
+		params := &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML}
+		if markup != nil {
+			params.ReplyMarkup = markup
+		}
+		b.SendMessage(ctx, params)
+		return
+	}
+
+	l := userLang(user)
+	period, err := h.quietService.AddPeriod(ctx, user, args)
+	if err != nil {
+		text := l.T("quiet.add_failed")
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuietPeriod):
+			text = l.T("quiet.invalid")
+		case errors.Is(err, service.ErrTooManyQuietPeriods):
+			text = l.T("quiet.too_many")
+		default:
+			log.Error().Ctx(ctx).Err(err).Msg("failed to add quiet period")
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   l.T("quiet.added", period.Times(), weekdaysName(l, period.Weekdays)),
+	})
+}
+
+func (h *Handler) renderQuietPeriods(ctx context.Context, user *domain.User) (string, *models.InlineKeyboardMarkup, error) {
+	periods, err := h.quietService.Periods(ctx, user)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l := userLang(user)
+	var sb strings.Builder
+	sb.WriteString(l.T("quiet.list_header"))
+	if len(periods) == 0 {
+		sb.WriteString(l.T("quiet.list_empty"))
+	}
+	for i, p := range periods {
+		sb.WriteString(l.T("quiet.list_entry", i+1, p.Times(), weekdaysName(l, p.Weekdays)))
+	}
+	sb.WriteString(l.T("quiet.list_footer"))
+
+	return sb.String(), quietPeriodsKeyboard(periods), nil
+}
+
+func (h *Handler) handleQuietDeleteCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if _, err := h.quietService.DeletePeriod(ctx, user, id); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to delete quiet period")
+		return
+	}
+
+	text, markup, err := h.renderQuietPeriods(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list quiet periods")
+		return
+	}
+	params := &bot.EditMessageTextParams{ChatID: chatID, MessageID: messageID, Text: text, ParseMode: models.ParseModeHTML}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	b.EditMessageText(ctx, params)
+}
+
+func (h *Handler) handleQuietModeCallback(ctx context.Context, b *bot.Bot, chatID int64, userID int64, value string) {
+	if !slices.Contains(quietModes, value) {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if err := h.quietService.SetMode(ctx, user, value); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to update user settings")
+		return
+	}
+
+	l := userLang(user)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        l.T("settings.quiet_mode_updated", quietModeName(l, value)),
+		ReplyMarkup: mainMenuKeyboard(l),
+	})
+}
+
+// quietModes are the choices of how reminders deferred by quiet hours are
+// delivered, in the order they are offered.
+var quietModes = []string{domain.QuietDefer, domain.QuietSummary}
+
+// quietModeName names the user's quiet mode; empty means QuietDefer.
+func quietModeName(l i18n.Lang, mode string) string {
+	if mode == "" {
+		mode = domain.QuietDefer
+	}
+	return l.T("quiet_mode." + mode)
+}
+
+// weekdaysName lists the days of a quiet period, e.g. "Mon, Wed".
+func weekdaysName(l i18n.Lang, w domain.Weekdays) string {
+	days := w.Days()
+	if days == nil {
+		return l.T("quiet.every_day")
+	}
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		names = append(names, l.T("weekday."+strings.ToLower(d.String()[:3])))
+	}
+	return strings.Join(names, ", ")
+}
+
+func dndKeyboard(l i18n.Lang) *models.InlineKeyboardMarkup {
+	button := func(hours int) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{Text: l.N("hours", hours), CallbackData: fmt.Sprintf("dnd:%dh", hours)}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{button(1), button(2), button(4)},
+			{{Text: l.T("button.dnd_tomorrow"), CallbackData: "dnd:tomorrow"}},
+			{{Text: l.T("button.dnd_off"), CallbackData: "dnd:off"}},
+		},
+	}
+}
+
+func quietPeriodsKeyboard(periods []*domain.QuietPeriod) *models.InlineKeyboardMarkup {
+	if len(periods) == 0 {
+		return nil
+	}
+
+	var row []models.InlineKeyboardButton
+	for i, p := range periods {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("🗑 %d", i+1),
+			CallbackData: fmt.Sprintf("quiet_delete:%d", p.ID),
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
+
+func quietModeKeyboard(l i18n.Lang, current string) *models.InlineKeyboardMarkup {
+	if current == "" {
+		current = domain.QuietDefer
+	}
+	rows := make([][]models.InlineKeyboardButton, 0, len(quietModes))
+	for _, mode := range quietModes {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: selectedLabel(quietModeName(l, mode), mode == current), CallbackData: "quiet_mode:" + mode},
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
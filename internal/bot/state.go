@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/importer"
 )
 
 type UserState struct {
@@ -13,6 +14,17 @@ type UserState struct {
 	Deadline    time.Time
 	Importance  int
 	Frequency   domain.Frequency
+	// Assignee is set when the task is being assigned to another user.
+	Assignee *domain.User
+	// Import holds a parsed file waiting for confirmation.
+	Import *importer.Result
+	// Broadcast holds an admin's message waiting for confirmation.
+	Broadcast string
+	// TaskID is the task a pause date or attachments are being asked for.
+	TaskID int64
+	// Attachments are attached to the task being added once it is created,
+	// such as the message it was forwarded from.
+	Attachments []*domain.Attachment
 }
 
 type StateManager struct {
@@ -49,4 +61,10 @@ const (
 	StateWaitingDeadline    = "waiting_deadline"
 	StateWaitingImportance  = "waiting_importance"
 	StateWaitingFrequency   = "waiting_frequency"
+	StateWaitingAssignee    = "waiting_assignee"
+	StateWaitingPauseDate   = "waiting_pause_date"
+	StateWaitingAttachment  = "waiting_attachment"
+
+	StateWaitingImportConfirm    = "waiting_import_confirm"
+	StateWaitingBroadcastConfirm = "waiting_broadcast_confirm"
 )
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+)
+
+func (h *Handler) HandleStats(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	telegramID := update.Message.From.ID
+
+	user, err := h.userService.GetOrCreate(ctx, telegramID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	stats, err := h.statsService.GetStats(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get stats")
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      formatStatsMessage(userLang(user), stats),
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func formatStatsMessage(l i18n.Lang, stats domain.Stats) string {
+	if stats.CompletedTotal == 0 {
+		return l.T("stats.empty")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(l.T("stats.summary",
+		stats.CompletedThisWeek, stats.CompletedThisMonth, stats.CompletedTotal,
+		stats.OnTime, stats.Late, stats.OnTimeRate()*100,
+		stats.AvgRemindersBeforeCompletion,
+		stats.CurrentStreak, stats.BestStreak,
+	))
+
+	sb.WriteString(l.T("stats.by_importance"))
+	for importance := 5; importance >= 1; importance-- {
+		task := domain.Task{Importance: importance}
+		fmt.Fprintf(&sb, "%s — %d\n", task.ImportanceStars(), stats.CompletedByImportance[importance])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/render"
+)
+
+// HandleToken issues an API token; "/token revoke" revokes all of them.
+func (h *Handler) HandleToken(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	args, ok := commandArgs(update.Message.Text, "/token")
+	if !ok {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	user, err := h.userService.GetOrCreate(ctx, update.Message.From.ID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	l := userLang(user)
+	switch args {
+	case "":
+	case "revoke":
+		revoked, err := h.userService.RevokeAPITokens(ctx, user)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to revoke api tokens")
+			return
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("token.revoked", revoked),
+		})
+		return
+	default:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   l.T("token.usage"),
+		})
+		return
+	}
+
+	token, err := h.userService.IssueAPIToken(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to issue api token")
+		return
+	}
+
+	text := l.T("token.issued", render.Escape(token))
+	if h.publicURL != "" {
+		text += l.T("token.api_url", render.Escape(h.publicURL), render.Escape(h.publicURL))
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"telegram-reminder-bot/internal/tracing"
+)
+
+// traceUpdate starts a span for every incoming update. Handlers pass its
+// context to the services and to outgoing Bot API calls.
+func traceUpdate(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		name, attrs := describeUpdate(update)
+		ctx, span := tracing.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		next(ctx, b, update)
+	}
+}
+
+// describeUpdate names the span after the command or callback action, never
+// after free text, which may contain personal data.
+func describeUpdate(update *models.Update) (string, []attribute.KeyValue) {
+	attrs := []attribute.KeyValue{attribute.Int64("telegram.update_id", update.ID)}
+
+	switch {
+	case update.Message != nil:
+		msg := update.Message
+		attrs = append(attrs, attribute.Int64("telegram.chat_id", msg.Chat.ID))
+		if msg.From != nil {
+			attrs = append(attrs, attribute.Int64("telegram.user_id", msg.From.ID))
+		}
+		if msg.Document != nil {
+			return "telegram document", attrs
+		}
+		first, _, _ := strings.Cut(strings.TrimSpace(msg.Text), " ")
+		if command, ok := strings.CutPrefix(first, "/"); ok && command != "" {
+			command, _, _ = strings.Cut(command, "@")
+			return "telegram /" + command, attrs
+		}
+		return "telegram message", attrs
+
+	case update.CallbackQuery != nil:
+		query := update.CallbackQuery
+		action, _, _ := strings.Cut(query.Data, ":")
+		attrs = append(attrs,
+			attribute.Int64("telegram.user_id", query.From.ID),
+			attribute.String("telegram.callback_data", query.Data),
+		)
+		return "telegram callback " + action, attrs
+	}
+
+	return "telegram update", attrs
+}
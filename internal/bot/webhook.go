@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+	"telegram-reminder-bot/internal/service"
+	"telegram-reminder-bot/internal/webhook"
+)
+
+const webhookLogSize = 10
+
+// HandleWebhook manages webhooks: "/webhook" lists them, "/webhook add <url>"
+// registers one and "/webhook log" shows recent deliveries.
+func (h *Handler) HandleWebhook(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil {
+		return
+	}
+
+	args, ok := commandArgs(update.Message.Text, "/webhook")
+	if !ok {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	user, err := h.userService.GetOrCreate(ctx, update.Message.From.ID, update.Message.From.Username)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	subcommand, rest, _ := strings.Cut(args, " ")
+	switch subcommand {
+	case "":
+		text, markup, err := h.renderWebhooks(ctx, user)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Msg("failed to list webhooks")
+			return
+		}
+		params := &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML}
+		if markup != nil {
+			params.ReplyMarkup = markup
+		}
+		b.SendMessage(ctx, params)
+
+	case "add":
+		h.addWebhook(ctx, b, chatID, user, strings.TrimSpace(rest))
+
+	case "log":
+		h.sendWebhookLog(ctx, b, chatID, user)
+
+	default:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   userLang(user).T("webhook.usage"),
+		})
+	}
+}
+
+func (h *Handler) addWebhook(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User, rawURL string) {
+	l := userLang(user)
+	hook, err := h.webhookService.Create(ctx, user, rawURL)
+	if err != nil {
+		text := l.T("webhook.add_failed")
+		switch {
+		case errors.Is(err, service.ErrInvalidWebhookURL):
+			text = l.T("webhook.invalid_url")
+		case errors.Is(err, service.ErrTooManyWebhooks):
+			text = l.T("webhook.too_many")
+		default:
+			log.Error().Ctx(ctx).Err(err).Msg("failed to create webhook")
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		ParseMode: models.ParseModeHTML,
+		Text: l.T("webhook.added",
+			render.Escape(hook.URL), render.Escape(hook.Secret), webhook.HeaderSignature, webhook.HeaderTimestamp),
+	})
+}
+
+func (h *Handler) sendWebhookLog(ctx context.Context, b *bot.Bot, chatID int64, user *domain.User) {
+	deliveries, err := h.webhookService.Deliveries(ctx, user, webhookLogSize)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list webhook deliveries")
+		return
+	}
+
+	l := userLang(user)
+	var sb strings.Builder
+	sb.WriteString(l.T("webhook.log_header"))
+	if len(deliveries) == 0 {
+		sb.WriteString(l.T("webhook.log_empty"))
+	}
+	for _, d := range deliveries {
+		icon := "✅"
+		result := strconv.Itoa(d.StatusCode)
+		if !d.Success {
+			icon = "❌"
+			if d.StatusCode == 0 {
+				result = render.Truncate(d.Error, 60)
+			}
+		}
+		sb.WriteString(l.T("webhook.log_entry",
+			icon,
+			d.CreatedAt.In(user.Location()).Format("02.01 15:04"),
+			webhook.EventName(d.EventType),
+			d.TaskID,
+			render.Escape(result),
+			d.Attempt,
+		))
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      sb.String(),
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func (h *Handler) renderWebhooks(ctx context.Context, user *domain.User) (string, *models.InlineKeyboardMarkup, error) {
+	hooks, err := h.webhookService.List(ctx, user)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l := userLang(user)
+	var sb strings.Builder
+	sb.WriteString(l.T("webhook.list_header"))
+	if len(hooks) == 0 {
+		sb.WriteString(l.T("webhook.list_empty"))
+	}
+	for i, hook := range hooks {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, render.Escape(hook.URL))
+	}
+	sb.WriteString(l.T("webhook.list_footer"))
+
+	return sb.String(), webhooksKeyboard(hooks), nil
+}
+
+func (h *Handler) handleWebhookDeleteCallback(ctx context.Context, b *bot.Bot, chatID int64, messageID int, userID int64, value string) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := h.userService.GetOrCreate(ctx, userID, "")
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get user")
+		return
+	}
+
+	if _, err := h.webhookService.Delete(ctx, user, id); err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to delete webhook")
+		return
+	}
+
+	text, markup, err := h.renderWebhooks(ctx, user)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list webhooks")
+		return
+	}
+	params := &bot.EditMessageTextParams{ChatID: chatID, MessageID: messageID, Text: text, ParseMode: models.ParseModeHTML}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	b.EditMessageText(ctx, params)
+}
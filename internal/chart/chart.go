@@ -0,0 +1,238 @@
+// Package chart renders simple bar and line charts as PNG images using only
+// the standard library and golang.org/x/image. Labels are drawn with a
+// built-in ASCII bitmap font, so titles are expected to be sent alongside the
+// image (e.g. as a photo caption) rather than drawn on it.
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type Kind int
+
+const (
+	KindBar Kind = iota
+	KindLine
+)
+
+type Chart struct {
+	Kind   Kind
+	Labels []string
+	Values []float64
+	Width  int
+	Height int
+}
+
+const (
+	defaultWidth  = 800
+	defaultHeight = 400
+
+	marginLeft   = 48
+	marginRight  = 16
+	marginTop    = 16
+	marginBottom = 32
+)
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorAxis       = color.RGBA{0x60, 0x60, 0x60, 0xff}
+	colorGrid       = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	colorText       = color.RGBA{0x30, 0x30, 0x30, 0xff}
+	colorSeries     = color.RGBA{0x2a, 0x7a, 0xe2, 0xff}
+)
+
+// Render draws c and encodes it as PNG into w.
+func Render(w io.Writer, c Chart) error {
+	img, err := Draw(c)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Draw draws c into a new image.
+func Draw(c Chart) (*image.RGBA, error) {
+	if len(c.Labels) != len(c.Values) {
+		return nil, fmt.Errorf("chart: %d labels for %d values", len(c.Labels), len(c.Values))
+	}
+	if len(c.Values) == 0 {
+		return nil, fmt.Errorf("chart: no values")
+	}
+
+	width, height := c.Width, c.Height
+	if width == 0 {
+		width = defaultWidth
+	}
+	if height == 0 {
+		height = defaultHeight
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colorBackground}, image.Point{}, draw.Src)
+
+	plot := image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom)
+	maxValue, step := yScale(c.Values)
+
+	// Horizontal grid lines with value labels.
+	for v := 0.0; v <= maxValue+step/2; v += step {
+		y := plot.Max.Y - int(math.Round(v/maxValue*float64(plot.Dy())))
+		hline(img, plot.Min.X, plot.Max.X, y, colorGrid)
+		label := formatValue(v)
+		drawText(img, plot.Min.X-6-textWidth(label), y+4, label)
+	}
+
+	vline(img, plot.Min.X, plot.Min.Y, plot.Max.Y, colorAxis)
+	hline(img, plot.Min.X, plot.Max.X, plot.Max.Y, colorAxis)
+
+	n := len(c.Values)
+	slot := float64(plot.Dx()) / float64(n)
+	yOf := func(v float64) int {
+		return plot.Max.Y - int(math.Round(v/maxValue*float64(plot.Dy())))
+	}
+	xOf := func(i int) int {
+		return plot.Min.X + int(math.Round(slot*(float64(i)+0.5)))
+	}
+
+	switch c.Kind {
+	case KindBar:
+		barWidth := max(int(slot*0.7), 1)
+		for i, v := range c.Values {
+			x := xOf(i) - barWidth/2
+			fillRect(img, image.Rect(x, yOf(v), x+barWidth, plot.Max.Y), colorSeries)
+		}
+	case KindLine:
+		for i := 1; i < n; i++ {
+			thickLine(img, xOf(i-1), yOf(c.Values[i-1]), xOf(i), yOf(c.Values[i]), colorSeries)
+		}
+		for i, v := range c.Values {
+			fillRect(img, image.Rect(xOf(i)-3, yOf(v)-3, xOf(i)+4, yOf(v)+4), colorSeries)
+		}
+	default:
+		return nil, fmt.Errorf("chart: unknown kind %d", c.Kind)
+	}
+
+	// Skip x labels that would overlap their neighbours.
+	every := 1
+	for _, label := range c.Labels {
+		if need := int(math.Ceil(float64(textWidth(label)+6) / slot)); need > every {
+			every = need
+		}
+	}
+	for i, label := range c.Labels {
+		if i%every != 0 {
+			continue
+		}
+		drawText(img, xOf(i)-textWidth(label)/2, plot.Max.Y+18, label)
+	}
+
+	return img, nil
+}
+
+// yScale returns the top of the y axis and the grid step, rounded to "nice"
+// numbers so that labels are integers for count data.
+func yScale(values []float64) (top, step float64) {
+	maxValue := 0.0
+	for _, v := range values {
+		maxValue = math.Max(maxValue, v)
+	}
+	if maxValue <= 0 {
+		return 1, 1
+	}
+
+	raw := maxValue / 4
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step = magnitude
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*magnitude >= raw {
+			step = m * magnitude
+			break
+		}
+	}
+	step = math.Max(step, 1)
+
+	return math.Ceil(maxValue/step) * step, step
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+func textWidth(s string) int {
+	return font.MeasureString(basicfont.Face7x13, s).Round()
+}
+
+func drawText(img *image.RGBA, x, y int, s string) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{colorText},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+func hline(img *image.RGBA, x1, x2, y int, c color.Color) {
+	for x := x1; x <= x2; x++ {
+		img.Set(x, y, c)
+	}
+}
+
+func vline(img *image.RGBA, x, y1, y2 int, c color.Color) {
+	for y := y1; y <= y2; y++ {
+		img.Set(x, y, c)
+	}
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// thickLine draws a 3px wide line using Bresenham's algorithm.
+func thickLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+
+	for {
+		fillRect(img, image.Rect(x0-1, y0-1, x0+2, y0+2), c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,158 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRender_Golden(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart Chart
+	}{
+		{
+			name: "bar",
+			chart: Chart{
+				Kind:   KindBar,
+				Labels: []string{"06.01", "07.01", "08.01", "09.01", "10.01", "11.01", "12.01"},
+				Values: []float64{2, 0, 5, 3, 7, 1, 4},
+			},
+		},
+		{
+			name: "line",
+			chart: Chart{
+				Kind:   KindLine,
+				Labels: []string{"06.01", "07.01", "08.01", "09.01", "10.01", "11.01", "12.01"},
+				Values: []float64{12, 11, 11, 9, 10, 6, 4},
+			},
+		},
+		{
+			name: "hours",
+			chart: Chart{
+				Kind: KindBar,
+				Labels: []string{
+					"00", "01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11",
+					"12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23",
+				},
+				Values: []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 14, 8, 22, 5, 18, 9, 11, 3, 7, 0, 0, 0, 0, 0, 0},
+				Width:  600,
+				Height: 300,
+			},
+		},
+		{
+			name: "all_zero",
+			chart: Chart{
+				Kind:   KindBar,
+				Labels: []string{"a", "b", "c"},
+				Values: []float64{0, 0, 0},
+				Width:  300,
+				Height: 200,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.chart); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".png")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want := readPNG(t, golden)
+			got, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !samePixels(got, want) {
+				t.Errorf("rendered image differs from %s; run go test -update to regenerate", golden)
+			}
+		})
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart Chart
+	}{
+		{"no values", Chart{}},
+		{"label mismatch", Chart{Labels: []string{"a"}, Values: []float64{1, 2}}},
+		{"unknown kind", Chart{Kind: Kind(42), Labels: []string{"a"}, Values: []float64{1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Render(&bytes.Buffer{}, tt.chart); err == nil {
+				t.Error("Render() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestYScale(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		wantTop  float64
+		wantStep float64
+	}{
+		{[]float64{0, 0}, 1, 1},
+		{[]float64{3}, 3, 1},
+		{[]float64{7}, 8, 2},
+		{[]float64{22}, 30, 10},
+		{[]float64{130}, 150, 50},
+	}
+
+	for _, tt := range tests {
+		top, step := yScale(tt.values)
+		if top != tt.wantTop || step != tt.wantStep {
+			t.Errorf("yScale(%v) = %v, %v, want %v, %v", tt.values, top, step, tt.wantTop, tt.wantStep)
+		}
+	}
+}
+
+func readPNG(t *testing.T, path string) image.Image {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("missing golden file: %v (run go test -update)", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func samePixels(a, b image.Image) bool {
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+	for y := a.Bounds().Min.Y; y < a.Bounds().Max.Y; y++ {
+		for x := a.Bounds().Min.X; x < a.Bounds().Max.X; x++ {
+			r1, g1, b1, a1 := a.At(x, y).RGBA()
+			r2, g2, b2, a2 := b.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				return false
+			}
+		}
+	}
+	return true
+}
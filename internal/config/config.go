@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v10"
 )
 
@@ -8,6 +10,43 @@ type Config struct {
 	TelegramBotToken string `env:"TELEGRAM_BOT_TOKEN,required"`
 	DatabaseURL      string `env:"DATABASE_URL,required"`
 	LogLevel         string `env:"LOG_LEVEL" envDefault:"info"`
+
+	// Completed tasks older than this are purged; 0 keeps them forever.
+	ArchiveRetentionDays int `env:"ARCHIVE_RETENTION_DAYS" envDefault:"90"`
+	// How long "Отменить" can restore a completed or deleted task.
+	UndoWindow time.Duration `env:"UNDO_WINDOW" envDefault:"5m"`
+
+	// HTTP server for the calendar feed; disabled when empty.
+	HTTPAddr string `env:"HTTP_ADDR"`
+	// External base URL of the HTTP server, used in links sent to users.
+	PublicURL string `env:"PUBLIC_URL"`
+
+	// Telegram IDs allowed to use admin commands.
+	AdminIDs []int64 `env:"ADMIN_IDS" envSeparator:","`
+	// Who may register: "open", "allowlist" (ALLOWED_IDS only) or "invite"
+	// (single-use links from /invite).
+	AccessMode string  `env:"ACCESS_MODE" envDefault:"open"`
+	AllowedIDs []int64 `env:"ALLOWED_IDS" envSeparator:","`
+
+	// How the scheduler decides on reminders: "adaptive" adjusts the number
+	// of reminders a day to how users respond, "fixed" always sends as many
+	// as the task's importance.
+	ReminderPolicy string `env:"REMINDER_POLICY" envDefault:"fixed"`
+	// Stages of "days:frequency:min_per_day[:tone]" that raise reminders as
+	// deadlines approach, e.g. "7:daily:1,2:daily:3:firm,0:daily:5:urgent";
+	// "default" uses that curve, empty or "off" disables escalation.
+	EscalationCurve string `env:"ESCALATION_CURVE"`
+
+	// Allow webhooks to private and loopback addresses, e.g. for local testing.
+	WebhookAllowPrivate bool `env:"WEBHOOK_ALLOW_PRIVATE" envDefault:"false"`
+
+	// Trace exporter: "otlp", "stdout" or empty to disable tracing.
+	TracingExporter string `env:"TRACING_EXPORTER"`
+	// OTLP/HTTP endpoint URL, e.g. http://localhost:4318/v1/traces. When
+	// empty the standard OTEL_EXPORTER_OTLP_* variables apply.
+	TracingEndpoint string `env:"TRACING_ENDPOINT"`
+	// Fraction of traces to record, from 0 to 1.
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
 }
 
 func Load() (*Config, error) {
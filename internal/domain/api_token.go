@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// APIToken grants access to the HTTP API on behalf of a user. Only a hash of
+// the token is stored; the token itself is shown to the user once.
+type APIToken struct {
+	ID         int64
+	UserID     int64
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
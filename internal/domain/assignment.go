@@ -0,0 +1,12 @@
+package domain
+
+// AssignmentStatus is the assignee's answer to a task assigned to them.
+type AssignmentStatus string
+
+const (
+	AssignmentNone     AssignmentStatus = ""
+	AssignmentPending  AssignmentStatus = "pending"
+	AssignmentAccepted AssignmentStatus = "accepted"
+	// AssignmentDeclined tasks are returned to the user who assigned them.
+	AssignmentDeclined AssignmentStatus = "declined"
+)
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxAttachmentsPerTask limits how many attachments one task can have.
+const MaxAttachmentsPerTask = 10
+
+var ErrTooManyAttachments = errors.New("too many attachments")
+
+// AttachmentKind is what an attachment is and so how it is sent back.
+type AttachmentKind string
+
+const (
+	AttachmentPhoto    AttachmentKind = "photo"
+	AttachmentDocument AttachmentKind = "document"
+	AttachmentVoice    AttachmentKind = "voice"
+	AttachmentVideo    AttachmentKind = "video"
+	AttachmentAudio    AttachmentKind = "audio"
+	// AttachmentMessage is a forwarded message, copied back from the chat
+	// with the bot where it was forwarded.
+	AttachmentMessage AttachmentKind = "message"
+	AttachmentLink    AttachmentKind = "link"
+)
+
+// Attachment is a file, message or link attached to a task. Files are kept
+// in Telegram and referred to by FileID; forwarded messages by ChatID and
+// MessageID, the Telegram IDs of the chat with the bot and of the message.
+type Attachment struct {
+	ID        int64
+	TaskID    int64
+	Kind      AttachmentKind
+	FileID    string
+	FileName  string
+	Caption   string
+	URL       string
+	ChatID    int64
+	MessageID int
+	CreatedAt time.Time
+}
+
+// Label is a short name for the attachment: its file name, caption or URL,
+// or empty when it has none of them.
+func (a *Attachment) Label() string {
+	for _, s := range []string{a.FileName, a.Caption, a.URL} {
+		if s = strings.Join(strings.Fields(s), " "); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+var linkRe = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// ExtractLinks returns the http and https URLs in text without trailing
+// punctuation, each once.
+func ExtractLinks(text string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, link := range linkRe.FindAllString(text, -1) {
+		link = strings.TrimRight(link, ".,;:!?)»")
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no links here", nil},
+		{"see https://example.com/doc?id=1.", []string{"https://example.com/doc?id=1"}},
+		{"(http://a.example) and http://b.example/x, http://a.example",
+			[]string{"http://a.example", "http://b.example/x"}},
+		{"ftp://example.com is not a link", nil},
+		{"«https://example.com/путь»", []string{"https://example.com/путь"}},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractLinks(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractLinks(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAttachment_Label(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment Attachment
+		want       string
+	}{
+		{"file name first", Attachment{FileName: "report.pdf", Caption: "Q3"}, "report.pdf"},
+		{"caption", Attachment{Caption: "  Q3\nfigures "}, "Q3 figures"},
+		{"url", Attachment{Kind: AttachmentLink, URL: "https://example.com"}, "https://example.com"},
+		{"nothing", Attachment{Kind: AttachmentVoice}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attachment.Label(); got != tt.want {
+				t.Errorf("Label() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Chat is a Telegram group the bot was added to. Tasks created there belong
+// to the chat rather than to the member who created them: reminders are
+// posted to the group and any member can complete them.
+type Chat struct {
+	ID              int64
+	TelegramID      int64
+	Title           string
+	Timezone        string
+	WorkHoursPerDay int
+	WorkStartHour   int
+	WorkEndHour     int
+	Language        string
+	// LeftAt is set while the bot is not a member of the chat.
+	LeftAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewChat creates a group chat that takes its working hours, timezone and
+// language from the member who started using the bot there.
+func NewChat(telegramID int64, title string, creator *User) *Chat {
+	return &Chat{
+		TelegramID:      telegramID,
+		Title:           title,
+		Timezone:        creator.Timezone,
+		WorkHoursPerDay: creator.WorkHoursPerDay,
+		WorkStartHour:   creator.WorkStartHour,
+		WorkEndHour:     creator.WorkEndHour,
+		Language:        creator.Language,
+	}
+}
+
+func (c *Chat) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
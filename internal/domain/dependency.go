@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrDependencyCycle is returned when a dependency would make tasks
+	// wait for each other.
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+	// ErrInvalidDependency is returned for a task waiting for itself, for a
+	// completed task or for a task of another user or group.
+	ErrInvalidDependency = errors.New("invalid dependency")
+)
+
+// TaskDependency says that TaskID is blocked by BlockerID: it gets no
+// reminders until BlockerID is completed.
+type TaskDependency struct {
+	TaskID    int64
+	BlockerID int64
+	CreatedAt time.Time
+}
+
+// Dependencies is the dependency graph of the tasks of one user or group.
+// Dependencies on completed and deleted tasks are kept in the graph: they no
+// longer block anything, but would again if the task were reopened or its
+// deletion undone.
+type Dependencies struct {
+	blockers map[int64][]int64
+	active   []*Task
+	byID     map[int64]*Task
+}
+
+// NewDependencies builds the graph from the owner's dependencies and active
+// tasks. Tasks keeps the order of active.
+func NewDependencies(deps []*TaskDependency, active []*Task) *Dependencies {
+	d := &Dependencies{
+		blockers: make(map[int64][]int64),
+		active:   active,
+		byID:     make(map[int64]*Task, len(active)),
+	}
+	for _, dep := range deps {
+		d.blockers[dep.TaskID] = append(d.blockers[dep.TaskID], dep.BlockerID)
+	}
+	for _, task := range active {
+		d.byID[task.ID] = task
+	}
+	return d
+}
+
+// Tasks returns the active tasks of the owner.
+func (d *Dependencies) Tasks() []*Task {
+	return d.active
+}
+
+// Has reports whether taskID is blocked by blockerID directly.
+func (d *Dependencies) Has(taskID, blockerID int64) bool {
+	for _, id := range d.blockers[taskID] {
+		if id == blockerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Blockers returns the active tasks taskID waits for directly.
+func (d *Dependencies) Blockers(taskID int64) []*Task {
+	var tasks []*Task
+	for _, id := range d.blockers[taskID] {
+		if task, ok := d.byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// Blocked reports whether taskID waits for an active task.
+func (d *Dependencies) Blocked(taskID int64) bool {
+	return len(d.Blockers(taskID)) > 0
+}
+
+// Chains returns what taskID waits for as chains of active tasks: each
+// starts with a task it waits for directly, followed by what that task waits
+// for in turn, one chain per path.
+func (d *Dependencies) Chains(taskID int64) [][]*Task {
+	var chains [][]*Task
+	var walk func(id int64, chain []*Task, seen map[int64]bool)
+	walk = func(id int64, chain []*Task, seen map[int64]bool) {
+		var next []*Task
+		for _, blocker := range d.Blockers(id) {
+			if !seen[blocker.ID] {
+				next = append(next, blocker)
+			}
+		}
+		if len(next) == 0 {
+			if len(chain) > 0 {
+				chains = append(chains, chain)
+			}
+			return
+		}
+		for _, blocker := range next {
+			seen[blocker.ID] = true
+			walk(blocker.ID, append(chain[:len(chain):len(chain)], blocker), seen)
+			delete(seen, blocker.ID)
+		}
+	}
+	walk(taskID, nil, map[int64]bool{taskID: true})
+	return chains
+}
+
+// WouldCycle reports whether making taskID wait for blockerID would close a
+// cycle, that is whether blockerID already waits for taskID, directly or
+// through other tasks.
+func (d *Dependencies) WouldCycle(taskID, blockerID int64) bool {
+	if taskID == blockerID {
+		return true
+	}
+
+	seen := map[int64]bool{blockerID: true}
+	queue := []int64{blockerID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range d.blockers[id] {
+			if next == taskID {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sampleDependencies has 1 waiting for 2 and 3, 2 waiting for 4, 3 waiting
+// for the completed 5, which waits for 4, and 6 on its own.
+func sampleDependencies() *Dependencies {
+	var active []*Task
+	for _, id := range []int64{1, 2, 3, 4, 6} {
+		active = append(active, &Task{ID: id})
+	}
+	deps := []*TaskDependency{
+		{TaskID: 1, BlockerID: 2},
+		{TaskID: 1, BlockerID: 3},
+		{TaskID: 2, BlockerID: 4},
+		{TaskID: 3, BlockerID: 5},
+		{TaskID: 5, BlockerID: 4},
+	}
+	return NewDependencies(deps, active)
+}
+
+func taskIDs(tasks []*Task) []int64 {
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestDependencies_Blocked(t *testing.T) {
+	d := sampleDependencies()
+
+	tests := []struct {
+		id   int64
+		want bool
+	}{
+		{1, true},
+		{2, true},
+		{3, false}, // waits only for a completed task
+		{4, false},
+		{6, false},
+	}
+
+	for _, tt := range tests {
+		if got := d.Blocked(tt.id); got != tt.want {
+			t.Errorf("Blocked(%d) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestDependencies_Chains(t *testing.T) {
+	d := sampleDependencies()
+
+	var got [][]int64
+	for _, chain := range d.Chains(1) {
+		got = append(got, taskIDs(chain))
+	}
+	want := [][]int64{{2, 4}, {3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chains(1) = %v, want %v", got, want)
+	}
+
+	if chains := d.Chains(4); chains != nil {
+		t.Errorf("Chains(4) = %v, want none", chains)
+	}
+}
+
+func TestDependencies_WouldCycle(t *testing.T) {
+	d := sampleDependencies()
+
+	tests := []struct {
+		name          string
+		task, blocker int64
+		want          bool
+	}{
+		{"itself", 6, 6, true},
+		{"direct", 2, 1, true},
+		{"transitive", 4, 1, true},
+		{"through a completed task", 4, 3, true},
+		{"unrelated", 6, 1, false},
+		{"same direction", 1, 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.WouldCycle(tt.task, tt.blocker); got != tt.want {
+				t.Errorf("WouldCycle(%d, %d) = %v, want %v", tt.task, tt.blocker, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+type TaskEventType string
+
+const (
+	TaskEventCreated      TaskEventType = "created"
+	TaskEventReminderSent TaskEventType = "reminder_sent"
+	TaskEventCompleted    TaskEventType = "completed"
+	TaskEventReopened     TaskEventType = "reopened"
+	TaskEventDeleted      TaskEventType = "deleted"
+	TaskEventRestored     TaskEventType = "restored"
+	TaskEventUpdated      TaskEventType = "updated"
+	TaskEventOverdue      TaskEventType = "overdue"
+	TaskEventPaused       TaskEventType = "paused"
+	TaskEventResumed      TaskEventType = "resumed"
+	TaskEventUnblocked    TaskEventType = "unblocked"
+)
+
+// TaskEvent is an entry in a user's task history. Importance and Deadline are
+// copied from the task so that history survives the task being purged.
+// ChatID is set for the events of shared group tasks.
+type TaskEvent struct {
+	ID         int64
+	TaskID     int64
+	UserID     int64
+	ChatID     *int64
+	Type       TaskEventType
+	Importance int
+	Deadline   time.Time
+	CreatedAt  time.Time
+}
+
+func NewTaskEvent(task *Task, eventType TaskEventType) *TaskEvent {
+	return &TaskEvent{
+		TaskID:     task.ID,
+		UserID:     task.UserID,
+		ChatID:     task.ChatID,
+		Type:       eventType,
+		Importance: task.Importance,
+		Deadline:   task.Deadline,
+	}
+}
+
+// PersonalEvents returns the events of personal tasks, leaving out those of
+// shared group tasks: they are recorded for the member who created the task
+// but belong to the group.
+func PersonalEvents(events []*TaskEvent) []*TaskEvent {
+	var personal []*TaskEvent
+	for _, e := range events {
+		if e.ChatID == nil {
+			personal = append(personal, e)
+		}
+	}
+	return personal
+}
@@ -12,19 +12,6 @@ func (f Frequency) String() string {
 	return string(f)
 }
 
-func (f Frequency) DisplayName() string {
-	switch f {
-	case FrequencyDaily:
-		return "Ежедневно"
-	case FrequencyEveryOtherDay:
-		return "Через день"
-	case FrequencyWeekly:
-		return "Раз в неделю"
-	default:
-		return string(f)
-	}
-}
-
 func ParseFrequency(s string) (Frequency, bool) {
 	switch s {
 	case string(FrequencyDaily):
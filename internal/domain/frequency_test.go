@@ -21,26 +21,6 @@ func TestFrequency_String(t *testing.T) {
 	}
 }
 
-func TestFrequency_DisplayName(t *testing.T) {
-	tests := []struct {
-		freq Frequency
-		want string
-	}{
-		{FrequencyDaily, "Ежедневно"},
-		{FrequencyEveryOtherDay, "Через день"},
-		{FrequencyWeekly, "Раз в неделю"},
-		{Frequency("unknown"), "unknown"},
-	}
-
-	for _, tt := range tests {
-		t.Run(string(tt.freq), func(t *testing.T) {
-			if got := tt.freq.DisplayName(); got != tt.want {
-				t.Errorf("DisplayName() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestParseFrequency(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Invite is a single-use code that lets a new user register while the bot
+// is in invite-only mode. CreatedBy and UsedBy are Telegram IDs.
+type Invite struct {
+	Code      string
+	CreatedBy int64
+	UsedBy    *int64
+	UsedAt    *time.Time
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
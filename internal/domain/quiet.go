@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Quiet modes decide how reminders that come due during quiet hours are
+// delivered once they are over.
+const (
+	// QuietDefer sends each reminder on its own.
+	QuietDefer = "defer"
+	// QuietSummary sends them together in one message.
+	QuietSummary = "summary"
+)
+
+var ErrInvalidQuietPeriod = errors.New("quiet period must look like \"13:00-14:00\" or \"mon-fri 13:00-14:00\"")
+
+// Weekdays is a set of days of the week, bit i standing for time.Weekday(i).
+// The empty set means every day.
+type Weekdays uint8
+
+func (w Weekdays) Has(d time.Weekday) bool {
+	return w == 0 || w&(1<<d) != 0
+}
+
+// Days lists the days in the set from Monday, or nil for every day.
+func (w Weekdays) Days() []time.Weekday {
+	var days []time.Weekday
+	for i := range 7 {
+		d := time.Weekday((i + 1) % 7)
+		if w&(1<<d) != 0 {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// QuietPeriod is a recurring time of day when a user gets no reminders,
+// such as a lunch break or a weekly meeting.
+type QuietPeriod struct {
+	ID     int64
+	UserID int64
+	// Start and End are minutes since midnight in the user's timezone. A
+	// period whose End is not after Start runs past midnight.
+	Start int
+	End   int
+	// Weekdays are the days the period starts on.
+	Weekdays  Weekdays
+	CreatedAt time.Time
+}
+
+// Times formats the period's times of day, e.g. "13:00-14:00".
+func (p *QuietPeriod) Times() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", p.Start/60, p.Start%60, p.End/60, p.End%60)
+}
+
+// Until returns when the period covering now ends; ok is false when now is
+// not within the period.
+func (p *QuietPeriod) Until(now time.Time) (until time.Time, ok bool) {
+	minute := now.Hour()*60 + now.Minute()
+	at := func(days, minutes int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, 0, minutes, 0, 0, now.Location())
+	}
+
+	if p.Start < p.End {
+		if p.Weekdays.Has(now.Weekday()) && minute >= p.Start && minute < p.End {
+			return at(0, p.End), true
+		}
+		return time.Time{}, false
+	}
+
+	switch {
+	case minute >= p.Start && p.Weekdays.Has(now.Weekday()):
+		return at(1, p.End), true
+	case minute < p.End && p.Weekdays.Has((now.Weekday()+6)%7):
+		return at(0, p.End), true
+	}
+	return time.Time{}, false
+}
+
+// maxQuietSpan bounds how far QuietUntil follows periods that run into
+// each other, so that periods covering the whole day still end somewhere.
+const maxQuietSpan = 7 * 24 * time.Hour
+
+// QuietUntil returns when the quiet hours covering now end, following
+// Do Not Disturb and periods that run into each other, but no further than
+// maxQuietSpan after now unless Do Not Disturb lasts longer; ok is false
+// when reminders may be sent at now. dnd may be nil.
+func QuietUntil(now time.Time, dnd *time.Time, periods []*QuietPeriod) (until time.Time, ok bool) {
+	limit := now.Add(maxQuietSpan)
+	if dnd != nil && dnd.After(limit) {
+		limit = *dnd
+	}
+	until = now
+	for {
+		if !until.Before(limit) {
+			return limit, true
+		}
+		next := until
+		if dnd != nil && next.Before(*dnd) {
+			next = *dnd
+		}
+		for _, p := range periods {
+			if end, ok := p.Until(next); ok && end.After(next) {
+				next = end
+			}
+		}
+		if !next.After(until) {
+			return until, until.After(now)
+		}
+		until = next
+	}
+}
+
+// weekdayNames are the day names ParseQuietPeriod accepts, in English and
+// Russian.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	"вс": time.Sunday, "пн": time.Monday, "вт": time.Tuesday, "ср": time.Wednesday,
+	"чт": time.Thursday, "пт": time.Friday, "сб": time.Saturday,
+}
+
+// ParseQuietPeriod parses "13:00-14:00", optionally preceded by days such
+// as "mon,wed", "mon-fri" or "пн-пт".
+func ParseQuietPeriod(s string) (*QuietPeriod, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	var days string
+	switch len(fields) {
+	case 1:
+	case 2:
+		days = fields[0]
+	default:
+		return nil, ErrInvalidQuietPeriod
+	}
+
+	from, to, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return nil, ErrInvalidQuietPeriod
+	}
+	start, ok := parseClock(from)
+	if !ok {
+		return nil, ErrInvalidQuietPeriod
+	}
+	end, ok := parseClock(to)
+	if !ok || end == start {
+		return nil, ErrInvalidQuietPeriod
+	}
+
+	period := &QuietPeriod{Start: start, End: end}
+	if days == "" {
+		return period, nil
+	}
+	for _, item := range strings.Split(days, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		d1, ok1 := weekdayNames[first]
+		d2, ok2 := d1, ok1
+		if isRange {
+			d2, ok2 = weekdayNames[last]
+		}
+		if !ok1 || !ok2 {
+			return nil, ErrInvalidQuietPeriod
+		}
+		for d := d1; ; d = (d + 1) % 7 {
+			period.Weekdays |= 1 << d
+			if d == d2 {
+				break
+			}
+		}
+	}
+	return period, nil
+}
+
+// parseClock parses "13:00" or "9:30" into minutes since midnight.
+func parseClock(s string) (int, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// wednesday is 2025-01-15, a Wednesday, at the given time.
+func wednesday(hour, minute int) time.Time {
+	return time.Date(2025, time.January, 15, hour, minute, 0, 0, time.UTC)
+}
+
+func weekdays(days ...time.Weekday) Weekdays {
+	var w Weekdays
+	for _, d := range days {
+		w |= 1 << d
+	}
+	return w
+}
+
+func TestParseQuietPeriod(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *QuietPeriod
+		wantErr bool
+	}{
+		{"13:00-14:00", &QuietPeriod{Start: 780, End: 840}, false},
+		{"9:30-10:00", &QuietPeriod{Start: 570, End: 600}, false},
+		{"22:00-07:00", &QuietPeriod{Start: 1320, End: 420}, false},
+		{"mon,wed 10:00-11:00", &QuietPeriod{Start: 600, End: 660, Weekdays: weekdays(time.Monday, time.Wednesday)}, false},
+		{"Mon-Fri 13:00-14:00", &QuietPeriod{Start: 780, End: 840, Weekdays: weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)}, false},
+		{"сб-вс 00:00-12:00", &QuietPeriod{Start: 0, End: 720, Weekdays: weekdays(time.Saturday, time.Sunday)}, false},
+		{"fri-mon 18:00-09:00", &QuietPeriod{Start: 1080, End: 540, Weekdays: weekdays(time.Friday, time.Saturday, time.Sunday, time.Monday)}, false},
+		{"13:00", nil, true},
+		{"13:00-13:00", nil, true},
+		{"25:00-26:00", nil, true},
+		{"lunch 13:00-14:00", nil, true},
+		{"mon 13:00-14:00 please", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseQuietPeriod(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuietPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("ParseQuietPeriod() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietPeriodTimes(t *testing.T) {
+	p := &QuietPeriod{Start: 570, End: 840}
+	if got := p.Times(); got != "09:30-14:00" {
+		t.Errorf("Times() = %q", got)
+	}
+}
+
+func TestWeekdaysDays(t *testing.T) {
+	got := weekdays(time.Sunday, time.Monday, time.Friday).Days()
+	if want := []time.Weekday{time.Monday, time.Friday, time.Sunday}; !slices.Equal(got, want) {
+		t.Errorf("Days() = %v, want %v", got, want)
+	}
+	if got := Weekdays(0).Days(); got != nil {
+		t.Errorf("Days() of every day = %v, want nil", got)
+	}
+}
+
+func TestQuietPeriodUntil(t *testing.T) {
+	lunch := &QuietPeriod{Start: 780, End: 840}
+	night := &QuietPeriod{Start: 1320, End: 420}
+	tuesdayMeeting := &QuietPeriod{Start: 600, End: 660, Weekdays: weekdays(time.Tuesday)}
+	tuesdayNight := &QuietPeriod{Start: 1320, End: 420, Weekdays: weekdays(time.Tuesday)}
+
+	tests := []struct {
+		name   string
+		period *QuietPeriod
+		now    time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"before", lunch, wednesday(12, 59), time.Time{}, false},
+		{"start", lunch, wednesday(13, 0), wednesday(14, 0), true},
+		{"end", lunch, wednesday(14, 0), time.Time{}, false},
+		{"evening before midnight", night, wednesday(23, 0), wednesday(7, 0).AddDate(0, 0, 1), true},
+		{"morning after midnight", night, wednesday(6, 0), wednesday(7, 0), true},
+		{"day", night, wednesday(12, 0), time.Time{}, false},
+		{"other weekday", tuesdayMeeting, wednesday(10, 30), time.Time{}, false},
+		{"night that started on its weekday", tuesdayNight, wednesday(6, 0), wednesday(7, 0), true},
+		{"night that did not", tuesdayNight, wednesday(23, 0), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.period.Until(tt.now)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Until() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestQuietUntil(t *testing.T) {
+	lunch := &QuietPeriod{Start: 780, End: 840}
+	afterLunch := &QuietPeriod{Start: 840, End: 870}
+	night := &QuietPeriod{Start: 1320, End: 120}
+	morning := &QuietPeriod{Start: 120, End: 480}
+	beforeNoon := &QuietPeriod{Start: 0, End: 720}
+	afterNoon := &QuietPeriod{Start: 720, End: 0}
+	dnd := wednesday(13, 30)
+	past := wednesday(11, 0)
+	longDND := wednesday(12, 0).Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		dnd     *time.Time
+		periods []*QuietPeriod
+		want    time.Time
+		wantOK  bool
+	}{
+		{"nothing", wednesday(12, 0), nil, nil, wednesday(12, 0), false},
+		{"do not disturb", wednesday(12, 0), &dnd, nil, dnd, true},
+		{"do not disturb over", wednesday(12, 0), &past, nil, wednesday(12, 0), false},
+		{"period", wednesday(13, 10), nil, []*QuietPeriod{lunch}, wednesday(14, 0), true},
+		{"do not disturb runs into a period", wednesday(12, 0), &dnd, []*QuietPeriod{lunch}, wednesday(14, 0), true},
+		{"periods run into each other", wednesday(13, 10), nil, []*QuietPeriod{afterLunch, lunch}, wednesday(14, 30), true},
+		{"adjacent periods past midnight", wednesday(23, 0), nil, []*QuietPeriod{morning, night}, wednesday(8, 0).AddDate(0, 0, 1), true},
+		{"periods cover the whole day", wednesday(12, 0), nil, []*QuietPeriod{beforeNoon, afterNoon}, wednesday(12, 0).Add(maxQuietSpan), true},
+		{"do not disturb beyond the limit", wednesday(12, 0), &longDND, []*QuietPeriod{beforeNoon, afterNoon}, longDND, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := QuietUntil(tt.now, tt.dnd, tt.periods)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("QuietUntil() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
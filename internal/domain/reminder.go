@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// ReminderOutcome is what the user did about a reminder.
+type ReminderOutcome string
+
+const (
+	// ReminderPending reminders have had no response yet.
+	ReminderPending ReminderOutcome = "pending"
+	// ReminderClicked reminders were acted on: the task was completed.
+	ReminderClicked ReminderOutcome = "clicked"
+	ReminderSnoozed ReminderOutcome = "snoozed"
+	// ReminderIgnored reminders got no response before the next reminder
+	// for the task or the end of the day.
+	ReminderIgnored ReminderOutcome = "ignored"
+)
+
+// Reminder is a reminder sent for a task. UserID is the task's owner, and
+// ChatID is set for the reminders of shared group tasks.
+type Reminder struct {
+	ID     int64
+	TaskID int64
+	UserID int64
+	ChatID *int64
+	// Number is the position of the reminder among the task's reminders
+	// that day, starting from 1.
+	Number      int
+	Outcome     ReminderOutcome
+	SentAt      time.Time
+	RespondedAt *time.Time
+}
+
+func NewReminder(task *Task) *Reminder {
+	return &Reminder{
+		TaskID:  task.ID,
+		UserID:  task.UserID,
+		ChatID:  task.ChatID,
+		Number:  task.RemindersSentToday + 1,
+		Outcome: ReminderPending,
+	}
+}
+
+// Responsiveness summarizes how a user reacted to recent reminders.
+type Responsiveness struct {
+	// FirstReminders counts answered or ignored first reminders of the day,
+	// FirstClicked those of them the user acted on.
+	FirstReminders int
+	FirstClicked   int
+	// IgnoredInARow is how many of a task's latest reminders have gone
+	// unanswered, including one that is still pending.
+	IgnoredInARow int
+}
+
+// SummarizeReminders computes the responsiveness of the owner of reminders,
+// given oldest first, with IgnoredInARow counted for the task with taskID.
+func SummarizeReminders(reminders []*Reminder, taskID int64) Responsiveness {
+	var r Responsiveness
+	for _, reminder := range reminders {
+		if reminder.Number == 1 && reminder.Outcome != ReminderPending {
+			r.FirstReminders++
+			if reminder.Outcome == ReminderClicked {
+				r.FirstClicked++
+			}
+		}
+
+		if reminder.TaskID != taskID {
+			continue
+		}
+		switch reminder.Outcome {
+		case ReminderIgnored, ReminderPending:
+			r.IgnoredInARow++
+		default:
+			r.IgnoredInARow = 0
+		}
+	}
+	return r
+}
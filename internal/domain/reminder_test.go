@@ -0,0 +1,67 @@
+package domain
+
+import "testing"
+
+func reminder(taskID int64, number int, outcome ReminderOutcome) *Reminder {
+	return &Reminder{TaskID: taskID, Number: number, Outcome: outcome}
+}
+
+func TestSummarizeReminders(t *testing.T) {
+	tests := []struct {
+		name      string
+		reminders []*Reminder
+		want      Responsiveness
+	}{
+		{
+			name: "no reminders",
+			want: Responsiveness{},
+		},
+		{
+			name: "first reminders of all tasks count",
+			reminders: []*Reminder{
+				reminder(1, 1, ReminderClicked),
+				reminder(2, 1, ReminderClicked),
+				reminder(2, 1, ReminderIgnored),
+				reminder(2, 2, ReminderClicked),
+				reminder(3, 1, ReminderSnoozed),
+			},
+			want: Responsiveness{FirstReminders: 4, FirstClicked: 2},
+		},
+		{
+			name: "pending first reminder is not counted yet",
+			reminders: []*Reminder{
+				reminder(1, 1, ReminderPending),
+			},
+			want: Responsiveness{IgnoredInARow: 1},
+		},
+		{
+			name: "ignored in a row since the last answer",
+			reminders: []*Reminder{
+				reminder(1, 1, ReminderIgnored),
+				reminder(1, 2, ReminderSnoozed),
+				reminder(1, 3, ReminderIgnored),
+				reminder(2, 1, ReminderClicked),
+				reminder(1, 1, ReminderIgnored),
+				reminder(1, 2, ReminderPending),
+			},
+			want: Responsiveness{FirstReminders: 3, FirstClicked: 1, IgnoredInARow: 3},
+		},
+		{
+			name: "other tasks do not break the streak",
+			reminders: []*Reminder{
+				reminder(1, 1, ReminderIgnored),
+				reminder(2, 1, ReminderClicked),
+				reminder(1, 2, ReminderIgnored),
+			},
+			want: Responsiveness{FirstReminders: 2, FirstClicked: 1, IgnoredInARow: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeReminders(tt.reminders, 1); got != tt.want {
+				t.Errorf("SummarizeReminders() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
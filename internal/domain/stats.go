@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+type Stats struct {
+	CompletedThisWeek  int
+	CompletedThisMonth int
+	CompletedTotal     int
+	OnTime             int
+	Late               int
+	// AvgRemindersBeforeCompletion is the mean number of reminders a task
+	// received before it was completed.
+	AvgRemindersBeforeCompletion float64
+	// CurrentStreak is the number of consecutive days, ending today or
+	// yesterday, with at least one completed task.
+	CurrentStreak int
+	BestStreak    int
+	// CompletedByImportance is indexed by importance (1-5).
+	CompletedByImportance [6]int
+}
+
+// OnTimeRate returns the share of completions made on or before the deadline.
+func (s Stats) OnTimeRate() float64 {
+	if s.OnTime+s.Late == 0 {
+		return 0
+	}
+	return float64(s.OnTime) / float64(s.OnTime+s.Late)
+}
+
+// ComputeStats derives statistics from a user's event history. now must be
+// in the user's timezone: weeks, months and streaks use local dates.
+func ComputeStats(events []*TaskEvent, now time.Time) Stats {
+	var stats Stats
+
+	completions := EffectiveCompletions(events)
+
+	reminders := make(map[int64][]time.Time)
+	for _, e := range events {
+		if e.Type == TaskEventReminderSent {
+			reminders[e.TaskID] = append(reminders[e.TaskID], e.CreatedAt)
+		}
+	}
+
+	loc := now.Location()
+	today := dateOf(now)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	days := make(map[time.Time]bool)
+	totalReminders := 0
+
+	for _, c := range completions {
+		day := dateOf(c.CreatedAt.In(loc))
+		days[day] = true
+
+		stats.CompletedTotal++
+		if !day.Before(weekStart) {
+			stats.CompletedThisWeek++
+		}
+		if !day.Before(monthStart) {
+			stats.CompletedThisMonth++
+		}
+
+		if day.After(dateOf(c.Deadline)) {
+			stats.Late++
+		} else {
+			stats.OnTime++
+		}
+
+		if c.Importance >= 1 && c.Importance <= 5 {
+			stats.CompletedByImportance[c.Importance]++
+		}
+
+		for _, sent := range reminders[c.TaskID] {
+			if sent.Before(c.CreatedAt) {
+				totalReminders++
+			}
+		}
+	}
+
+	if stats.CompletedTotal > 0 {
+		stats.AvgRemindersBeforeCompletion = float64(totalReminders) / float64(stats.CompletedTotal)
+	}
+
+	stats.CurrentStreak, stats.BestStreak = streaks(days, today)
+
+	return stats
+}
+
+// EffectiveCompletions returns completion events that were not later undone
+// by reopening the same task, in chronological order.
+func EffectiveCompletions(events []*TaskEvent) []*TaskEvent {
+	sorted := make([]*TaskEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var completions []*TaskEvent
+	last := make(map[int64]int)
+	for _, e := range sorted {
+		switch e.Type {
+		case TaskEventCompleted:
+			last[e.TaskID] = len(completions)
+			completions = append(completions, e)
+		case TaskEventReopened:
+			if i, ok := last[e.TaskID]; ok {
+				completions[i] = nil
+				delete(last, e.TaskID)
+			}
+		}
+	}
+
+	result := completions[:0]
+	for _, c := range completions {
+		if c != nil {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func streaks(days map[time.Time]bool, today time.Time) (current, best int) {
+	sorted := make([]time.Time, 0, len(days))
+	for d := range days {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	run := 0
+	for i, d := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(d) {
+			run++
+		} else {
+			run = 1
+		}
+		best = max(best, run)
+	}
+
+	start := today
+	if !days[start] {
+		start = today.AddDate(0, 0, -1)
+	}
+	for d := start; days[d]; d = d.AddDate(0, 0, -1) {
+		current++
+	}
+
+	return current, best
+}
+
+// dateOf returns the calendar date of t as midnight UTC, so that dates from
+// different locations and DATE columns compare directly.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CompletionsPerDay returns the number of completed tasks for each of the
+// last days local dates, oldest first, ending today.
+func CompletionsPerDay(events []*TaskEvent, now time.Time, days int) []int {
+	today := dateOf(now)
+	first := today.AddDate(0, 0, -(days - 1))
+
+	counts := make([]int, days)
+	for _, c := range EffectiveCompletions(events) {
+		day := dateOf(c.CreatedAt.In(now.Location()))
+		if day.Before(first) || day.After(today) {
+			continue
+		}
+		counts[int(day.Sub(first).Hours()/24)]++
+	}
+	return counts
+}
+
+// OpenTasksPerDay returns the number of open tasks at the end of each of the
+// last days local dates, oldest first. openNow is the current number of open
+// tasks; earlier values are reconstructed by replaying events backwards.
+func OpenTasksPerDay(events []*TaskEvent, openNow int, now time.Time, days int) []int {
+	sorted := make([]*TaskEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	// Whether a deletion or restore changes the open count depends on
+	// whether the task was completed at that moment.
+	deltas := make([]int, len(sorted))
+	completed := make(map[int64]bool)
+	for i, e := range sorted {
+		switch e.Type {
+		case TaskEventCreated:
+			deltas[i] = 1
+		case TaskEventCompleted:
+			deltas[i] = -1
+			completed[e.TaskID] = true
+		case TaskEventReopened:
+			deltas[i] = 1
+			completed[e.TaskID] = false
+		case TaskEventDeleted:
+			if !completed[e.TaskID] {
+				deltas[i] = -1
+			}
+		case TaskEventRestored:
+			if !completed[e.TaskID] {
+				deltas[i] = 1
+			}
+		}
+	}
+
+	today := dateOf(now)
+	counts := make([]int, days)
+	open := openNow
+	j := len(sorted) - 1
+	for d := days - 1; d >= 0; d-- {
+		counts[d] = open
+		// Undo the events of this day to get the count at the end of the previous one.
+		dayStart := today.AddDate(0, 0, d-(days-1))
+		for ; j >= 0 && !dateOf(sorted[j].CreatedAt.In(now.Location())).Before(dayStart); j-- {
+			open -= deltas[j]
+		}
+	}
+	return counts
+}
+
+// RemindersPerHour returns the number of reminders sent in each local hour of the day.
+func RemindersPerHour(events []*TaskEvent, loc *time.Location) [24]int {
+	var counts [24]int
+	for _, e := range events {
+		if e.Type == TaskEventReminderSent {
+			counts[e.CreatedAt.In(loc).Hour()]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func event(taskID int64, eventType TaskEventType, importance int, deadline, at time.Time) *TaskEvent {
+	return &TaskEvent{
+		TaskID:     taskID,
+		Type:       eventType,
+		Importance: importance,
+		Deadline:   deadline,
+		CreatedAt:  at,
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 1, 15, 18, 0, 0, 0, time.UTC)
+	day := func(d, hour int) time.Time { return time.Date(2025, 1, d, hour, 0, 0, 0, time.UTC) }
+	deadline := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	events := []*TaskEvent{
+		// Task 1: two reminders, completed on time today.
+		event(1, TaskEventCreated, 3, deadline(20), day(10, 9)),
+		event(1, TaskEventReminderSent, 3, deadline(20), day(14, 10)),
+		event(1, TaskEventReminderSent, 3, deadline(20), day(15, 10)),
+		event(1, TaskEventCompleted, 3, deadline(20), day(15, 11)),
+		// Reminder after completion is not counted.
+		event(1, TaskEventReminderSent, 3, deadline(20), day(15, 12)),
+
+		// Task 2: completed late yesterday.
+		event(2, TaskEventReminderSent, 5, deadline(12), day(13, 10)),
+		event(2, TaskEventCompleted, 5, deadline(12), day(14, 9)),
+
+		// Task 3: completed last Sunday, on the deadline.
+		event(3, TaskEventCompleted, 1, deadline(12), day(12, 20)),
+
+		// Task 4: completed and then reopened, does not count.
+		event(4, TaskEventCompleted, 2, deadline(30), day(15, 9)),
+		event(4, TaskEventReopened, 2, deadline(30), day(15, 9).Add(time.Minute)),
+
+		// Task 5: completed in December.
+		event(5, TaskEventCompleted, 3, deadline(1), time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)),
+	}
+
+	stats := ComputeStats(events, now)
+
+	if stats.CompletedTotal != 4 {
+		t.Errorf("CompletedTotal = %d, want 4", stats.CompletedTotal)
+	}
+	if stats.CompletedThisWeek != 2 {
+		t.Errorf("CompletedThisWeek = %d, want 2", stats.CompletedThisWeek)
+	}
+	if stats.CompletedThisMonth != 3 {
+		t.Errorf("CompletedThisMonth = %d, want 3", stats.CompletedThisMonth)
+	}
+	if stats.OnTime != 3 || stats.Late != 1 {
+		t.Errorf("OnTime/Late = %d/%d, want 3/1", stats.OnTime, stats.Late)
+	}
+	if math.Abs(stats.AvgRemindersBeforeCompletion-0.75) > 1e-9 {
+		t.Errorf("AvgRemindersBeforeCompletion = %v, want 0.75", stats.AvgRemindersBeforeCompletion)
+	}
+	if stats.CurrentStreak != 2 {
+		t.Errorf("CurrentStreak = %d, want 2", stats.CurrentStreak)
+	}
+	if stats.BestStreak != 2 {
+		t.Errorf("BestStreak = %d, want 2", stats.BestStreak)
+	}
+	want := [6]int{0, 1, 0, 2, 0, 1}
+	if stats.CompletedByImportance != want {
+		t.Errorf("CompletedByImportance = %v, want %v", stats.CompletedByImportance, want)
+	}
+}
+
+func TestComputeStats_Empty(t *testing.T) {
+	stats := ComputeStats(nil, time.Now())
+	if stats != (Stats{}) {
+		t.Errorf("ComputeStats(nil) = %+v, want zero value", stats)
+	}
+	if stats.OnTimeRate() != 0 {
+		t.Errorf("OnTimeRate() = %v, want 0", stats.OnTimeRate())
+	}
+}
+
+func TestComputeStats_UsesLocalDates(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, loc)
+	deadline := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	// 22:30 UTC on the 14th is already the 15th in UTC+3, so it is late.
+	events := []*TaskEvent{
+		event(1, TaskEventCompleted, 3, deadline, time.Date(2025, 1, 14, 22, 30, 0, 0, time.UTC)),
+	}
+
+	stats := ComputeStats(events, now)
+	if stats.Late != 1 {
+		t.Errorf("Late = %d, want 1", stats.Late)
+	}
+	if stats.CurrentStreak != 1 {
+		t.Errorf("CurrentStreak = %d, want 1", stats.CurrentStreak)
+	}
+}
+
+func TestComputeStats_Streaks(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time { return time.Date(2025, 1, d, 10, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name        string
+		days        []int
+		wantCurrent int
+		wantBest    int
+	}{
+		{"streak ending today", []int{13, 14, 15}, 3, 3},
+		{"streak ending yesterday still counts", []int{12, 13, 14}, 3, 3},
+		{"broken streak", []int{10, 11, 12, 13}, 0, 4},
+		{"best streak in the past", []int{1, 2, 3, 4, 5, 14, 15}, 2, 5},
+		{"several completions per day", []int{15, 15, 15}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []*TaskEvent
+			for i, d := range tt.days {
+				events = append(events, event(int64(i), TaskEventCompleted, 3, deadline, at(d)))
+			}
+
+			stats := ComputeStats(events, now)
+			if stats.CurrentStreak != tt.wantCurrent {
+				t.Errorf("CurrentStreak = %d, want %d", stats.CurrentStreak, tt.wantCurrent)
+			}
+			if stats.BestStreak != tt.wantBest {
+				t.Errorf("BestStreak = %d, want %d", stats.BestStreak, tt.wantBest)
+			}
+		})
+	}
+}
+
+func TestCompletionsPerDay(t *testing.T) {
+	now := time.Date(2025, 1, 15, 18, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d, hour int) time.Time { return time.Date(2025, 1, d, hour, 0, 0, 0, time.UTC) }
+
+	events := []*TaskEvent{
+		event(1, TaskEventCompleted, 3, deadline, at(15, 9)),
+		event(2, TaskEventCompleted, 3, deadline, at(15, 10)),
+		event(3, TaskEventCompleted, 3, deadline, at(13, 10)),
+		event(4, TaskEventCompleted, 3, deadline, at(1, 10)), // outside the window
+		event(5, TaskEventCompleted, 3, deadline, at(14, 10)),
+		event(5, TaskEventReopened, 3, deadline, at(14, 11)),
+	}
+
+	got := CompletionsPerDay(events, now, 4)
+	want := []int{0, 1, 0, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompletionsPerDay() = %v, want %v", got, want)
+	}
+}
+
+func TestOpenTasksPerDay(t *testing.T) {
+	now := time.Date(2025, 1, 15, 18, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d, hour int) time.Time { return time.Date(2025, 1, d, hour, 0, 0, 0, time.UTC) }
+
+	events := []*TaskEvent{
+		event(1, TaskEventCreated, 3, deadline, at(12, 9)),
+		event(2, TaskEventCreated, 3, deadline, at(12, 10)),
+		event(3, TaskEventCreated, 3, deadline, at(13, 10)),
+		event(1, TaskEventCompleted, 3, deadline, at(14, 10)),
+		// Deleting a completed task does not change the open count.
+		event(1, TaskEventDeleted, 3, deadline, at(14, 11)),
+		event(2, TaskEventDeleted, 3, deadline, at(15, 10)),
+		event(2, TaskEventRestored, 3, deadline, at(15, 10).Add(time.Minute)),
+		event(3, TaskEventCompleted, 3, deadline, at(15, 12)),
+	}
+
+	// Tasks 2 and a task created before the history window are open now.
+	got := OpenTasksPerDay(events, 2, now, 5)
+	want := []int{1, 3, 4, 3, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenTasksPerDay() = %v, want %v", got, want)
+	}
+}
+
+func TestRemindersPerHour(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	deadline := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []*TaskEvent{
+		event(1, TaskEventReminderSent, 3, deadline, time.Date(2025, 1, 15, 7, 0, 0, 0, time.UTC)),
+		event(2, TaskEventReminderSent, 3, deadline, time.Date(2025, 1, 14, 7, 59, 0, 0, time.UTC)),
+		event(3, TaskEventReminderSent, 3, deadline, time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC)),
+		event(3, TaskEventCompleted, 3, deadline, time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC)),
+	}
+
+	got := RemindersPerHour(events, loc)
+	if got[10] != 2 || got[15] != 1 {
+		t.Errorf("RemindersPerHour() = %v, want 2 at 10:00 and 1 at 15:00", got)
+	}
+	total := 0
+	for _, n := range got {
+		total += n
+	}
+	if total != 3 {
+		t.Errorf("total reminders = %d, want 3", total)
+	}
+}
@@ -5,8 +5,16 @@ import (
 )
 
 type Task struct {
-	ID                 int64
-	UserID             int64
+	ID     int64
+	UserID int64
+	// ChatID is the group chat a shared task belongs to, nil for personal
+	// tasks. UserID is then the member who created it, or 0 once they have
+	// deleted their account.
+	ChatID *int64
+	// AssignedBy is the user who assigned the task to its owner, nil for
+	// tasks users created for themselves.
+	AssignedBy         *int64
+	AssignmentStatus   AssignmentStatus
 	Description        string
 	Deadline           time.Time
 	Importance         int
@@ -14,8 +22,22 @@ type Task struct {
 	IsCompleted        bool
 	LastReminderDate   *time.Time
 	RemindersSentToday int
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	CompletedAt        *time.Time
+	// CompletedBy names the group member who completed a shared task.
+	CompletedBy string
+	DeletedAt   *time.Time
+	OverdueAt   *time.Time
+	// SnoozedUntil postpones the next reminder after the user snoozed one.
+	SnoozedUntil *time.Time
+	// Deferred is set when SnoozedUntil was set by the owner's quiet hours
+	// rather than by the owner.
+	Deferred bool
+	// PausedAt is set while the task is paused and gets no reminders.
+	// PausedUntil is the date it resumes on, nil when paused indefinitely.
+	PausedAt    *time.Time
+	PausedUntil *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 func NewTask(userID int64, description string, deadline time.Time, importance int, frequency Frequency) *Task {
@@ -29,10 +51,20 @@ func NewTask(userID int64, description string, deadline time.Time, importance in
 	}
 }
 
+// IsDelegated reports whether the task was assigned to its owner by another
+// user and the owner has not declined it.
+func (t *Task) IsDelegated() bool {
+	return t.AssignedBy != nil && t.AssignmentStatus != AssignmentDeclined
+}
+
 func (t *Task) DaysUntilDeadline() int {
-	now := time.Now().Truncate(24 * time.Hour)
-	deadline := t.Deadline.Truncate(24 * time.Hour)
-	return int(deadline.Sub(now).Hours() / 24)
+	return t.DaysUntil(time.Now())
+}
+
+// DaysUntil is DaysUntilDeadline as of now, counted from the date of now in
+// its location.
+func (t *Task) DaysUntil(now time.Time) int {
+	return int(dateOf(t.Deadline).Sub(dateOf(now)).Hours() / 24)
 }
 
 func (t *Task) WorkHoursRemaining(workHoursPerDay int) int {
@@ -43,13 +75,22 @@ func (t *Task) WorkHoursRemaining(workHoursPerDay int) int {
 	return days * workHoursPerDay
 }
 
+func (t *Task) IsPaused() bool {
+	return t.PausedAt != nil
+}
+
 func (t *Task) ShouldRemindToday() bool {
-	if t.IsCompleted {
+	return t.ShouldRemindOn(time.Now())
+}
+
+// ShouldRemindOn is ShouldRemindToday for the date of now in its location.
+func (t *Task) ShouldRemindOn(now time.Time) bool {
+	if t.IsCompleted || t.IsPaused() {
 		return false
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-	deadline := t.Deadline.Truncate(24 * time.Hour)
+	today := dateOf(now)
+	deadline := dateOf(t.Deadline)
 
 	if today.After(deadline) {
 		return false
@@ -59,7 +100,7 @@ func (t *Task) ShouldRemindToday() bool {
 	case FrequencyDaily:
 		return true
 	case FrequencyEveryOtherDay:
-		daysSinceCreation := int(today.Sub(t.CreatedAt.Truncate(24*time.Hour)).Hours() / 24)
+		daysSinceCreation := int(today.Sub(dateOf(t.CreatedAt.In(now.Location()))).Hours() / 24)
 		return daysSinceCreation%2 == 0
 	case FrequencyWeekly:
 		return today.Weekday() == t.Deadline.Weekday()
@@ -68,10 +109,6 @@ func (t *Task) ShouldRemindToday() bool {
 	}
 }
 
-func (t *Task) CanSendReminder() bool {
-	return t.ShouldRemindToday() && t.RemindersSentToday < t.Importance
-}
-
 func (t *Task) ImportanceStars() string {
 	stars := ""
 	for i := 0; i < 5; i++ {
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+type TaskSort string
+
+const (
+	TaskSortDeadline   TaskSort = "deadline"
+	TaskSortImportance TaskSort = "importance"
+	TaskSortCreated    TaskSort = "created"
+)
+
+func ParseTaskSort(s string) (TaskSort, bool) {
+	switch s {
+	case string(TaskSortDeadline):
+		return TaskSortDeadline, true
+	case string(TaskSortImportance):
+		return TaskSortImportance, true
+	case string(TaskSortCreated):
+		return TaskSortCreated, true
+	default:
+		return "", false
+	}
+}
+
+type TaskFilter string
+
+const (
+	TaskFilterAll     TaskFilter = "all"
+	TaskFilterToday   TaskFilter = "today"
+	TaskFilterWeek    TaskFilter = "week"
+	TaskFilterOverdue TaskFilter = "overdue"
+)
+
+func ParseTaskFilter(s string) (TaskFilter, bool) {
+	switch s {
+	case string(TaskFilterAll):
+		return TaskFilterAll, true
+	case string(TaskFilterToday):
+		return TaskFilterToday, true
+	case string(TaskFilterWeek):
+		return TaskFilterWeek, true
+	case string(TaskFilterOverdue):
+		return TaskFilterOverdue, true
+	default:
+		return "", false
+	}
+}
+
+// PausedTasks says how a task list treats paused tasks.
+type PausedTasks int
+
+const (
+	PausedInclude PausedTasks = iota
+	PausedExclude
+	PausedOnly
+)
+
+// TaskListOptions describes one page of a user's task list.
+// Today is the current date in the user's timezone; filters are relative to it.
+type TaskListOptions struct {
+	Sort   TaskSort
+	Filter TaskFilter
+	Paused PausedTasks
+	Today  time.Time
+	Limit  int
+	Offset int
+}
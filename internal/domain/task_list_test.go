@@ -0,0 +1,56 @@
+package domain
+
+import "testing"
+
+func TestParseTaskSort(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   TaskSort
+		wantOk bool
+	}{
+		{"deadline", TaskSortDeadline, true},
+		{"importance", TaskSortImportance, true},
+		{"created", TaskSortCreated, true},
+		{"name", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseTaskSort(tt.input)
+			if ok != tt.wantOk {
+				t.Errorf("ParseTaskSort() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTaskSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTaskFilter(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   TaskFilter
+		wantOk bool
+	}{
+		{"all", TaskFilterAll, true},
+		{"today", TaskFilterToday, true},
+		{"week", TaskFilterWeek, true},
+		{"overdue", TaskFilterOverdue, true},
+		{"month", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseTaskFilter(tt.input)
+			if ok != tt.wantOk {
+				t.Errorf("ParseTaskFilter() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTaskFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
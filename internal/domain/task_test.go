@@ -102,6 +102,15 @@ func TestTask_ShouldRemindToday(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "paused task",
+			task: &Task{
+				Deadline:  tomorrow,
+				Frequency: FrequencyDaily,
+				PausedAt:  &yesterday,
+			},
+			want: false,
+		},
 		{
 			name: "deadline passed",
 			task: &Task{
@@ -141,70 +150,6 @@ func TestTask_ShouldRemindToday(t *testing.T) {
 	}
 }
 
-func TestTask_CanSendReminder(t *testing.T) {
-	tomorrow := time.Now().Truncate(24 * time.Hour).Add(24 * time.Hour)
-
-	tests := []struct {
-		name string
-		task *Task
-		want bool
-	}{
-		{
-			name: "can send - no reminders sent yet",
-			task: &Task{
-				IsCompleted:        false,
-				Deadline:           tomorrow,
-				Frequency:          FrequencyDaily,
-				Importance:         3,
-				RemindersSentToday: 0,
-			},
-			want: true,
-		},
-		{
-			name: "can send - some reminders sent",
-			task: &Task{
-				IsCompleted:        false,
-				Deadline:           tomorrow,
-				Frequency:          FrequencyDaily,
-				Importance:         3,
-				RemindersSentToday: 2,
-			},
-			want: true,
-		},
-		{
-			name: "cannot send - all reminders sent",
-			task: &Task{
-				IsCompleted:        false,
-				Deadline:           tomorrow,
-				Frequency:          FrequencyDaily,
-				Importance:         3,
-				RemindersSentToday: 3,
-			},
-			want: false,
-		},
-		{
-			name: "cannot send - more than importance",
-			task: &Task{
-				IsCompleted:        false,
-				Deadline:           tomorrow,
-				Frequency:          FrequencyDaily,
-				Importance:         2,
-				RemindersSentToday: 5,
-			},
-			want: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := tt.task.CanSendReminder()
-			if got != tt.want {
-				t.Errorf("CanSendReminder() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestTask_ImportanceStars(t *testing.T) {
 	tests := []struct {
 		importance int
@@ -228,6 +173,30 @@ func TestTask_ImportanceStars(t *testing.T) {
 	}
 }
 
+func TestTask_IsDelegated(t *testing.T) {
+	assigner := int64(7)
+	tests := []struct {
+		name       string
+		assignedBy *int64
+		status     AssignmentStatus
+		want       bool
+	}{
+		{"own task", nil, AssignmentNone, false},
+		{"pending", &assigner, AssignmentPending, true},
+		{"accepted", &assigner, AssignmentAccepted, true},
+		{"declined", &assigner, AssignmentDeclined, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{AssignedBy: tt.assignedBy, AssignmentStatus: tt.status}
+			if got := task.IsDelegated(); got != tt.want {
+				t.Errorf("IsDelegated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewTask(t *testing.T) {
 	deadline := time.Now().Add(24 * time.Hour)
 	task := NewTask(123, "Test task", deadline, 3, FrequencyDaily)
@@ -248,3 +217,48 @@ func TestNewTask(t *testing.T) {
 		t.Error("IsCompleted should be false")
 	}
 }
+
+// Deadlines are dates; the day they are counted from is the date of now
+// where the user is, also when it is already another date in UTC.
+func TestTask_DaysUntilInLocation(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	newYork := time.FixedZone("UTC-5", -5*60*60)
+	deadline := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantDays   int
+		wantRemind bool
+	}{
+		{"just after midnight east of UTC", time.Date(2025, 3, 1, 0, 30, 0, 0, tokyo), 0, true},
+		{"the evening before east of UTC", time.Date(2025, 2, 28, 23, 30, 0, 0, tokyo), 1, true},
+		{"just before midnight west of UTC", time.Date(2025, 3, 1, 23, 30, 0, 0, newYork), 0, true},
+		{"just after midnight west of UTC", time.Date(2025, 3, 2, 0, 30, 0, 0, newYork), -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Deadline: deadline, Frequency: FrequencyDaily}
+			if got := task.DaysUntil(tt.now); got != tt.wantDays {
+				t.Errorf("DaysUntil() = %v, want %v", got, tt.wantDays)
+			}
+			if got := task.ShouldRemindOn(tt.now); got != tt.wantRemind {
+				t.Errorf("ShouldRemindOn() = %v, want %v", got, tt.wantRemind)
+			}
+		})
+	}
+}
+
+func TestTask_ShouldRemindOnWeeklyInLocation(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	// A Saturday deadline; early on Saturday in Tokyo it is still Friday in UTC.
+	task := &Task{Deadline: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), Frequency: FrequencyWeekly}
+
+	if !task.ShouldRemindOn(time.Date(2025, 3, 1, 1, 0, 0, 0, tokyo)) {
+		t.Error("ShouldRemindOn() = false on the deadline's weekday, want true")
+	}
+	if task.ShouldRemindOn(time.Date(2025, 2, 28, 23, 0, 0, 0, tokyo)) {
+		t.Error("ShouldRemindOn() = true on the day before, want false")
+	}
+}
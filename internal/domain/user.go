@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type User struct {
 	ID              int64
@@ -10,8 +13,30 @@ type User struct {
 	WorkHoursPerDay int
 	WorkStartHour   int
 	WorkEndHour     int
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	CalendarToken   string
+	// Language is an i18n language code; empty until the user's Telegram
+	// client reports one.
+	Language string
+	// MessageStyle names the render style of task messages; empty means the
+	// default one.
+	MessageStyle string
+	// DNDUntil mutes reminders until the given time.
+	DNDUntil *time.Time
+	// QuietMode is how reminders due during quiet hours are delivered: one
+	// of the Quiet* modes, empty meaning QuietDefer.
+	QuietMode string
+	// BannedAt is set while an admin has blocked the user.
+	BannedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// UserCounts summarizes registered users for admins. Recent counts users
+// registered since the requested time.
+type UserCounts struct {
+	Total  int
+	Recent int
+	Banned int
 }
 
 func NewUser(telegramID int64, username string) *User {
@@ -32,3 +57,16 @@ func (u *User) Location() *time.Location {
 	}
 	return loc
 }
+
+// Mention is how the user is referred to in messages to other users:
+// "@username", or the Telegram ID when there is no username.
+func (u *User) Mention() string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	return "id " + strconv.FormatInt(u.TelegramID, 10)
+}
+
+func (u *User) IsBanned() bool {
+	return u.BannedAt != nil
+}
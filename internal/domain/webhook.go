@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Webhook is a user-registered URL that receives task events. Payloads are
+// signed with Secret.
+type Webhook struct {
+	ID        int64
+	UserID    int64
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// WebhookDelivery is one attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  int64
+	EventType  TaskEventType
+	TaskID     int64
+	Attempt    int
+	StatusCode int
+	Error      string
+	Success    bool
+	CreatedAt  time.Time
+}
@@ -0,0 +1,54 @@
+// Package eventbus passes task lifecycle events from the service layer to
+// subscribers such as webhooks, without the services knowing about them.
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+// Event is published after a change has been stored. Task is a snapshot
+// taken at that moment.
+type Event struct {
+	Type       domain.TaskEventType
+	Task       domain.Task
+	OccurredAt time.Time
+}
+
+// Handler receives events synchronously on the publisher's goroutine, so it
+// must return quickly and hand slow work off to its own goroutines.
+type Handler func(ctx context.Context, event Event)
+
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish delivers event to all subscribers. Publishing on a nil Bus does
+// nothing, so services work without one.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(ctx, event)
+	}
+}
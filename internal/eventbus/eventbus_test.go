@@ -0,0 +1,28 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+func TestPublish(t *testing.T) {
+	bus := New()
+
+	var got []string
+	bus.Subscribe(func(_ context.Context, e Event) { got = append(got, "a:"+string(e.Type)) })
+	bus.Subscribe(func(_ context.Context, e Event) { got = append(got, "b:"+string(e.Type)) })
+
+	bus.Publish(context.Background(), Event{Type: domain.TaskEventCompleted})
+
+	want := []string{"a:completed", "b:completed"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("handlers received %v, want %v", got, want)
+	}
+}
+
+func TestPublish_NilBus(t *testing.T) {
+	var bus *Bus
+	bus.Publish(context.Background(), Event{Type: domain.TaskEventCreated})
+}
@@ -0,0 +1,405 @@
+package i18n
+
+var enMessages = map[string]string{
+	// Main menu and common buttons
+	"menu.add":            "Add task",
+	"menu.list":           "My tasks",
+	"menu.settings":       "Settings",
+	"button.done":         "Done",
+	"button.snooze":       "💤 In an hour",
+	"button.snoozed":      "💤 Until %s",
+	"button.delete":       "Delete",
+	"button.back_to_list": "◀ Back to list",
+	"button.accept":       "👍 Accept",
+	"button.decline":      "👎 Decline",
+	"button.undo":         "Undo",
+	"button.cancel":       "Cancel",
+	"common.cancelled":    "Cancelled.",
+
+	// Frequencies
+	"frequency.daily":           "Daily",
+	"frequency.every_other_day": "Every other day",
+	"frequency.weekly":          "Weekly",
+
+	// Task card labels, used by the templates in internal/render
+	"task.until_deadline":  "Until deadline",
+	"task.work_hours_left": "Work hours left",
+	"task.importance":      "Importance",
+	"task.frequency":       "Frequency",
+
+	// Message styles
+	"style.detailed": "Detailed",
+	"style.compact":  "Compact",
+
+	// Access control
+	"access.banned":         "⛔ Your access to the bot has been blocked.",
+	"access.allowlist":      "The bot is available to team members only.",
+	"access.invite_only":    "The bot is available by invitation only. Ask an admin for a link.",
+	"access.invite_invalid": "This invitation is invalid, expired or already used.",
+
+	// /start
+	"start.help": `Hi! I'm a bot that reminds you about tasks.
+
+I'll help you not to forget important things. Here is what I can do:
+
+📌 Add task - create a new reminder
+↪️ Forward me a message - and it becomes a task
+📋 My tasks - see your active tasks
+⚙️ Settings - change work hours and language
+
+Use the menu buttons or commands:
+/add - add a task
+/assign - assign a task to someone else
+/list - task list
+/archive - completed tasks
+/stats - statistics
+/chart - charts
+/export_ics - export to a calendar
+/import - import tasks from a file
+/export - download all your data
+/delete_account - delete your account
+/token - API token
+/webhook - webhooks
+/dnd - do not disturb
+/quiet - quiet hours
+/settings - settings`,
+
+	// Adding tasks
+	"add.unparsed":            "Could not understand: <code>%s</code>",
+	"add.ask_description":     "Enter the task description:",
+	"add.ask_deadline":        "Enter the deadline as DD.MM.YYYY (for example, 15.01.2025):",
+	"add.ask_importance":      "Choose the task importance (it sets how many reminders you get per day):",
+	"add.ask_frequency":       "Choose how often to remind you:",
+	"add.bad_deadline":        "Invalid date. Enter it as DD.MM.YYYY:",
+	"add.quick_past_deadline": "The date %s has already passed.",
+	"add.past_deadline":       "The deadline can't be in the past. Enter another date:",
+	"add.failed":              "Failed to create the task. Please try again.",
+	"add.created":             "✅ Task created!",
+
+	// Task actions
+	"task.completed": "✅ Task completed!",
+	"task.deleted":   "🗑 Task deleted.",
+	"task.restored":  "↩️ Task restored.",
+	"undo.expired":   "⌛ It's too late to undo.",
+
+	// Settings
+	"settings.summary": `⚙️ <b>Settings</b>
+
+Work hours per day: <b>%d</b>
+Timezone: <b>%s</b>
+Language: <b>%s</b>
+Message style: <b>%s</b>
+Reminders after quiet hours: <b>%s</b>
+
+Choose what to change:`,
+	"settings.button.work_hours":  "Work hours per day",
+	"settings.button.timezone":    "Timezone",
+	"settings.button.language":    "Language / Язык",
+	"settings.button.style":       "Message style",
+	"settings.ask_work_hours":     "Choose how many hours you work per day:",
+	"settings.ask_timezone":       "Send your timezone (for example, Europe/London, America/New_York):",
+	"settings.ask_language":       "Choose a language:",
+	"settings.ask_style":          "Choose a message style: detailed shows everything about a task, compact only the essentials.",
+	"settings.work_hours_updated": "✅ Work hours updated: %s per day",
+	"settings.language_updated":   "✅ Language set to English.",
+	"settings.style_updated":      "✅ Message style: %s",
+	"language.name":               "English",
+
+	// Task list
+	"list.empty": "You have no active tasks. Add one with /add",
+	"list.empty_filter": `📋 <b>Tasks</b> · %s
+
+No tasks match this filter.`,
+	"list.title":                  "Tasks",
+	"list.summary":                "Total: %d, page %d/%d",
+	"list.sort_button.deadline":   "⏰ Deadline",
+	"list.sort_button.importance": "⚡ Importance",
+	"list.sort_button.created":    "🆕 Newest",
+	"list.sort_title.deadline":    "by deadline",
+	"list.sort_title.importance":  "by importance",
+	"list.sort_title.created":     "newest first",
+	"list.filter_button.all":      "All",
+	"list.filter_button.today":    "Today",
+	"list.filter_button.week":     "Week",
+	"list.filter_button.overdue":  "Overdue",
+	"list.filter_title.all":       "all",
+	"list.filter_title.today":     "due today",
+	"list.filter_title.week":      "due this week",
+	"list.filter_title.overdue":   "overdue",
+
+	// Group chats
+	"group.help": `Hi! I remind the group about shared tasks.
+
+/add description date - add a task for the group, for example:
+/add water the plants tomorrow !2 weekly
+/list - the group's shared tasks
+
+Reminders come to this chat, and any member can mark a task done. Only its author or a group admin can delete it.
+
+Personal tasks and settings are in a private chat with me.`,
+	"group.no_tasks": `This group has no shared tasks. Add the first one:
+/add water the plants tomorrow`,
+	"group.private_only": "This command works only in a private chat with me.",
+	"group.add_usage": `Give a description and a deadline, for example:
+/add water the plants tomorrow`,
+	"group.created": "✅ Group task created!",
+	"group.completed_by": `✅ <b>%s</b>
+done by %s`,
+	"group.delete_forbidden": "%s, only the task's author or a group admin can delete it.",
+
+	// Assignment
+	"assign.ask_assignee":    "Who should do the task? Send their @username or share a contact.",
+	"assign.not_found":       "User not found. They need to start the bot with /start first.",
+	"assign.self":            "Add your own tasks with /add.",
+	"assign.received":        "📨 %s assigned you a task",
+	"assign.sent":            "📨 Task assigned to %s. I'll let you know when it's done.",
+	"assign.accepted":        "👍 Task accepted.",
+	"assign.declined":        "👎 Task declined and returned to its author.",
+	"assign.accepted_notice": "👍 %s accepted the task “%s”",
+	"assign.declined_notice": "👎 %s declined the task “%s”. It is back with you.",
+
+	// Admin commands
+	"admin.help": "\n\nAdministration:\n/admin_stats - bot statistics\n/admin_user <id> - user by Telegram ID\n/ban <id>, /unban <id> - block or unblock a user\n/broadcast <text> - message all users\n/invite - invitation link",
+	"admin.stats": `📊 <b>Bot statistics</b>
+
+👥 Users: <b>%d</b>
+• new in 7 days: %d
+• banned: %d
+
+📋 Tasks:
+• active: %d
+• overdue: %d
+• completed: %d
+• deleted (can be undone): %d`,
+	"admin.usage":          "Usage: %s",
+	"admin.user_not_found": "User not found.",
+	"admin.status_active":  "active",
+	"admin.status_banned":  "banned since %s",
+	"admin.status_admin":   ", admin",
+	"admin.user": `👤 <b>User %d</b>
+
+Name: %s
+Registered: %s
+Timezone: %s
+Work hours: %d:00-%d:00
+Tasks: %d active, %d completed
+Status: %s`,
+	"admin.ban_admin":        "Admins can't be banned.",
+	"admin.banned":           "⛔ User %d is banned. They no longer get reminders.",
+	"admin.unbanned":         "✅ User %d is unbanned.",
+	"admin.broadcast_syntax": "/broadcast <text>",
+	"admin.broadcast_preview": `📣 Broadcast to %s:
+
+%s`,
+	"admin.button.broadcast":   "📣 Send",
+	"admin.broadcast_started":  "📣 Broadcast started, %s.",
+	"admin.broadcast_finished": "📣 Broadcast finished: %d delivered, %d failed.",
+	"admin.invite": `🎟 Single-use invitation valid until %s:
+https://t.me/%s?start=%s`,
+	"admin.invite_not_required": "\n\nThe access mode is not invite, so no invitation is needed to register.",
+
+	// Archive
+	"archive.empty":       "The archive is empty. Completed tasks will show up here.",
+	"archive.title":       "Completed tasks",
+	"archive.reopen_hint": "↩️ — move the task back to active",
+
+	// Account
+	"account.export_failed":  "Could not prepare the export. Please try again later.",
+	"account.export_caption": "📦 All your data: profile, settings, tasks and history.",
+	"account.delete_confirm": `⚠️ <b>Delete account</b>
+
+Your profile, settings, all tasks and history will be deleted permanently. This can't be undone.
+
+If you need a copy of your data, run /export first.`,
+	"account.button.delete": "🗑 Yes, delete everything",
+	"account.delete_failed": "Could not delete the account. Please try again later.",
+	"account.deleted":       "🗑 Your account and all data have been deleted.",
+	"account.come_back":     "If you want to come back, just send /start.",
+
+	// Statistics and charts
+	"stats.empty": "📊 No statistics yet — complete your first task!",
+	"stats.summary": `📊 <b>Statistics</b>
+
+✅ Completed this week: <b>%d</b>
+✅ Completed this month: <b>%d</b>
+✅ Total this year: <b>%d</b>
+
+⏰ On time: <b>%d</b>, late: <b>%d</b> (%.0f%% on time)
+🔔 Reminders before completion: <b>%.1f</b> on average
+🔥 Streak: <b>%d</b> (best: %d)`,
+	"stats.by_importance":      "\n\n⚡ By importance:\n",
+	"chart.ask":                "Which chart do you want to see?",
+	"chart.button.completions": "✅ Completed per day",
+	"chart.button.burndown":    "📉 Open tasks",
+	"chart.button.hours":       "🔔 Reminders per hour",
+	"chart.completions":        "✅ Tasks completed per day over %s",
+	"chart.burndown":           "📉 Open tasks at the end of each day over %s",
+	"chart.hours":              "🔔 Reminders per hour over %s (%s)",
+
+	// Calendar
+	"calendar.caption": "📅 Active tasks: %d. Open the file to add them to your calendar.",
+	"calendar.feed": `🔗 <b>Calendar subscription</b>
+
+Add this link to your calendar as a subscription and your tasks will stay up to date:
+<code>%s</code>
+
+Don't show this link to anyone.`,
+	"calendar.button.rotate": "🔄 New link",
+	"calendar.rotated":       "🔄 The link has been replaced; the old one no longer works.\n\n",
+
+	// Import
+	"import.help": `📥 <b>Import tasks</b>
+
+Send a file in one of these formats:
+• <b>.ics</b> — tasks (VTODO) from a calendar
+• <b>.csv</b> — the first row names the columns: description, deadline, priority, frequency
+• <b>.json</b> — a Todoist export or a Trello board
+
+Before importing I'll show which tasks will be created and which rows were skipped.`,
+	"import.too_large":       "The file is too large, the limit is 1 MB.",
+	"import.download_failed": "Could not download the file. Please try again.",
+	"import.parse_failed":    "Could not read the file: %s",
+	"import.unknown_format":  "Unknown file format. Supported are .ics, .csv and Todoist or Trello JSON exports. More: /import",
+	"import.button.confirm":  "✅ Import (%d)",
+	"import.done":            "✅ Tasks imported: <b>%d</b>",
+	"import.done_failed":     "\n⚠️ Failed to save: %d",
+	"import.done_skipped":    "\nSkipped while parsing: %d",
+	"import.done_footer":     "\n\nTask list: /list",
+	"import.preview_header":  "📥 <b>Import (%s)</b>\n\n",
+	"import.preview_empty":   "The file has no tasks that can be imported.\n",
+	"import.preview_items":   "Tasks to create: <b>%d</b>\n",
+	"import.preview_more":    "…and %d more\n",
+	"import.preview_skipped": "\nSkipped: <b>%d</b>\n",
+
+	// API tokens
+	"token.revoked": "🔒 Tokens revoked: %d",
+	"token.usage":   "Usage: /token — new token, /token revoke — revoke all.",
+	"token.issued": `🔑 <b>API token</b>
+
+<code>%s</code>
+
+The token is shown only once, save it. Pass it in the <code>Authorization: Bearer ...</code> header.
+Revoke all tokens: /token revoke`,
+	"token.api_url": "\n\nAPI address: <code>%s/api/v1</code>\nDescription: %s/api/v1/openapi.json",
+
+	// Webhooks
+	"webhook.usage":       "Usage: /webhook, /webhook add <url>, /webhook log",
+	"webhook.add_failed":  "Could not add the webhook. Please try again later.",
+	"webhook.invalid_url": "A full URL is needed, for example: /webhook add https://example.com/hooks/tasks",
+	"webhook.too_many":    "You have reached the webhook limit. Remove some in /webhook.",
+	"webhook.added": `🔗 <b>Webhook added</b>
+
+%s
+
+Secret for checking signatures:
+<code>%s</code>
+
+Every request is signed: the <code>%s</code> header holds the HMAC-SHA256 of <code>&lt;%s&gt;.&lt;body&gt;</code>.`,
+	"webhook.log_header":  "📜 <b>Recent deliveries</b>\n\n",
+	"webhook.log_empty":   "No deliveries yet.",
+	"webhook.log_entry":   "%s %s %s #%d → %s (attempt %d)\n",
+	"webhook.list_header": "🔗 <b>Webhooks</b>\n\n",
+	"webhook.list_empty":  "No webhooks.\n",
+	"webhook.list_footer": "\nEvents: task.created, task.reminder_sent, task.completed, task.deleted, task.overdue and more.\nAdd: /webhook add &lt;url&gt;\nDelivery log: /webhook log",
+
+	// Reminders and notifications
+	"reminder.title":          "Reminder",
+	"reminder.title_firm":     "Deadline approaching",
+	"reminder.title_urgent":   "Urgent",
+	"reminder.today":          "%d/%d today",
+	"reminder.work_hours":     "Work hours",
+	"assign.completed_notice": "✅ %s completed the task “%s”",
+	"assign.overdue_notice":   "⚠️ The task “%s” for %s is overdue (deadline %s)",
+
+	// Quiet hours
+	"dnd.usage": "Usage: /dnd 2h — quiet for 2 hours, /dnd 30m — for 30 minutes, /dnd tomorrow — until tomorrow, /dnd off — turn off.",
+	"dnd.status_on": `🔕 Do Not Disturb until <b>%s</b>.
+
+Change it:`,
+	"dnd.status_off":              "🔔 Do Not Disturb is off. How long should it be on for?",
+	"dnd.enabled":                 "🔕 Do Not Disturb until <b>%s</b>. Reminders due meanwhile will come afterwards.",
+	"dnd.failed":                  "Could not change Do Not Disturb. Please try again later.",
+	"dnd.disabled":                "🔔 Do Not Disturb is off.",
+	"button.dnd_tomorrow":         "Until tomorrow",
+	"button.dnd_off":              "Turn off",
+	"quiet.list_header":           "🔕 <b>Quiet hours</b>\n\n",
+	"quiet.list_empty":            "No quiet hours.\n",
+	"quiet.list_entry":            "%d. %s, %s\n",
+	"quiet.list_footer":           "\nAdd: /quiet 13:00-14:00 or /quiet mon-fri 13:00-14:00\nOne-off: /dnd 2h",
+	"quiet.added":                 "✅ Quiet hours added: %s, %s",
+	"quiet.invalid":               "Could not understand the time. For example: /quiet 13:00-14:00 or /quiet mon,wed 10:00-11:00",
+	"quiet.too_many":              "You have reached the quiet hours limit. Remove some in /quiet.",
+	"quiet.add_failed":            "Could not add the quiet hours. Please try again later.",
+	"quiet.every_day":             "every day",
+	"quiet.summary_title":         "While you were not disturbed",
+	"quiet.summary_footer":        "Mark tasks done in /list",
+	"quiet_mode.defer":            "One by one",
+	"quiet_mode.summary":          "In one summary",
+	"weekday.mon":                 "Mon",
+	"weekday.tue":                 "Tue",
+	"weekday.wed":                 "Wed",
+	"weekday.thu":                 "Thu",
+	"weekday.fri":                 "Fri",
+	"weekday.sat":                 "Sat",
+	"weekday.sun":                 "Sun",
+	"settings.button.quiet_mode":  "Reminders after quiet hours",
+	"settings.ask_quiet_mode":     "How should reminders due during quiet hours or Do Not Disturb arrive?",
+	"settings.quiet_mode_updated": "✅ Reminders after quiet hours: %s",
+
+	// Paused tasks
+	"button.pause":              "⏸ Pause",
+	"button.resume":             "▶️ Resume",
+	"button.pause_until_date":   "📅 Until a date…",
+	"button.pause_indefinitely": "Until I resume",
+	"button.back":               "◀ Back",
+	"pause.ask_date":            "Until what date should reminders be paused? For example: 20.01 or tomorrow",
+	"pause.bad_date":            "Could not understand the date. For example: 20.01, 2025-01-20 or tomorrow",
+	"pause.past_date":           "The date must be after today.",
+	"pause.paused":              "⏸ Reminders paused",
+	"pause.resumed":             "▶️ Reminders resumed",
+	"pause.resumed_auto":        "▶️ The pause is over, reminders resumed",
+	"pause.failed":              "Could not change the pause. Please try again later.",
+	"task.paused":               "Paused",
+	"task.paused_until":         "Paused until %s",
+	"list.paused_title":         "⏸ Paused: %d",
+
+	// Task dependencies
+	"button.dependencies":         "⛓ Dependencies",
+	"task.blocked_by":             "Waits for",
+	"dependency.header":           "⛓ <b>Dependencies</b> · %s\n\n",
+	"dependency.waits_for":        "Waits for:\n",
+	"dependency.none":             "Does not wait for anything.\n",
+	"dependency.hint":             "\nTap ➕ next to a task that has to be done first, or ❌ to remove a dependency. A task gets no reminders while it waits.",
+	"dependency.cycle":            "Not possible: “%s” already waits for this task.",
+	"dependency.failed":           "Could not change the dependencies. Please try again later.",
+	"dependency.unblocked_notice": "🔓 “%s” no longer waits for anything; reminders resume.",
+
+	// Task attachments
+	"button.attachments":       "📎 Attachments",
+	"button.attachments_count": "📎 Attachments (%d)",
+	"button.attach":            "➕ Attach",
+	"button.send_all":          "📤 Send all",
+	"button.attach_done":       "✅ Done",
+	"attachment.header":        "📎 <b>Attachments</b> · %s\n\n",
+	"attachment.none":          "No attachments.",
+	"attachment.entry":         "%d. %s %s\n",
+	"attachment.ask":           "Send a photo, file, voice message, video or link, or forward a message, and I'll attach it to “%s”.",
+	"attachment.added":         "📎 Attached: %s (%d of %d). Send more or tap Done.",
+	"attachment.unsupported":   "That can't be attached. Send a photo, file, voice message, video or link, or forward a message.",
+	"attachment.too_many":      "A task can have at most %d attachments.",
+	"attachment.failed":        "Could not change the attachments. Please try again later.",
+	"attachment.send_failed":   "Could not send “%s”.",
+	"attachment.kind.photo":    "Photo",
+	"attachment.kind.document": "File",
+	"attachment.kind.voice":    "Voice message",
+	"attachment.kind.video":    "Video",
+	"attachment.kind.audio":    "Audio",
+	"attachment.kind.message":  "Forwarded message",
+	"attachment.kind.link":     "Link",
+}
+
+var enPlurals = map[string]map[pluralForm]string{
+	"days":       {one: "%d day", other: "%d days"},
+	"hours":      {one: "%d hour", other: "%d hours"},
+	"recipients": {one: "%d recipient", other: "%d recipients"},
+}
@@ -0,0 +1,96 @@
+// Package i18n holds the bot's message catalog. Messages are looked up by
+// key in the user's language and formatted with fmt verbs; counted nouns go
+// through N, which picks the CLDR plural form for the number.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+)
+
+// Default is used for users whose Telegram client did not report a
+// language.
+const Default = Russian
+
+// Languages lists the supported languages in the order they are offered.
+var Languages = []Lang{Russian, English}
+
+// Parse maps a stored setting or a Telegram language_code such as "en-US"
+// to a supported language. Users of Russian, Ukrainian, Belarusian and
+// Kazakh clients get Russian, everybody else English.
+func Parse(code string) Lang {
+	base, _, _ := strings.Cut(strings.ToLower(code), "-")
+	switch base {
+	case "":
+		return Default
+	case "ru", "uk", "be", "kk":
+		return Russian
+	default:
+		return English
+	}
+}
+
+type catalog struct {
+	messages map[string]string
+	// plurals hold a format with a single %d per plural form.
+	plurals map[string]map[pluralForm]string
+}
+
+var catalogs = map[Lang]catalog{
+	Russian: {messages: ruMessages, plurals: ruPlurals},
+	English: {messages: enMessages, plurals: enPlurals},
+}
+
+// T returns the message for key formatted with args. Messages missing in
+// the language fall back to Default; unknown keys are returned as is so
+// that they show up in the chat rather than as an empty message.
+func (l Lang) T(key string, args ...any) string {
+	msg, ok := catalogs[l].messages[key]
+	if !ok {
+		msg, ok = catalogs[Default].messages[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N returns the message for key in the plural form the language uses for
+// n, with n formatted into it: "5 дней", "21 день", "1 day".
+func (l Lang) N(key string, n int) string {
+	forms, ok := catalogs[l].plurals[key]
+	if !ok {
+		l = Default
+		forms, ok = catalogs[l].plurals[key]
+	}
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(forms[l.pluralForm(n)], n)
+}
+
+// Name is the language's name in itself, for the language picker.
+func (l Lang) Name() string {
+	return l.T("language.name")
+}
+
+// Matches reports whether text is the message for key in any language. It
+// recognises reply keyboard buttons, which arrive as plain text.
+func Matches(key, text string) bool {
+	for _, l := range Languages {
+		if msg, ok := catalogs[l].messages[key]; ok && msg == text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestPluralFormRussian(t *testing.T) {
+	tests := []struct {
+		n    int
+		want pluralForm
+	}{
+		{0, many},
+		{1, one},
+		{2, few},
+		{4, few},
+		{5, many},
+		{11, many},
+		{12, many},
+		{14, many},
+		{21, one},
+		{22, few},
+		{25, many},
+		{101, one},
+		{111, many},
+		{112, many},
+		{122, few},
+		{-1, one},
+		{-5, many},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			if got := Russian.pluralForm(tt.n); got != tt.want {
+				t.Errorf("pluralForm(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluralFormEnglish(t *testing.T) {
+	tests := []struct {
+		n    int
+		want pluralForm
+	}{
+		{0, other},
+		{1, one},
+		{2, other},
+		{11, other},
+		{21, other},
+		{-1, one},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			if got := English.pluralForm(tt.n); got != tt.want {
+				t.Errorf("pluralForm(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		key  string
+		n    int
+		want string
+	}{
+		{Russian, "days", 1, "1 день"},
+		{Russian, "days", 3, "3 дня"},
+		{Russian, "days", 5, "5 дней"},
+		{Russian, "days", 11, "11 дней"},
+		{Russian, "days", 21, "21 день"},
+		{Russian, "days", 22, "22 дня"},
+		{Russian, "hours", 0, "0 часов"},
+		{Russian, "hours", 12, "12 часов"},
+		{Russian, "hours", 23, "23 часа"},
+		{English, "days", 1, "1 day"},
+		{English, "days", 21, "21 days"},
+		{English, "hours", 0, "0 hours"},
+		{English, "unknown", 2, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s/%d", tt.lang, tt.key, tt.n), func(t *testing.T) {
+			if got := tt.lang.N(tt.key, tt.n); got != tt.want {
+				t.Errorf("N(%q, %d) = %q, want %q", tt.key, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := English.T("assign.received", "@x"); got != "📨 @x assigned you a task" {
+		t.Errorf("T() = %q", got)
+	}
+	if got := Lang("de").T("menu.add"); got != Russian.T("menu.add") {
+		t.Errorf("T() in an unsupported language = %q, want the default language", got)
+	}
+	if got := English.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T() for an unknown key = %q, want the key", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Lang
+	}{
+		{"", Russian},
+		{"ru", Russian},
+		{"uk", Russian},
+		{"en", English},
+		{"en-US", English},
+		{"EN", English},
+		{"de", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Parse(tt.code); got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	for _, text := range []string{"Мои задачи", "My tasks"} {
+		if !Matches("menu.list", text) {
+			t.Errorf("Matches(%q) = false, want true", text)
+		}
+	}
+	if Matches("menu.list", "Settings") {
+		t.Error("Matches() = true for another button")
+	}
+}
+
+var verb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// verbs lists the fmt verbs in a message, ignoring escaped percent signs.
+func verbs(msg string) []string {
+	var result []string
+	for _, v := range verb.FindAllString(msg, -1) {
+		if v != "%%" {
+			result = append(result, v[len(v)-1:])
+		}
+	}
+	return result
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, l := range Languages {
+		for key, msg := range catalogs[Default].messages {
+			translated, ok := catalogs[l].messages[key]
+			if !ok {
+				t.Errorf("%s: message %q is missing", l, key)
+				continue
+			}
+			if got, want := verbs(translated), verbs(msg); !slices.Equal(got, want) {
+				t.Errorf("%s: message %q has verbs %v, want %v", l, key, got, want)
+			}
+		}
+		for key := range catalogs[l].messages {
+			if _, ok := catalogs[Default].messages[key]; !ok {
+				t.Errorf("%s: message %q is not in the default language", l, key)
+			}
+		}
+
+		for key := range catalogs[Default].plurals {
+			forms, ok := catalogs[l].plurals[key]
+			if !ok {
+				t.Errorf("%s: plural %q is missing", l, key)
+				continue
+			}
+			for _, form := range l.pluralForms() {
+				if got := verbs(forms[form]); !slices.Equal(got, []string{"d"}) {
+					t.Errorf("%s: plural %q form %v has verbs %v, want one %%d", l, key, form, got)
+				}
+			}
+		}
+	}
+}
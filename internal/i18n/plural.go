@@ -0,0 +1,46 @@
+package i18n
+
+// pluralForm is a CLDR plural category. Each language uses a subset:
+// English one and other, Russian one, few and many.
+type pluralForm int
+
+const (
+	one pluralForm = iota
+	few
+	many
+	other
+)
+
+// pluralForm implements the CLDR cardinal rules for integers. Negative
+// numbers take the form of their absolute value.
+func (l Lang) pluralForm(n int) pluralForm {
+	if n < 0 {
+		n = -n
+	}
+
+	switch l {
+	case Russian:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return one
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return few
+		default:
+			return many
+		}
+	default:
+		if n == 1 {
+			return one
+		}
+		return other
+	}
+}
+
+// pluralForms are the categories a language's plural messages must define.
+func (l Lang) pluralForms() []pluralForm {
+	if l == Russian {
+		return []pluralForm{one, few, many}
+	}
+	return []pluralForm{one, other}
+}
@@ -0,0 +1,405 @@
+package i18n
+
+var ruMessages = map[string]string{
+	// Main menu and common buttons
+	"menu.add":            "Добавить задачу",
+	"menu.list":           "Мои задачи",
+	"menu.settings":       "Настройки",
+	"button.done":         "Выполнено",
+	"button.snooze":       "💤 Через час",
+	"button.snoozed":      "💤 До %s",
+	"button.delete":       "Удалить",
+	"button.back_to_list": "◀ К списку",
+	"button.accept":       "👍 Принять",
+	"button.decline":      "👎 Отклонить",
+	"button.undo":         "Отменить",
+	"button.cancel":       "Отмена",
+	"common.cancelled":    "Действие отменено.",
+
+	// Frequencies
+	"frequency.daily":           "Ежедневно",
+	"frequency.every_other_day": "Через день",
+	"frequency.weekly":          "Раз в неделю",
+
+	// Task card labels, used by the templates in internal/render
+	"task.until_deadline":  "До дедлайна",
+	"task.work_hours_left": "Рабочих часов осталось",
+	"task.importance":      "Важность",
+	"task.frequency":       "Частота",
+
+	// Message styles
+	"style.detailed": "Подробный",
+	"style.compact":  "Компактный",
+
+	// Access control
+	"access.banned":         "⛔ Доступ к боту заблокирован.",
+	"access.allowlist":      "Бот доступен только участникам команды.",
+	"access.invite_only":    "Бот доступен только по приглашению. Попросите ссылку у администратора.",
+	"access.invite_invalid": "Приглашение недействительно, истекло или уже использовано.",
+
+	// /start
+	"start.help": `Привет! Я бот для напоминаний о задачах.
+
+Я помогу тебе не забыть о важных делах. Вот что я умею:
+
+📌 Добавить задачу - создать новое напоминание
+↪️ Перешли мне сообщение - и оно станет задачей
+📋 Мои задачи - посмотреть активные задачи
+⚙️ Настройки - изменить рабочие часы и язык
+
+Используй кнопки меню или команды:
+/add - добавить задачу
+/assign - поручить задачу другому
+/list - список задач
+/archive - выполненные задачи
+/stats - статистика
+/chart - графики
+/export_ics - экспорт в календарь
+/import - импорт задач из файла
+/export - выгрузка всех данных
+/delete_account - удалить аккаунт
+/token - токен для API
+/webhook - вебхуки
+/dnd - не беспокоить
+/quiet - тихие часы
+/settings - настройки`,
+
+	// Adding tasks
+	"add.unparsed":            "Не удалось разобрать: <code>%s</code>",
+	"add.ask_description":     "Введи описание задачи:",
+	"add.ask_deadline":        "Введи дедлайн в формате ДД.ММ.ГГГГ (например, 15.01.2025):",
+	"add.ask_importance":      "Выбери важность задачи (влияет на количество напоминаний в день):",
+	"add.ask_frequency":       "Выбери частоту напоминаний:",
+	"add.bad_deadline":        "Неверный формат даты. Введи в формате ДД.ММ.ГГГГ:",
+	"add.quick_past_deadline": "Дата %s уже прошла.",
+	"add.past_deadline":       "Дедлайн не может быть в прошлом. Введи корректную дату:",
+	"add.failed":              "Ошибка при создании задачи. Попробуй ещё раз.",
+	"add.created":             "✅ Задача создана!",
+
+	// Task actions
+	"task.completed": "✅ Задача выполнена!",
+	"task.deleted":   "🗑 Задача удалена.",
+	"task.restored":  "↩️ Задача восстановлена.",
+	"undo.expired":   "⌛ Время для отмены истекло.",
+
+	// Settings
+	"settings.summary": `⚙️ <b>Настройки</b>
+
+Рабочие часы в день: <b>%d</b>
+Часовой пояс: <b>%s</b>
+Язык: <b>%s</b>
+Стиль сообщений: <b>%s</b>
+Напоминания после тишины: <b>%s</b>
+
+Выбери что изменить:`,
+	"settings.button.work_hours":  "Рабочие часы в день",
+	"settings.button.timezone":    "Часовой пояс",
+	"settings.button.language":    "Язык / Language",
+	"settings.button.style":       "Стиль сообщений",
+	"settings.ask_work_hours":     "Выбери количество рабочих часов в день:",
+	"settings.ask_timezone":       "Отправь свой часовой пояс (например, Europe/Moscow, Asia/Yekaterinburg):",
+	"settings.ask_language":       "Выбери язык:",
+	"settings.ask_style":          "Выбери стиль сообщений: подробный показывает всё о задаче, компактный — только главное.",
+	"settings.work_hours_updated": "✅ Рабочие часы обновлены: %s в день",
+	"settings.language_updated":   "✅ Язык изменён на русский.",
+	"settings.style_updated":      "✅ Стиль сообщений: %s",
+	"language.name":               "Русский",
+
+	// Task list
+	"list.empty": "У тебя нет активных задач. Добавь новую с помощью /add",
+	"list.empty_filter": `📋 <b>Задачи</b> · %s
+
+Нет задач по этому фильтру.`,
+	"list.title":                  "Задачи",
+	"list.summary":                "Всего: %d, стр. %d/%d",
+	"list.sort_button.deadline":   "⏰ Дедлайн",
+	"list.sort_button.importance": "⚡ Важность",
+	"list.sort_button.created":    "🆕 Новые",
+	"list.sort_title.deadline":    "по дедлайну",
+	"list.sort_title.importance":  "по важности",
+	"list.sort_title.created":     "сначала новые",
+	"list.filter_button.all":      "Все",
+	"list.filter_button.today":    "Сегодня",
+	"list.filter_button.week":     "Неделя",
+	"list.filter_button.overdue":  "Просрочено",
+	"list.filter_title.all":       "все",
+	"list.filter_title.today":     "на сегодня",
+	"list.filter_title.week":      "на неделю",
+	"list.filter_title.overdue":   "просроченные",
+
+	// Group chats
+	"group.help": `Привет! Я напоминаю группе об общих задачах.
+
+/add описание дата - добавить задачу для группы, например:
+/add полить цветы завтра !2 еженедельно
+/list - общие задачи группы
+
+Напоминания приходят в этот чат, отметить задачу выполненной может любой участник. Удалить её может автор или администратор группы.
+
+Личные задачи и настройки - в личном чате со мной.`,
+	"group.no_tasks": `В этой группе нет общих задач. Добавь первую:
+/add полить цветы завтра`,
+	"group.private_only": "Эта команда работает только в личном чате со мной.",
+	"group.add_usage": `Укажи описание и дедлайн, например:
+/add полить цветы завтра`,
+	"group.created": "✅ Задача для группы создана!",
+	"group.completed_by": `✅ <b>%s</b>
+выполнил %s`,
+	"group.delete_forbidden": "%s, удалить задачу может только её автор или администратор группы.",
+
+	// Assignment
+	"assign.ask_assignee":    "Кому поручить задачу? Отправь @username или поделись контактом.",
+	"assign.not_found":       "Пользователь не найден. Он должен сначала запустить бота командой /start.",
+	"assign.self":            "Свою задачу добавь через /add.",
+	"assign.received":        "📨 %s поручил тебе задачу",
+	"assign.sent":            "📨 Задача поручена %s. Сообщу, когда её выполнят.",
+	"assign.accepted":        "👍 Задача принята.",
+	"assign.declined":        "👎 Задача отклонена и возвращена автору.",
+	"assign.accepted_notice": "👍 %s принял задачу «%s»",
+	"assign.declined_notice": "👎 %s отклонил задачу «%s». Она вернулась к тебе.",
+
+	// Admin commands
+	"admin.help": "\n\nАдминистрирование:\n/admin_stats - статистика бота\n/admin_user <id> - пользователь по Telegram ID\n/ban <id>, /unban <id> - заблокировать или разблокировать\n/broadcast <текст> - рассылка всем пользователям\n/invite - ссылка-приглашение",
+	"admin.stats": `📊 <b>Статистика бота</b>
+
+👥 Пользователей: <b>%d</b>
+• новых за 7 дней: %d
+• заблокировано: %d
+
+📋 Задачи:
+• активных: %d
+• просроченных: %d
+• выполненных: %d
+• удалённых (можно отменить): %d`,
+	"admin.usage":          "Использование: %s",
+	"admin.user_not_found": "Пользователь не найден.",
+	"admin.status_active":  "активен",
+	"admin.status_banned":  "заблокирован с %s",
+	"admin.status_admin":   ", администратор",
+	"admin.user": `👤 <b>Пользователь %d</b>
+
+Имя: %s
+Зарегистрирован: %s
+Часовой пояс: %s
+Рабочие часы: %d:00-%d:00
+Задач: активных %d, выполненных %d
+Статус: %s`,
+	"admin.ban_admin":        "Нельзя заблокировать администратора.",
+	"admin.banned":           "⛔ Пользователь %d заблокирован. Напоминания ему больше не отправляются.",
+	"admin.unbanned":         "✅ Пользователь %d разблокирован.",
+	"admin.broadcast_syntax": "/broadcast <текст>",
+	"admin.broadcast_preview": `📣 Рассылка, %s:
+
+%s`,
+	"admin.button.broadcast":   "📣 Отправить",
+	"admin.broadcast_started":  "📣 Рассылка запущена, %s.",
+	"admin.broadcast_finished": "📣 Рассылка завершена: доставлено %d, не доставлено %d.",
+	"admin.invite": `🎟 Одноразовое приглашение до %s:
+https://t.me/%s?start=%s`,
+	"admin.invite_not_required": "\n\nРежим доступа сейчас не invite, поэтому для регистрации приглашение не требуется.",
+
+	// Archive
+	"archive.empty":       "В архиве пока пусто. Выполненные задачи появятся здесь.",
+	"archive.title":       "Выполненные задачи",
+	"archive.reopen_hint": "↩️ — вернуть задачу в активные",
+
+	// Account
+	"account.export_failed":  "Не удалось подготовить выгрузку. Попробуй позже.",
+	"account.export_caption": "📦 Все твои данные: профиль, настройки, задачи и история.",
+	"account.delete_confirm": `⚠️ <b>Удаление аккаунта</b>
+
+Будут безвозвратно удалены профиль, настройки, все задачи и история. Отменить это нельзя.
+
+Если нужна копия данных, сначала сделай /export.`,
+	"account.button.delete": "🗑 Да, удалить всё",
+	"account.delete_failed": "Не удалось удалить аккаунт. Попробуй позже.",
+	"account.deleted":       "🗑 Аккаунт и все данные удалены.",
+	"account.come_back":     "Если захочешь вернуться, просто напиши /start.",
+
+	// Statistics and charts
+	"stats.empty": "📊 Статистики пока нет — выполни первую задачу!",
+	"stats.summary": `📊 <b>Статистика</b>
+
+✅ Выполнено за неделю: <b>%d</b>
+✅ Выполнено за месяц: <b>%d</b>
+✅ Всего за год: <b>%d</b>
+
+⏰ В срок: <b>%d</b>, с опозданием: <b>%d</b> (%.0f%% в срок)
+🔔 Напоминаний до выполнения: <b>%.1f</b> в среднем
+🔥 Серия: <b>%d</b> (лучшая: %d)`,
+	"stats.by_importance":      "\n\n⚡ По важности:\n",
+	"chart.ask":                "Какой график показать?",
+	"chart.button.completions": "✅ Выполнено по дням",
+	"chart.button.burndown":    "📉 Открытые задачи",
+	"chart.button.hours":       "🔔 Напоминания по часам",
+	"chart.completions":        "✅ Выполнено задач по дням за %s",
+	"chart.burndown":           "📉 Открытые задачи на конец дня за %s",
+	"chart.hours":              "🔔 Напоминания по часам за %s (%s)",
+
+	// Calendar
+	"calendar.caption": "📅 Активные задачи: %d. Открой файл, чтобы добавить их в календарь.",
+	"calendar.feed": `🔗 <b>Подписка на календарь</b>
+
+Добавь ссылку в календарь как подписку, и задачи будут обновляться автоматически:
+<code>%s</code>
+
+Никому не показывай эту ссылку.`,
+	"calendar.button.rotate": "🔄 Новая ссылка",
+	"calendar.rotated":       "🔄 Ссылка обновлена, старая больше не работает.\n\n",
+
+	// Import
+	"import.help": `📥 <b>Импорт задач</b>
+
+Отправь файл одного из форматов:
+• <b>.ics</b> — задачи (VTODO) из календаря
+• <b>.csv</b> — первая строка с названиями колонок: описание, срок, важность, частота (или description, deadline, priority, frequency)
+• <b>.json</b> — экспорт из Todoist или доска Trello
+
+Перед импортом покажу, какие задачи будут созданы и какие строки пропущены.`,
+	"import.too_large":       "Файл слишком большой, максимум 1 МБ.",
+	"import.download_failed": "Не удалось скачать файл. Попробуй ещё раз.",
+	"import.parse_failed":    "Не удалось прочитать файл: %s",
+	"import.unknown_format":  "Неизвестный формат файла. Поддерживаются .ics, .csv и JSON-экспорт Todoist или Trello. Подробнее: /import",
+	"import.button.confirm":  "✅ Импортировать (%d)",
+	"import.done":            "✅ Импортировано задач: <b>%d</b>",
+	"import.done_failed":     "\n⚠️ Не удалось сохранить: %d",
+	"import.done_skipped":    "\nПропущено при разборе: %d",
+	"import.done_footer":     "\n\nСписок задач: /list",
+	"import.preview_header":  "📥 <b>Импорт (%s)</b>\n\n",
+	"import.preview_empty":   "В файле нет задач, которые можно импортировать.\n",
+	"import.preview_items":   "Будет создано задач: <b>%d</b>\n",
+	"import.preview_more":    "…и ещё %d\n",
+	"import.preview_skipped": "\nПропущено: <b>%d</b>\n",
+
+	// API tokens
+	"token.revoked": "🔒 Отозвано токенов: %d",
+	"token.usage":   "Использование: /token — новый токен, /token revoke — отозвать все.",
+	"token.issued": `🔑 <b>Токен API</b>
+
+<code>%s</code>
+
+Токен показывается один раз, сохрани его. Передавай его в заголовке <code>Authorization: Bearer ...</code>.
+Отозвать все токены: /token revoke`,
+	"token.api_url": "\n\nАдрес API: <code>%s/api/v1</code>\nОписание: %s/api/v1/openapi.json",
+
+	// Webhooks
+	"webhook.usage":       "Использование: /webhook, /webhook add <url>, /webhook log",
+	"webhook.add_failed":  "Не удалось добавить вебхук. Попробуй позже.",
+	"webhook.invalid_url": "Нужен полный адрес, например: /webhook add https://example.com/hooks/tasks",
+	"webhook.too_many":    "Достигнут лимит вебхуков. Удали лишние в /webhook.",
+	"webhook.added": `🔗 <b>Вебхук добавлен</b>
+
+%s
+
+Секрет для проверки подписи:
+<code>%s</code>
+
+Каждый запрос подписан: заголовок <code>%s</code> содержит HMAC-SHA256 от строки <code>&lt;%s&gt;.&lt;тело&gt;</code>.`,
+	"webhook.log_header":  "📜 <b>Последние доставки</b>\n\n",
+	"webhook.log_empty":   "Доставок пока не было.",
+	"webhook.log_entry":   "%s %s %s #%d → %s (попытка %d)\n",
+	"webhook.list_header": "🔗 <b>Вебхуки</b>\n\n",
+	"webhook.list_empty":  "Вебхуков нет.\n",
+	"webhook.list_footer": "\nСобытия: task.created, task.reminder_sent, task.completed, task.deleted, task.overdue и другие.\nДобавить: /webhook add &lt;url&gt;\nЖурнал доставок: /webhook log",
+
+	// Reminders and notifications
+	"reminder.title":          "Напоминание",
+	"reminder.title_firm":     "Дедлайн близко",
+	"reminder.title_urgent":   "Срочно",
+	"reminder.today":          "%d/%d за сегодня",
+	"reminder.work_hours":     "Рабочих часов",
+	"assign.completed_notice": "✅ %s выполнил задачу «%s»",
+	"assign.overdue_notice":   "⚠️ Задача «%s» для %s просрочена (дедлайн %s)",
+
+	// Quiet hours
+	"dnd.usage": "Использование: /dnd 2h — тишина на 2 часа, /dnd 30m — на 30 минут, /dnd tomorrow — до завтра, /dnd off — выключить.",
+	"dnd.status_on": `🔕 Не беспокоить до <b>%s</b>.
+
+Изменить:`,
+	"dnd.status_off":              "🔔 Режим «Не беспокоить» выключен. На сколько включить?",
+	"dnd.enabled":                 "🔕 Не беспокоить до <b>%s</b>. Напоминания, которые придутся на это время, придут после.",
+	"dnd.failed":                  "Не удалось изменить режим «Не беспокоить». Попробуй позже.",
+	"dnd.disabled":                "🔔 Режим «Не беспокоить» выключен.",
+	"button.dnd_tomorrow":         "До завтра",
+	"button.dnd_off":              "Выключить",
+	"quiet.list_header":           "🔕 <b>Тихие часы</b>\n\n",
+	"quiet.list_empty":            "Тихих часов нет.\n",
+	"quiet.list_entry":            "%d. %s, %s\n",
+	"quiet.list_footer":           "\nДобавить: /quiet 13:00-14:00 или /quiet пн-пт 13:00-14:00\nРазово: /dnd 2h",
+	"quiet.added":                 "✅ Тихие часы добавлены: %s, %s",
+	"quiet.invalid":               "Не понял время. Например: /quiet 13:00-14:00 или /quiet пн,ср 10:00-11:00",
+	"quiet.too_many":              "Достигнут лимит тихих часов. Удали лишние в /quiet.",
+	"quiet.add_failed":            "Не удалось добавить тихие часы. Попробуй позже.",
+	"quiet.every_day":             "каждый день",
+	"quiet.summary_title":         "Пока тебя не беспокоили",
+	"quiet.summary_footer":        "Отметить выполненное: /list",
+	"quiet_mode.defer":            "По одному",
+	"quiet_mode.summary":          "Одной сводкой",
+	"weekday.mon":                 "пн",
+	"weekday.tue":                 "вт",
+	"weekday.wed":                 "ср",
+	"weekday.thu":                 "чт",
+	"weekday.fri":                 "пт",
+	"weekday.sat":                 "сб",
+	"weekday.sun":                 "вс",
+	"settings.button.quiet_mode":  "Напоминания после тишины",
+	"settings.ask_quiet_mode":     "Как прислать напоминания, которые пришлись на тихие часы или «Не беспокоить»?",
+	"settings.quiet_mode_updated": "✅ Напоминания после тишины: %s",
+
+	// Paused tasks
+	"button.pause":              "⏸ Пауза",
+	"button.resume":             "▶️ Возобновить",
+	"button.pause_until_date":   "📅 До даты…",
+	"button.pause_indefinitely": "Пока не возобновлю",
+	"button.back":               "◀ Назад",
+	"pause.ask_date":            "До какой даты приостановить напоминания? Например: 20.01 или завтра",
+	"pause.bad_date":            "Не понял дату. Например: 20.01, 2025-01-20 или завтра",
+	"pause.past_date":           "Дата должна быть позже сегодняшней.",
+	"pause.paused":              "⏸ Напоминания приостановлены",
+	"pause.resumed":             "▶️ Напоминания возобновлены",
+	"pause.resumed_auto":        "▶️ Пауза закончилась, напоминания возобновлены",
+	"pause.failed":              "Не удалось изменить паузу. Попробуй позже.",
+	"task.paused":               "На паузе",
+	"task.paused_until":         "На паузе до %s",
+	"list.paused_title":         "⏸ На паузе: %d",
+
+	// Task dependencies
+	"button.dependencies":         "⛓ Зависимости",
+	"task.blocked_by":             "Ждёт",
+	"dependency.header":           "⛓ <b>Зависимости</b> · %s\n\n",
+	"dependency.waits_for":        "Ждёт выполнения:\n",
+	"dependency.none":             "Ничего не ждёт.\n",
+	"dependency.hint":             "\nНажми ➕ у задачи, которую нужно выполнить раньше, или ❌, чтобы убрать зависимость. Пока задача ждёт, напоминаний по ней нет.",
+	"dependency.cycle":            "Нельзя: «%s» уже ждёт эту задачу.",
+	"dependency.failed":           "Не удалось изменить зависимости. Попробуй позже.",
+	"dependency.unblocked_notice": "🔓 Задача «%s» больше ничего не ждёт, напоминания возобновятся.",
+
+	// Task attachments
+	"button.attachments":       "📎 Вложения",
+	"button.attachments_count": "📎 Вложения (%d)",
+	"button.attach":            "➕ Прикрепить",
+	"button.send_all":          "📤 Прислать все",
+	"button.attach_done":       "✅ Готово",
+	"attachment.header":        "📎 <b>Вложения</b> · %s\n\n",
+	"attachment.none":          "Вложений нет.",
+	"attachment.entry":         "%d. %s %s\n",
+	"attachment.ask":           "Пришли фото, файл, голосовое, видео или ссылку — или перешли сообщение, — и я прикреплю их к задаче «%s».",
+	"attachment.added":         "📎 Прикреплено: %s (%d из %d). Пришли ещё или нажми «Готово».",
+	"attachment.unsupported":   "Это не прикрепить. Пришли фото, файл, голосовое, видео, ссылку или перешли сообщение.",
+	"attachment.too_many":      "К задаче можно прикрепить не больше %d вложений.",
+	"attachment.failed":        "Не удалось изменить вложения. Попробуй позже.",
+	"attachment.send_failed":   "Не удалось прислать «%s».",
+	"attachment.kind.photo":    "Фото",
+	"attachment.kind.document": "Файл",
+	"attachment.kind.voice":    "Голосовое сообщение",
+	"attachment.kind.video":    "Видео",
+	"attachment.kind.audio":    "Аудио",
+	"attachment.kind.message":  "Пересланное сообщение",
+	"attachment.kind.link":     "Ссылка",
+}
+
+var ruPlurals = map[string]map[pluralForm]string{
+	"days":       {one: "%d день", few: "%d дня", many: "%d дней"},
+	"hours":      {one: "%d час", few: "%d часа", many: "%d часов"},
+	"recipients": {one: "%d получатель", few: "%d получателя", many: "%d получателей"},
+}
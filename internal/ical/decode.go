@@ -0,0 +1,149 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Decode reads the first component of an iCalendar stream, unfolding lines.
+// Property values are kept raw; use UnescapeText for TEXT values.
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+	for i, line := range lines {
+		prop, ok := parseLine(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: malformed content line", i+1)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			stack = append(stack, NewComponent(strings.ToUpper(prop.Value)))
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, prop.Value)
+			}
+			done := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return done, nil
+			}
+			stack[len(stack)-1].AddComponent(done)
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside of a component", i+1)
+			}
+			cur := stack[len(stack)-1]
+			cur.Properties = append(cur.Properties, prop)
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected end of calendar")
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseLine splits NAME;PARAMS:VALUE. Colons inside quoted parameter values
+// do not end the name part.
+func parseLine(line string) (Property, bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			name, params, _ := strings.Cut(line[:i], ";")
+			if name == "" {
+				return Property{}, false
+			}
+			return Property{Name: strings.ToUpper(name), Params: params, Value: line[i+1:]}, true
+		}
+	}
+	return Property{}, false
+}
+
+// Get returns the first property with the given name.
+func (c *Component) Get(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// Children returns the direct subcomponents with the given name.
+func (c *Component) Children(name string) []*Component {
+	var result []*Component
+	for _, child := range c.Components {
+		if child.Name == name {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// Param returns the value of a property parameter, e.g. TZID.
+func (p Property) Param(name string) string {
+	for _, param := range strings.Split(p.Params, ";") {
+		if key, value, ok := strings.Cut(param, "="); ok && strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+var textUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, `;`,
+	`\,`, `,`,
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+// UnescapeText reverses EscapeText.
+func UnescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+// ParseTime parses a DATE or DATE-TIME property. Floating times and times
+// with a TZID that cannot be loaded are read in loc.
+func ParseTime(p Property, loc *time.Location) (time.Time, error) {
+	if tzid := p.Param("TZID"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	switch {
+	case len(p.Value) == len("20060102"):
+		return time.ParseInLocation("20060102", p.Value, loc)
+	case strings.HasSuffix(p.Value, "Z"):
+		return time.Parse("20060102T150405Z", p.Value)
+	default:
+		return time.ParseInLocation("20060102T150405", p.Value, loc)
+	}
+}
@@ -0,0 +1,123 @@
+// Package ical reads and writes RFC 5545 calendars.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Property is a single content line, e.g. DUE;VALUE=DATE:20250115.
+type Property struct {
+	Name   string
+	Params string
+	Value  string
+}
+
+// Component is a calendar component such as VTODO, VEVENT or VALARM.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends a property with a raw value.
+func (c *Component) Add(name, params, value string) *Component {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+	return c
+}
+
+// AddText appends a property with a TEXT value, escaping it.
+func (c *Component) AddText(name, value string) *Component {
+	return c.Add(name, "", EscapeText(value))
+}
+
+// AddDate appends a DATE property.
+func (c *Component) AddDate(name string, t time.Time) *Component {
+	return c.Add(name, "VALUE=DATE", FormatDate(t))
+}
+
+// AddDateTime appends a DATE-TIME property in UTC.
+func (c *Component) AddDateTime(name string, t time.Time) *Component {
+	return c.Add(name, "", FormatDateTime(t))
+}
+
+func (c *Component) AddComponent(child *Component) *Component {
+	c.Components = append(c.Components, child)
+	return c
+}
+
+// Encode writes c with CRLF line endings and lines folded at 75 octets.
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	writeComponent(bw, c)
+	return bw.Flush()
+}
+
+func writeComponent(w *bufio.Writer, c *Component) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		line := p.Name
+		if p.Params != "" {
+			line += ";" + p.Params
+		}
+		writeLine(w, line+":"+p.Value)
+	}
+	for _, child := range c.Components {
+		writeComponent(w, child)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+func writeLine(w *bufio.Writer, line string) {
+	for _, part := range Fold(line) {
+		w.WriteString(part)
+		w.WriteString("\r\n")
+	}
+}
+
+// Fold splits a content line into chunks of at most 75 octets without
+// breaking UTF-8 sequences. Continuation chunks start with a space.
+func Fold(line string) []string {
+	const limit = 75
+
+	var parts []string
+	prefix := ""
+	for len(prefix)+len(line) > limit {
+		cut := limit - len(prefix)
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		parts = append(parts, prefix+line[:cut])
+		line = line[cut:]
+		prefix = " "
+	}
+	return append(parts, prefix+line)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// EscapeText escapes a TEXT value.
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func FormatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
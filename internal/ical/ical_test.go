@@ -0,0 +1,221 @@
+package ical
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestTaskCalendar_Golden(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2025, 1, 9, 15, 0, 0, 0, time.UTC)
+
+	user := domain.NewUser(1, "ivan")
+	tasks := []*domain.Task{
+		{
+			ID:          1,
+			Description: "Подготовить отчёт; черновик, итог",
+			Deadline:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			Importance:  3,
+			Frequency:   domain.FrequencyDaily,
+			CreatedAt:   time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:          2,
+			Description: "Продлить подписку",
+			Deadline:    time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Importance:  1,
+			Frequency:   domain.FrequencyWeekly,
+			IsCompleted: true,
+			CompletedAt: &completedAt,
+			CreatedAt:   time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, TaskCalendar(tasks, user, now)); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "tasks.ics")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden file: %v (run go test -update)", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("calendar differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestTaskCalendar_AlarmsMatchReminderTimes(t *testing.T) {
+	user := domain.NewUser(1, "ivan")
+	task := &domain.Task{
+		ID:         1,
+		Deadline:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		Importance: 3,
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, TaskCalendar([]*domain.Task{task}, user, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	// 9:00-18:00 Moscow time split in three slots: 10:30, 13:30, 16:30 MSK.
+	for _, trigger := range []string{"20250115T073000Z", "20250115T103000Z", "20250115T133000Z"} {
+		if !strings.Contains(buf.String(), "TRIGGER;VALUE=DATE-TIME:"+trigger+"\r\n") {
+			t.Errorf("missing alarm at %s", trigger)
+		}
+	}
+	if n := strings.Count(buf.String(), "BEGIN:VALARM"); n != 3 {
+		t.Errorf("got %d alarms, want 3", n)
+	}
+}
+
+func TestFold(t *testing.T) {
+	short := "SUMMARY:short"
+	if got := Fold(short); len(got) != 1 || got[0] != short {
+		t.Errorf("Fold(%q) = %q", short, got)
+	}
+
+	long := "SUMMARY:" + strings.Repeat("ж", 80)
+	parts := Fold(long)
+	if len(parts) < 2 {
+		t.Fatalf("Fold() returned %d parts, want several", len(parts))
+	}
+
+	var joined strings.Builder
+	for i, part := range parts {
+		if len(part) > 75 {
+			t.Errorf("part %d is %d octets, want at most 75", i, len(part))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(part, " ") {
+				t.Errorf("continuation %d does not start with a space", i)
+			}
+			part = part[1:]
+		}
+		joined.WriteString(part)
+	}
+	if joined.String() != long {
+		t.Error("unfolded line differs from the original")
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"a, b; c", `a\, b\; c`},
+		{`back\slash`, `back\\slash`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2", `line1\nline2`},
+	}
+
+	for _, tt := range tests {
+		if got := EscapeText(tt.in); got != tt.want {
+			t.Errorf("EscapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPriority(t *testing.T) {
+	want := map[int]int{1: 9, 2: 7, 3: 5, 4: 3, 5: 1}
+	for importance, priority := range want {
+		if got := Priority(importance); got != priority {
+			t.Errorf("Priority(%d) = %d, want %d", importance, got, priority)
+		}
+	}
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "tasks.ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cal, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("re-encoded calendar differs:\n%s", buf.String())
+	}
+
+	todos := cal.Children("VTODO")
+	if len(todos) != 2 {
+		t.Fatalf("got %d VTODOs, want 2", len(todos))
+	}
+	summary, _ := todos[0].Get("SUMMARY")
+	if got := UnescapeText(summary.Value); got != "Подготовить отчёт; черновик, итог" {
+		t.Errorf("SUMMARY = %q", got)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	input := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VTODO\n" +
+		"SUMMARY:Long\n" +
+		"  summary\n" +
+		"DUE;TZID=Europe/Moscow:20250115T180000\n" +
+		"X-LINK;X-LABEL=\"a:b\":https://example.com\n" +
+		"END:VTODO\n" +
+		"END:VCALENDAR\n"
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	todo := cal.Children("VTODO")[0]
+	if p, _ := todo.Get("SUMMARY"); p.Value != "Long summary" {
+		t.Errorf("SUMMARY = %q, want unfolded value", p.Value)
+	}
+	if p, _ := todo.Get("X-LINK"); p.Value != "https://example.com" || p.Param("X-LABEL") != "a:b" {
+		t.Errorf("X-LINK = %+v", p)
+	}
+
+	due, _ := todo.Get("DUE")
+	got, err := ParseTime(due, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ParseTime() = %v, want %v", got, want)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"unterminated":   "BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VTODO\n",
+		"mismatched end": "BEGIN:VCALENDAR\nEND:VTODO\n",
+		"no colon":       "BEGIN:VCALENDAR\nGARBAGE\nEND:VCALENDAR\n",
+	}
+
+	for name, input := range tests {
+		if _, err := Decode(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: Decode() error = nil, want error", name)
+		}
+	}
+}
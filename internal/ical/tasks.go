@@ -0,0 +1,84 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/scheduler"
+)
+
+const prodID = "-//telegram-reminder-bot//Tasks//RU"
+
+// TaskCalendar builds a calendar with a VTODO for every task and an all-day
+// VEVENT on its deadline. The event carries one VALARM per reminder the bot
+// would send on the deadline day, at the same times.
+func TaskCalendar(tasks []*domain.Task, user *domain.User, now time.Time) *Component {
+	cal := NewComponent("VCALENDAR").
+		Add("VERSION", "", "2.0").
+		Add("PRODID", "", prodID).
+		Add("CALSCALE", "", "GREGORIAN").
+		Add("METHOD", "", "PUBLISH").
+		AddText("X-WR-CALNAME", "Задачи").
+		Add("X-WR-TIMEZONE", "", user.Timezone)
+
+	for _, task := range tasks {
+		cal.AddComponent(taskTodo(task, now))
+		cal.AddComponent(deadlineEvent(task, user, now))
+	}
+
+	return cal
+}
+
+func taskTodo(task *domain.Task, now time.Time) *Component {
+	todo := NewComponent("VTODO").
+		Add("UID", "", fmt.Sprintf("task-%d@telegram-reminder-bot", task.ID)).
+		AddDateTime("DTSTAMP", now).
+		AddDateTime("CREATED", task.CreatedAt).
+		AddText("SUMMARY", task.Description).
+		AddDate("DUE", task.Deadline).
+		Add("PRIORITY", "", fmt.Sprint(Priority(task.Importance)))
+
+	if task.IsCompleted {
+		todo.Add("STATUS", "", "COMPLETED")
+		if task.CompletedAt != nil {
+			todo.AddDateTime("COMPLETED", *task.CompletedAt)
+		}
+	} else {
+		todo.Add("STATUS", "", "NEEDS-ACTION")
+	}
+
+	return todo
+}
+
+func deadlineEvent(task *domain.Task, user *domain.User, now time.Time) *Component {
+	event := NewComponent("VEVENT").
+		Add("UID", "", fmt.Sprintf("deadline-%d@telegram-reminder-bot", task.ID)).
+		AddDateTime("DTSTAMP", now).
+		AddDate("DTSTART", task.Deadline).
+		AddDate("DTEND", task.Deadline.AddDate(0, 0, 1)).
+		AddText("SUMMARY", "⏰ "+task.Description).
+		Add("TRANSP", "", "TRANSPARENT")
+
+	day := time.Date(task.Deadline.Year(), task.Deadline.Month(), task.Deadline.Day(), 12, 0, 0, 0, user.Location())
+	for _, at := range scheduler.CalculateReminderTimes(task.Importance, user.WorkStartHour, user.WorkEndHour, day) {
+		event.AddComponent(NewComponent("VALARM").
+			Add("ACTION", "", "DISPLAY").
+			AddText("DESCRIPTION", task.Description).
+			Add("TRIGGER", "VALUE=DATE-TIME", FormatDateTime(at)))
+	}
+
+	return event
+}
+
+// Priority maps importance 1-5 onto the iCalendar 1 (highest) to 9 (lowest) scale.
+func Priority(importance int) int {
+	switch {
+	case importance >= 5:
+		return 1
+	case importance <= 0:
+		return 0
+	default:
+		return 11 - 2*importance
+	}
+}
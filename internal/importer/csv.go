@@ -0,0 +1,157 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type csvField int
+
+const (
+	csvDescription csvField = iota
+	csvDeadline
+	csvImportance
+	csvFrequency
+)
+
+// csvHeaders maps lower-cased column names onto task fields. Columns with
+// other names are ignored.
+var csvHeaders = map[string]csvField{
+	"description": csvDescription,
+	"title":       csvDescription,
+	"name":        csvDescription,
+	"task":        csvDescription,
+	"content":     csvDescription,
+	"описание":    csvDescription,
+	"задача":      csvDescription,
+	"название":    csvDescription,
+
+	"deadline": csvDeadline,
+	"due":      csvDeadline,
+	"due date": csvDeadline,
+	"date":     csvDeadline,
+	"дедлайн":  csvDeadline,
+	"срок":     csvDeadline,
+	"дата":     csvDeadline,
+
+	"importance": csvImportance,
+	"priority":   csvImportance,
+	"важность":   csvImportance,
+	"приоритет":  csvImportance,
+
+	"frequency":  csvFrequency,
+	"recurrence": csvFrequency,
+	"repeat":     csvFrequency,
+	"частота":    csvFrequency,
+	"повтор":     csvFrequency,
+}
+
+var errNoDescriptionColumn = errors.New("no description column (description, title, name, task, описание, задача)")
+
+// parseCSV reads a CSV file whose first row names the columns. The
+// delimiter is detected from the header: comma, semicolon or tab.
+func parseCSV(data []byte, now time.Time) (*Result, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make(map[csvField]int)
+	for i, name := range header {
+		field, ok := csvHeaders[strings.ToLower(strings.TrimSpace(name))]
+		if _, seen := columns[field]; ok && !seen {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns[csvDescription]; !ok {
+		return nil, errNoDescriptionColumn
+	}
+
+	result := &Result{}
+	today := dateOf(now)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				result.skip(fmt.Sprintf("строка %d", parseErr.Line), "не удалось разобрать строку")
+				continue
+			}
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		ref := fmt.Sprintf("строка %d", line)
+
+		value := func(field csvField) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		item := Item{Description: value(csvDescription)}
+
+		if s := value(csvDeadline); s != "" {
+			deadline, ok := parseDate(s, now)
+			if !ok {
+				result.skip(ref, "неверная дата: "+s)
+				continue
+			}
+			item.Deadline = deadline
+		}
+
+		if s := value(csvImportance); s != "" {
+			importance, ok := parseImportance(s)
+			if !ok {
+				result.skip(ref, "неверная важность: "+s)
+				continue
+			}
+			item.Importance = importance
+		}
+
+		if s := value(csvFrequency); s != "" {
+			item.Frequency = parseFrequency(s, now)
+			if item.Frequency == "" {
+				result.skip(ref, "неизвестная частота: "+s)
+				continue
+			}
+		}
+
+		result.add(ref, item, today)
+	}
+
+	return result, nil
+}
+
+func detectDelimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	best, bestCount := ',', bytes.Count(header, []byte(","))
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(header, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
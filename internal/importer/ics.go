@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/ical"
+)
+
+func parseICS(data []byte, now time.Time) (*Result, error) {
+	cal, err := ical.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	today := dateOf(now)
+
+	for i, todo := range cal.Children("VTODO") {
+		summary, _ := todo.Get("SUMMARY")
+		description := ical.UnescapeText(summary.Value)
+
+		ref := description
+		if ref == "" {
+			ref = "VTODO #" + strconv.Itoa(i+1)
+		}
+
+		if status, ok := todo.Get("STATUS"); ok && (status.Value == "COMPLETED" || status.Value == "CANCELLED") {
+			result.skip(ref, "задача уже закрыта")
+			continue
+		}
+
+		item := Item{Description: description}
+
+		due, ok := todo.Get("DUE")
+		if !ok {
+			due, ok = todo.Get("DTSTART")
+		}
+		if ok {
+			t, err := ical.ParseTime(due, now.Location())
+			if err != nil {
+				result.skip(ref, "неверная дата: "+due.Value)
+				continue
+			}
+			item.Deadline = dateOf(t.In(now.Location()))
+		}
+
+		if priority, ok := todo.Get("PRIORITY"); ok {
+			if n, err := strconv.Atoi(priority.Value); err == nil {
+				item.Importance = importanceFromPriority(n)
+			}
+		}
+
+		if rrule, ok := todo.Get("RRULE"); ok {
+			item.Frequency = frequencyFromRRule(rrule.Value)
+		}
+
+		result.add(ref, item, today)
+	}
+
+	return result, nil
+}
+
+// importanceFromPriority maps the iCalendar 1 (highest) to 9 (lowest) scale
+// onto importance; 0 means undefined. It is the inverse of ical.Priority.
+func importanceFromPriority(priority int) int {
+	if priority < 1 || priority > 9 {
+		return 0
+	}
+	return 5 - priority/2
+}
+
+// frequencyFromRRule maps a recurrence rule onto the closest reminder
+// frequency, e.g. FREQ=DAILY;INTERVAL=2 onto every other day.
+func frequencyFromRRule(rule string) domain.Frequency {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			parts[strings.ToUpper(key)] = strings.ToUpper(value)
+		}
+	}
+
+	interval := 1
+	if n, err := strconv.Atoi(parts["INTERVAL"]); err == nil && n > 0 {
+		interval = n
+	}
+
+	switch parts["FREQ"] {
+	case "DAILY":
+		switch {
+		case interval == 1:
+			return domain.FrequencyDaily
+		case interval < 7:
+			return domain.FrequencyEveryOtherDay
+		default:
+			return domain.FrequencyWeekly
+		}
+	case "WEEKLY", "MONTHLY", "YEARLY":
+		return domain.FrequencyWeekly
+	}
+	return ""
+}
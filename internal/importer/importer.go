@@ -0,0 +1,225 @@
+// Package importer reads tasks from files exported by other tools: iCalendar
+// VTODOs, CSV with a header row, and Todoist or Trello JSON exports.
+//
+// Parsing never writes anything; the caller shows the result as a preview and
+// creates the tasks once the user confirms.
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/quickadd"
+)
+
+type Format string
+
+const (
+	FormatICS     Format = "ics"
+	FormatCSV     Format = "csv"
+	FormatTodoist Format = "todoist"
+	FormatTrello  Format = "trello"
+)
+
+const (
+	DefaultImportance = 3
+	DefaultFrequency  = domain.FrequencyDaily
+)
+
+var ErrUnknownFormat = errors.New("unknown file format")
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// Item is a task ready to be created.
+type Item struct {
+	Description string
+	// Deadline is a date at midnight UTC.
+	Deadline   time.Time
+	Importance int
+	Frequency  domain.Frequency
+}
+
+// Skipped describes a source record that was not imported.
+type Skipped struct {
+	// Ref identifies the record in the source: a CSV line number, a task title.
+	Ref    string
+	Reason string
+}
+
+type Result struct {
+	Format  Format
+	Items   []Item
+	Skipped []Skipped
+}
+
+func (r *Result) skip(ref, reason string) {
+	r.Skipped = append(r.Skipped, Skipped{Ref: ref, Reason: reason})
+}
+
+// add validates an item and either appends it or records why it was skipped.
+func (r *Result) add(ref string, item Item, today time.Time) {
+	item.Description = strings.TrimSpace(item.Description)
+	switch {
+	case item.Description == "":
+		r.skip(ref, "нет описания")
+	case item.Deadline.IsZero():
+		r.skip(ref, "нет дедлайна")
+	case item.Deadline.Before(today):
+		r.skip(ref, "дедлайн в прошлом")
+	default:
+		if item.Importance == 0 {
+			item.Importance = DefaultImportance
+		}
+		if item.Frequency == "" {
+			item.Frequency = DefaultFrequency
+		}
+		r.Items = append(r.Items, item)
+	}
+}
+
+// Parse detects the format of data from the file name and content and
+// returns the tasks it contains. now must be in the user's timezone: it is
+// used for floating times and to skip tasks whose deadline has passed.
+func Parse(filename string, data []byte, now time.Time) (*Result, error) {
+	format, err := Detect(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Result
+	switch format {
+	case FormatICS:
+		result, err = parseICS(data, now)
+	case FormatCSV:
+		result, err = parseCSV(data, now)
+	case FormatTodoist:
+		result, err = parseTodoist(data, now)
+	case FormatTrello:
+		result, err = parseTrello(data, now)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", format, err)
+	}
+
+	result.Format = format
+	return result, nil
+}
+
+// Detect returns the format of a file, looking at the extension first and
+// at the content when the extension is missing or ambiguous.
+func Detect(filename string, data []byte) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ics", ".ical", ".ifb":
+		return FormatICS, nil
+	case ".csv", ".tsv":
+		return FormatCSV, nil
+	}
+
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, utf8BOM))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("BEGIN:VCALENDAR")):
+		return FormatICS, nil
+	case bytes.HasPrefix(trimmed, []byte("[")), bytes.HasPrefix(trimmed, []byte("{")):
+		return detectJSON(trimmed)
+	case strings.EqualFold(filepath.Ext(filename), ".txt"):
+		return FormatCSV, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+func detectJSON(data []byte) (Format, error) {
+	if data[0] == '[' {
+		return FormatTodoist, nil
+	}
+
+	var probe struct {
+		Cards json.RawMessage `json:"cards"`
+		Items json.RawMessage `json:"items"`
+		Tasks json.RawMessage `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch {
+	case probe.Cards != nil:
+		return FormatTrello, nil
+	case probe.Items != nil, probe.Tasks != nil:
+		return FormatTodoist, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// parseFrequency maps a recurrence description such as "every day",
+// "через день" or "weekly" onto a frequency. It returns "" when the text
+// describes nothing the bot supports.
+func parseFrequency(s string, now time.Time) domain.Frequency {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if freq, ok := domain.ParseFrequency(s); ok {
+		return freq
+	}
+	return quickadd.Parse(s, now).Frequency
+}
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"02.01.2006",
+	"02.01.06",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"02.01.2006 15:04",
+}
+
+// parseDate reads a date or a date-time and returns its calendar date in
+// the location of now as midnight UTC.
+func parseDate(s string, now time.Time) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return dateOf(t.In(now.Location())), true
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return dateOf(t), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseImportance reads an importance from 1 to 5 or a word such as "high".
+func parseImportance(s string) (int, bool) {
+	s = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(s, "!")))
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, n >= 1 && n <= 5
+	}
+
+	switch s {
+	case "highest", "critical", "urgent", "критическая", "срочно":
+		return 5, true
+	case "high", "высокая":
+		return 4, true
+	case "medium", "normal", "средняя", "обычная":
+		return 3, true
+	case "low", "низкая":
+		return 2, true
+	case "lowest", "минимальная":
+		return 1, true
+	}
+	return 0, false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
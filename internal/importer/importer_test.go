@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, moscow)
+
+	tests := []struct {
+		file        string
+		wantFormat  Format
+		wantItems   []Item
+		wantSkipped []Skipped
+	}{
+		{
+			file:       "tasks.ics",
+			wantFormat: FormatICS,
+			wantItems: []Item{
+				{"Подготовить отчёт, черновик", date(2025, 1, 15), 5, domain.FrequencyEveryOtherDay},
+				{"Позвонить в банк", date(2025, 1, 20), DefaultImportance, DefaultFrequency},
+			},
+			wantSkipped: []Skipped{
+				{"Старое", "дедлайн в прошлом"},
+				{"Сделано", "задача уже закрыта"},
+				{"Без срока", "нет дедлайна"},
+			},
+		},
+		{
+			file:       "tasks.csv",
+			wantFormat: FormatCSV,
+			wantItems: []Item{
+				{"Купить билеты", date(2025, 1, 20), 5, domain.FrequencyDaily},
+				{"Отчёт; итоговый", date(2025, 2, 1), 4, domain.FrequencyWeekly},
+			},
+			wantSkipped: []Skipped{
+				{"строка 4", "нет дедлайна"},
+				{"строка 5", "неверная дата: 31.02.2025"},
+				{"строка 6", "неверная важность: 9"},
+				{"строка 7", "неизвестная частота: по четвергам"},
+			},
+		},
+		{
+			file:       "todoist.json",
+			wantFormat: FormatTodoist,
+			wantItems: []Item{
+				{"Buy milk", date(2025, 1, 15), 5, domain.FrequencyDaily},
+				// 21:30 UTC is already the next day in Moscow.
+				{"Write report", date(2025, 1, 21), 2, DefaultFrequency},
+			},
+			wantSkipped: []Skipped{
+				{"Someday", "нет дедлайна"},
+				{"Done already", "задача уже закрыта"},
+			},
+		},
+		{
+			file:       "trello.json",
+			wantFormat: FormatTrello,
+			wantItems: []Item{
+				{"Release 1.2", date(2025, 1, 17), 5, DefaultFrequency},
+				{"Update docs", date(2025, 1, 18), 2, DefaultFrequency},
+			},
+			wantSkipped: []Skipped{
+				{"Shipped", "задача уже закрыта"},
+				{"Archived list", "задача уже закрыта"},
+				{"No due", "нет дедлайна"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Parse(tt.file, data, now)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", got.Format, tt.wantFormat)
+			}
+			if !reflect.DeepEqual(got.Items, tt.wantItems) {
+				t.Errorf("Items = %+v, want %+v", got.Items, tt.wantItems)
+			}
+			if !reflect.DeepEqual(got.Skipped, tt.wantSkipped) {
+				t.Errorf("Skipped = %+v, want %+v", got.Skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     Format
+		wantErr  bool
+	}{
+		{"ics extension", "export.ICS", "", FormatICS, false},
+		{"csv extension", "tasks.csv", "", FormatCSV, false},
+		{"ics content", "document", "BEGIN:VCALENDAR\r\n", FormatICS, false},
+		{"todoist array", "export.json", `[{"content": "a"}]`, FormatTodoist, false},
+		{"todoist backup", "backup.json", `{"items": []}`, FormatTodoist, false},
+		{"trello board", "board.json", `{"name": "b", "cards": []}`, FormatTrello, false},
+		{"unknown json", "data.json", `{"foo": 1}`, "", true},
+		{"broken json", "data.json", `{"cards": `, "", true},
+		{"binary", "photo.jpg", "\xff\xd8\xff", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.filename, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSV_NoDescriptionColumn(t *testing.T) {
+	_, err := Parse("tasks.csv", []byte("deadline,priority\n2025-01-15,3\n"), time.Now())
+	if err == nil {
+		t.Error("Parse() error = nil, want error")
+	}
+}
+
+func TestImportanceFromPriority(t *testing.T) {
+	tests := map[int]int{0: 0, 1: 5, 2: 4, 3: 4, 4: 3, 5: 3, 6: 2, 7: 2, 8: 1, 9: 1, 10: 0}
+	for priority, want := range tests {
+		if got := importanceFromPriority(priority); got != want {
+			t.Errorf("importanceFromPriority(%d) = %d, want %d", priority, got, want)
+		}
+	}
+}
+
+func TestFrequencyFromRRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		want domain.Frequency
+	}{
+		{"FREQ=DAILY", domain.FrequencyDaily},
+		{"FREQ=DAILY;INTERVAL=2", domain.FrequencyEveryOtherDay},
+		{"FREQ=DAILY;INTERVAL=7", domain.FrequencyWeekly},
+		{"FREQ=WEEKLY;BYDAY=MO", domain.FrequencyWeekly},
+		{"FREQ=MONTHLY", domain.FrequencyWeekly},
+		{"FREQ=HOURLY", ""},
+	}
+
+	for _, tt := range tests {
+		if got := frequencyFromRRule(tt.rule); got != tt.want {
+			t.Errorf("frequencyFromRRule(%q) = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
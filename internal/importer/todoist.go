@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+type todoistTask struct {
+	Content     string      `json:"content"`
+	Priority    int         `json:"priority"`
+	Due         *todoistDue `json:"due"`
+	IsCompleted bool        `json:"is_completed"`
+	Checked     flexBool    `json:"checked"`
+	IsDeleted   flexBool    `json:"is_deleted"`
+}
+
+type todoistDue struct {
+	Date        string `json:"date"`
+	Datetime    string `json:"datetime"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+}
+
+// flexBool accepts both true/false and the 0/1 used by older Todoist exports.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	*b = flexBool(bytes.Equal(data, []byte("true")) || bytes.Equal(data, []byte("1")))
+	return nil
+}
+
+// parseTodoist reads either the REST API task list (a JSON array) or a sync
+// backup with an "items" array.
+func parseTodoist(data []byte, now time.Time) (*Result, error) {
+	var tasks []todoistTask
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &tasks); err != nil {
+			return nil, err
+		}
+	} else {
+		var backup struct {
+			Items []todoistTask `json:"items"`
+			Tasks []todoistTask `json:"tasks"`
+		}
+		if err := json.Unmarshal(data, &backup); err != nil {
+			return nil, err
+		}
+		tasks = append(backup.Items, backup.Tasks...)
+	}
+
+	result := &Result{}
+	today := dateOf(now)
+
+	for _, task := range tasks {
+		ref := task.Content
+		if task.IsCompleted || bool(task.Checked) || bool(task.IsDeleted) {
+			result.skip(ref, "задача уже закрыта")
+			continue
+		}
+
+		item := Item{
+			Description: task.Content,
+			Importance:  importanceFromTodoist(task.Priority),
+		}
+
+		if task.Due != nil {
+			due := task.Due.Datetime
+			if due == "" {
+				due = task.Due.Date
+			}
+			deadline, ok := parseDate(due, now)
+			if !ok {
+				result.skip(ref, "неверная дата: "+due)
+				continue
+			}
+			item.Deadline = deadline
+
+			if task.Due.IsRecurring {
+				item.Frequency = parseFrequency(task.Due.String, now)
+			}
+		}
+
+		result.add(ref, item, today)
+	}
+
+	return result, nil
+}
+
+// importanceFromTodoist maps Todoist priorities, where 4 is the most urgent
+// (shown as p1) and 1 is normal, onto importance 5 to 2.
+func importanceFromTodoist(priority int) int {
+	if priority < 1 || priority > 4 {
+		return 0
+	}
+	return priority + 1
+}
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type trelloBoard struct {
+	Cards []trelloCard `json:"cards"`
+	Lists []trelloList `json:"lists"`
+}
+
+type trelloCard struct {
+	Name        string        `json:"name"`
+	Due         string        `json:"due"`
+	DueComplete bool          `json:"dueComplete"`
+	Closed      bool          `json:"closed"`
+	IDList      string        `json:"idList"`
+	Labels      []trelloLabel `json:"labels"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// trelloColors maps label colors onto importance. Trello has no priorities,
+// so the common red-to-green convention is assumed.
+var trelloColors = map[string]int{
+	"red":    5,
+	"orange": 4,
+	"yellow": 3,
+	"green":  2,
+}
+
+// parseTrello reads a board exported as JSON. Cards that are archived,
+// marked done or sit in an archived list are skipped.
+func parseTrello(data []byte, now time.Time) (*Result, error) {
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, err
+	}
+
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		if list.Closed {
+			closedLists[list.ID] = true
+		}
+	}
+
+	result := &Result{}
+	today := dateOf(now)
+
+	for _, card := range board.Cards {
+		ref := card.Name
+		if card.Closed || card.DueComplete || closedLists[card.IDList] {
+			result.skip(ref, "задача уже закрыта")
+			continue
+		}
+
+		item := Item{Description: card.Name}
+
+		if card.Due != "" {
+			deadline, ok := parseDate(card.Due, now)
+			if !ok {
+				result.skip(ref, "неверная дата: "+card.Due)
+				continue
+			}
+			item.Deadline = deadline
+		}
+
+		for _, label := range card.Labels {
+			importance, ok := parseImportance(label.Name)
+			if !ok {
+				importance = trelloColors[label.Color]
+			}
+			item.Importance = max(item.Importance, importance)
+		}
+
+		result.add(ref, item, today)
+	}
+
+	return result, nil
+}
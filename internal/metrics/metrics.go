@@ -0,0 +1,69 @@
+// Package metrics defines the bot's Prometheus metrics. They are registered
+// on the default registry and served by Handler.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reminder_bot"
+
+// Task states reported by the Tasks gauge.
+var TaskStates = []string{"active", "overdue", "completed", "deleted"}
+
+var (
+	RemindersSent = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_sent_total",
+		Help:      "Reminders delivered to users.",
+	})
+
+	RemindersFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_failed_total",
+		Help:      "Reminders that could not be delivered.",
+	})
+
+	SchedulerJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scheduler_job_duration_seconds",
+		Help:      "Duration of scheduler job runs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job"})
+
+	Tasks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tasks",
+		Help:      "Tasks by state.",
+	}, []string{"state"})
+
+	TelegramRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "telegram_request_duration_seconds",
+		Help:      "Latency of Telegram Bot API requests. getUpdates includes the long-poll wait.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"method"})
+
+	TelegramRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_request_errors_total",
+		Help:      "Telegram Bot API requests that failed or returned a non-2xx status.",
+	}, []string{"method"})
+)
+
+// SetTasks replaces the Tasks gauge with counts; states missing from counts
+// are reported as zero.
+func SetTasks(counts map[string]int) {
+	for _, state := range TaskStates {
+		Tasks.WithLabelValues(state).Set(float64(counts[state]))
+	}
+}
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// TelegramTransport wraps next so that every Bot API call is timed and
+// failures are counted, labelled by API method.
+func TelegramTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &telegramTransport{next: next}
+}
+
+type telegramTransport struct {
+	next http.RoundTripper
+}
+
+func (t *telegramTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := telegramMethod(req.URL.Path)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	TelegramRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		TelegramRequestErrors.WithLabelValues(method).Inc()
+	}
+	return resp, err
+}
+
+// telegramMethod extracts the API method from /bot<token>/<method>. Anything
+// else, such as file downloads, is reported as "other" so that neither the
+// token nor file paths end up in labels.
+func telegramMethod(p string) string {
+	dir, method := path.Split(p)
+	if strings.Count(dir, "/") != 2 || !strings.HasPrefix(dir, "/bot") || method == "" {
+		return "other"
+	}
+	return method
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTelegramMethod(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/bot123:abc/sendMessage", "sendMessage"},
+		{"/bot123:abc/getUpdates", "getUpdates"},
+		{"/file/bot123:abc/documents/file_1.csv", "other"},
+		{"/bot123:abc/", "other"},
+		{"/", "other"},
+	}
+
+	for _, tt := range tests {
+		if got := telegramMethod(tt.path); got != tt.want {
+			t.Errorf("telegramMethod(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTelegramTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bot1:x/sendMessage" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: TelegramTransport(nil)}
+	for _, method := range []string{"getMe", "sendMessage"} {
+		resp, err := client.Get(srv.URL + "/bot1:x/" + method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if got := testutil.ToFloat64(TelegramRequestErrors.WithLabelValues("getMe")); got != 0 {
+		t.Errorf("getMe errors = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(TelegramRequestErrors.WithLabelValues("sendMessage")); got != 1 {
+		t.Errorf("sendMessage errors = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(TelegramRequestDuration); got != 2 {
+		t.Errorf("duration series = %d, want 2", got)
+	}
+}
@@ -0,0 +1,259 @@
+// Package quickadd parses one-line task definitions such as
+//
+//	Подготовить отчёт до 15.01 !4 ежедневно #work
+//	Prepare report by 15.01 !4 daily #work
+//
+// into the fields of a task. Anything that is not recognised as a deadline,
+// importance or frequency becomes part of the description.
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type Result struct {
+	Description string
+	// Deadline is a date at midnight UTC, nil when the text has none.
+	Deadline *time.Time
+	// Importance is 0 when the text has none.
+	Importance int
+	// Frequency is empty when the text has none.
+	Frequency domain.Frequency
+	// Tags are the #hashtags found in the text. They are kept in Description as well.
+	Tags []string
+	// Unknown holds tokens that look like quick-add syntax but could not be parsed.
+	Unknown []string
+}
+
+// Complete reports whether every task field was present in the text.
+func (r Result) Complete() bool {
+	return r.Description != "" && r.Deadline != nil && r.Importance != 0 && r.Frequency != ""
+}
+
+var (
+	importanceRe = regexp.MustCompile(`^!(\S+)$`)
+	dayMonthRe   = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{2}|\d{4}))?$`)
+	isoDateRe    = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	tagRe        = regexp.MustCompile(`^#[\p{L}\p{N}_]+$`)
+)
+
+var deadlineKeywords = map[string]bool{
+	"до":  true,
+	"к":   true,
+	"by":  true,
+	"due": true,
+}
+
+var frequencyPhrases = []struct {
+	words     []string
+	frequency domain.Frequency
+}{
+	{[]string{"every", "other", "day"}, domain.FrequencyEveryOtherDay},
+	{[]string{"через", "день"}, domain.FrequencyEveryOtherDay},
+	{[]string{"каждый", "день"}, domain.FrequencyDaily},
+	{[]string{"every", "day"}, domain.FrequencyDaily},
+	{[]string{"раз", "в", "неделю"}, domain.FrequencyWeekly},
+	{[]string{"каждую", "неделю"}, domain.FrequencyWeekly},
+	{[]string{"every", "week"}, domain.FrequencyWeekly},
+	{[]string{"ежедневно"}, domain.FrequencyDaily},
+	{[]string{"daily"}, domain.FrequencyDaily},
+	{[]string{"еженедельно"}, domain.FrequencyWeekly},
+	{[]string{"weekly"}, domain.FrequencyWeekly},
+}
+
+var relativeDays = map[string]int{
+	"сегодня":     0,
+	"today":       0,
+	"завтра":      1,
+	"tomorrow":    1,
+	"послезавтра": 2,
+}
+
+var weekdays = map[string]time.Weekday{
+	"понедельника": time.Monday,
+	"вторника":     time.Tuesday,
+	"среды":        time.Wednesday,
+	"четверга":     time.Thursday,
+	"пятницы":      time.Friday,
+	"субботы":      time.Saturday,
+	"воскресенья":  time.Sunday,
+	"monday":       time.Monday,
+	"tuesday":      time.Tuesday,
+	"wednesday":    time.Wednesday,
+	"thursday":     time.Thursday,
+	"friday":       time.Friday,
+	"saturday":     time.Saturday,
+	"sunday":       time.Sunday,
+}
+
+// Parse extracts task fields from text. now is used to resolve relative and
+// year-less dates and should be in the user's timezone.
+func Parse(text string, now time.Time) Result {
+	var r Result
+	var description []string
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	words := strings.Fields(text)
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		lower := strings.ToLower(strings.TrimRight(word, ",;"))
+
+		if freq, n := matchFrequency(words[i:]); n > 0 {
+			if r.Frequency != "" {
+				r.Unknown = append(r.Unknown, strings.Join(words[i:i+n], " "))
+			} else {
+				r.Frequency = freq
+			}
+			i += n - 1
+			continue
+		}
+
+		if m := importanceRe.FindStringSubmatch(lower); m != nil {
+			importance, err := strconv.Atoi(m[1])
+			if err != nil || importance < 1 || importance > 5 || r.Importance != 0 {
+				r.Unknown = append(r.Unknown, word)
+			} else {
+				r.Importance = importance
+			}
+			continue
+		}
+
+		if deadlineKeywords[lower] && i+1 < len(words) {
+			next := strings.ToLower(strings.TrimRight(words[i+1], ",;"))
+			if date, ok := parseDate(next, today); ok {
+				r.setDeadline(date, word+" "+words[i+1])
+				i++
+				continue
+			}
+			if looksLikeDate(next) {
+				r.Unknown = append(r.Unknown, word+" "+words[i+1])
+				i++
+				continue
+			}
+		}
+
+		if isoDateRe.MatchString(lower) || isFullDayMonthYear(lower) {
+			if date, ok := parseDate(lower, today); ok {
+				r.setDeadline(date, word)
+			} else {
+				r.Unknown = append(r.Unknown, word)
+			}
+			continue
+		}
+
+		if tagRe.MatchString(word) {
+			r.Tags = append(r.Tags, strings.TrimPrefix(word, "#"))
+		}
+
+		description = append(description, word)
+	}
+
+	r.Description = strings.Join(description, " ")
+	return r
+}
+
+// ParseDate parses a date typed on its own, such as "20.01", "завтра" or
+// "до 20.01", into a date at midnight UTC. now should be in the user's
+// timezone.
+func ParseDate(text string, now time.Time) (time.Time, bool) {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 2 && (deadlineKeywords[words[0]] || words[0] == "until") {
+		words = words[1:]
+	}
+	if len(words) != 1 {
+		return time.Time{}, false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return parseDate(words[0], today)
+}
+
+func (r *Result) setDeadline(date time.Time, token string) {
+	if r.Deadline != nil {
+		r.Unknown = append(r.Unknown, token)
+		return
+	}
+	r.Deadline = &date
+}
+
+func matchFrequency(words []string) (domain.Frequency, int) {
+	for _, phrase := range frequencyPhrases {
+		if len(words) < len(phrase.words) {
+			continue
+		}
+		matched := true
+		for j, w := range phrase.words {
+			if strings.ToLower(strings.TrimRight(words[j], ",;")) != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return phrase.frequency, len(phrase.words)
+		}
+	}
+	return "", 0
+}
+
+func parseDate(s string, today time.Time) (time.Time, bool) {
+	if days, ok := relativeDays[s]; ok {
+		return today.AddDate(0, 0, days), true
+	}
+
+	if weekday, ok := weekdays[s]; ok {
+		diff := (int(weekday) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, diff), true
+	}
+
+	if m := isoDateRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return makeDate(year, month, day)
+	}
+
+	if m := dayMonthRe.FindStringSubmatch(s); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+
+		if m[3] != "" {
+			year, _ := strconv.Atoi(m[3])
+			if len(m[3]) == 2 {
+				year += 2000
+			}
+			return makeDate(year, month, day)
+		}
+
+		date, ok := makeDate(today.Year(), month, day)
+		if ok && date.Before(today) {
+			date, ok = makeDate(today.Year()+1, month, day)
+		}
+		return date, ok
+	}
+
+	return time.Time{}, false
+}
+
+func makeDate(year, month, day int) (time.Time, bool) {
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date normalises out-of-range values, e.g. 31.02 becomes 03.03.
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func looksLikeDate(s string) bool {
+	return dayMonthRe.MatchString(s) || isoDateRe.MatchString(s)
+}
+
+func isFullDayMonthYear(s string) bool {
+	m := dayMonthRe.FindStringSubmatch(s)
+	return m != nil && len(m[3]) == 4
+}
@@ -0,0 +1,244 @@
+package quickadd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestParse(t *testing.T) {
+	// Friday
+	now := time.Date(2025, 1, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{
+			name: "russian full syntax",
+			text: "Подготовить отчёт до 15.01 !4 ежедневно #work",
+			want: Result{
+				Description: "Подготовить отчёт #work",
+				Deadline:    date(2025, 1, 15),
+				Importance:  4,
+				Frequency:   domain.FrequencyDaily,
+				Tags:        []string{"work"},
+			},
+		},
+		{
+			name: "english full syntax",
+			text: "Prepare report by 15.01 !4 daily #work",
+			want: Result{
+				Description: "Prepare report #work",
+				Deadline:    date(2025, 1, 15),
+				Importance:  4,
+				Frequency:   domain.FrequencyDaily,
+				Tags:        []string{"work"},
+			},
+		},
+		{
+			name: "description only",
+			text: "Позвонить маме",
+			want: Result{Description: "Позвонить маме"},
+		},
+		{
+			name: "fields in any order",
+			text: "!2 weekly Renew passport due 2025-03-01",
+			want: Result{
+				Description: "Renew passport",
+				Deadline:    date(2025, 3, 1),
+				Importance:  2,
+				Frequency:   domain.FrequencyWeekly,
+			},
+		},
+		{
+			name: "multi-word frequency",
+			text: "Полить цветы через день до завтра",
+			want: Result{
+				Description: "Полить цветы",
+				Deadline:    date(2025, 1, 11),
+				Frequency:   domain.FrequencyEveryOtherDay,
+			},
+		},
+		{
+			name: "english multi-word frequency",
+			text: "Water plants every other day by tomorrow",
+			want: Result{
+				Description: "Water plants",
+				Deadline:    date(2025, 1, 11),
+				Frequency:   domain.FrequencyEveryOtherDay,
+			},
+		},
+		{
+			name: "raz v nedelyu",
+			text: "Отчёт раз в неделю",
+			want: Result{
+				Description: "Отчёт",
+				Frequency:   domain.FrequencyWeekly,
+			},
+		},
+		{
+			name: "year-less date in the past rolls over to next year",
+			text: "Поздравить до 05.01",
+			want: Result{
+				Description: "Поздравить",
+				Deadline:    date(2026, 1, 5),
+			},
+		},
+		{
+			name: "two-digit year",
+			text: "Отпуск до 01.06.25",
+			want: Result{
+				Description: "Отпуск",
+				Deadline:    date(2025, 6, 1),
+			},
+		},
+		{
+			name: "standalone full date",
+			text: "Сдать налоги 30.04.2025",
+			want: Result{
+				Description: "Сдать налоги",
+				Deadline:    date(2025, 4, 30),
+			},
+		},
+		{
+			name: "weekday resolves to the next occurrence",
+			text: "Ship release by monday",
+			want: Result{
+				Description: "Ship release",
+				Deadline:    date(2025, 1, 13),
+			},
+		},
+		{
+			name: "weekday equal to today is today",
+			text: "Демо до пятницы",
+			want: Result{
+				Description: "Демо",
+				Deadline:    date(2025, 1, 10),
+			},
+		},
+		{
+			name: "keyword without a date stays in description",
+			text: "Дойти до магазина",
+			want: Result{Description: "Дойти до магазина"},
+		},
+		{
+			name: "invalid importance is reported",
+			text: "Задача !9",
+			want: Result{
+				Description: "Задача",
+				Unknown:     []string{"!9"},
+			},
+		},
+		{
+			name: "invalid date is reported",
+			text: "Задача до 31.02",
+			want: Result{
+				Description: "Задача",
+				Unknown:     []string{"до 31.02"},
+			},
+		},
+		{
+			name: "duplicate fields are reported",
+			text: "Задача !3 !4 daily weekly",
+			want: Result{
+				Description: "Задача",
+				Importance:  3,
+				Frequency:   domain.FrequencyDaily,
+				Unknown:     []string{"!4", "weekly"},
+			},
+		},
+		{
+			name: "case insensitive",
+			text: "Report BY Tomorrow DAILY",
+			want: Result{
+				Description: "Report",
+				Deadline:    date(2025, 1, 11),
+				Frequency:   domain.FrequencyDaily,
+			},
+		},
+		{
+			name: "trailing punctuation after date",
+			text: "Купить билеты до 20.01, !5",
+			want: Result{
+				Description: "Купить билеты",
+				Deadline:    date(2025, 1, 20),
+				Importance:  5,
+			},
+		},
+		{
+			name: "empty",
+			text: "   ",
+			want: Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.text, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) =\n%+v\nwant\n%+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	// Friday
+	now := time.Date(2025, 1, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		text   string
+		want   *time.Time
+		wantOK bool
+	}{
+		{"20.01", date(2025, 1, 20), true},
+		{"до 20.01", date(2025, 1, 20), true},
+		{"until 2025-02-03", date(2025, 2, 3), true},
+		{"Завтра", date(2025, 1, 11), true},
+		{"понедельника", date(2025, 1, 13), true},
+		{"05.01", date(2026, 1, 5), true},
+		{"31.02", nil, false},
+		{"до", nil, false},
+		{"20.01 21.01", nil, false},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseDate(tt.text, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseDate(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if ok && !got.Equal(*tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.text, got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestResult_Complete(t *testing.T) {
+	full := Result{
+		Description: "Task",
+		Deadline:    date(2025, 1, 15),
+		Importance:  3,
+		Frequency:   domain.FrequencyDaily,
+	}
+	if !full.Complete() {
+		t.Error("Complete() = false for a fully specified result")
+	}
+
+	partial := full
+	partial.Importance = 0
+	if partial.Complete() {
+		t.Error("Complete() = true without importance")
+	}
+}
@@ -0,0 +1,219 @@
+// Package render turns tasks into the HTML messages the bot sends. Layouts
+// are html/template files, one per style, that define the same named
+// templates; labels come from the i18n catalog through the t and n
+// functions, and everything else is escaped by html/template.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+)
+
+// Style is a set of layouts a user can choose in the settings.
+type Style string
+
+const (
+	StyleDetailed Style = "detailed"
+	StyleCompact  Style = "compact"
+)
+
+// DefaultStyle is used for users who have not chosen one and for groups.
+const DefaultStyle = StyleDetailed
+
+// Styles lists the styles in the order they are offered.
+var Styles = []Style{StyleDetailed, StyleCompact}
+
+// ParseStyle maps a stored setting to a style, falling back to DefaultStyle.
+func ParseStyle(s string) Style {
+	for _, style := range Styles {
+		if string(style) == s {
+			return style
+		}
+	}
+	return DefaultStyle
+}
+
+//go:embed templates/*.tmpl
+var files embed.FS
+
+// templates are never executed themselves: execute clones them to bind the
+// language, and html/template cannot clone a template after it has run.
+var templates = parse()
+
+func parse() map[Style]*template.Template {
+	result := make(map[Style]*template.Template, len(Styles))
+	for _, style := range Styles {
+		result[style] = template.Must(template.New(string(style)).
+			Funcs(funcs(i18n.Default)).
+			ParseFS(files, "templates/"+string(style)+".tmpl"))
+	}
+	return result
+}
+
+func funcs(l i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"t": l.T,
+		"n": l.N,
+		"frequency": func(f domain.Frequency) string {
+			if _, ok := domain.ParseFrequency(f.String()); !ok {
+				return f.String()
+			}
+			return l.T("frequency." + f.String())
+		},
+		"stars": func(importance int) string {
+			task := domain.Task{Importance: importance}
+			return task.ImportanceStars()
+		},
+		"date":     func(t time.Time) string { return t.Format("02.01.2006") },
+		"datetime": func(t time.Time) string { return t.Format("02.01.2006 15:04") },
+		"truncate": Truncate,
+		"chain": func(chain []string) string {
+			short := make([]string, len(chain))
+			for i, description := range chain {
+				short[i] = Truncate(description, chainItemLength)
+			}
+			return strings.Join(short, " ← ")
+		},
+	}
+}
+
+// TaskView is what a task card shows. Days and WorkHours are computed when
+// the view is built, so that rendering does not depend on the clock.
+type TaskView struct {
+	Description string
+	Deadline    time.Time
+	Importance  int
+	Frequency   domain.Frequency
+	Days        int
+	WorkHours   int
+	Paused      bool
+	PausedUntil *time.Time
+	// BlockedBy are the chains of tasks the task waits for, each starting
+	// with a task it waits for directly. NewTaskView leaves it empty as it
+	// needs the task's dependency graph.
+	BlockedBy [][]string
+}
+
+// chainItemLength is how much of each task in a dependency chain is shown.
+const chainItemLength = 30
+
+func NewTaskView(task *domain.Task, workHoursPerDay int) TaskView {
+	return TaskView{
+		Description: task.Description,
+		Deadline:    task.Deadline,
+		Importance:  task.Importance,
+		Frequency:   task.Frequency,
+		Days:        task.DaysUntilDeadline(),
+		WorkHours:   task.WorkHoursRemaining(workHoursPerDay),
+		Paused:      task.IsPaused(),
+		PausedUntil: task.PausedUntil,
+	}
+}
+
+// Tone is how insistent a reminder sounds.
+type Tone string
+
+const (
+	ToneNormal Tone = ""
+	ToneFirm   Tone = "firm"
+	ToneUrgent Tone = "urgent"
+)
+
+// Tones lists the tones from the calmest.
+var Tones = []Tone{ToneNormal, ToneFirm, ToneUrgent}
+
+// ReminderView is a reminder: Number of the PerDay reminders due today.
+type ReminderView struct {
+	TaskView
+	Number int
+	PerDay int
+	Tone   Tone
+}
+
+// ItemView is a task in a digest. Completed items show when they were
+// completed instead of the importance.
+type ItemView struct {
+	TaskView
+	Number      int
+	Overdue     bool
+	Completed   bool
+	CompletedAt *time.Time
+}
+
+// DigestView is a page of tasks such as /list or the archive.
+type DigestView struct {
+	Icon    string
+	Title   string
+	Details []string
+	Summary string
+	Items   []ItemView
+	// Sections follow Items under titles of their own.
+	Sections []DigestSection
+	Footer   string
+}
+
+// DigestSection is a titled group of items after a digest's main items,
+// such as the paused tasks in /list.
+type DigestSection struct {
+	Title string
+	Items []ItemView
+}
+
+// ConfirmationView is a task card under a title saying what happened to it.
+type ConfirmationView struct {
+	Title string
+	Task  TaskView
+}
+
+func Task(l i18n.Lang, style Style, view TaskView) string {
+	return execute(l, style, "task", view)
+}
+
+func Reminder(l i18n.Lang, style Style, view ReminderView) string {
+	return execute(l, style, "reminder", view)
+}
+
+func Digest(l i18n.Lang, style Style, view DigestView) string {
+	return execute(l, style, "digest", view)
+}
+
+func Confirmation(l i18n.Lang, style Style, view ConfirmationView) string {
+	return execute(l, style, "confirmation", view)
+}
+
+func execute(l i18n.Lang, style Style, name string, data any) string {
+	tmpl, err := templates[ParseStyle(string(style))].Clone()
+	if err != nil {
+		log.Error().Err(err).Str("template", name).Msg("failed to clone template")
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(funcs(l)).ExecuteTemplate(&buf, name, data); err != nil {
+		log.Error().Err(err).Str("template", name).Str("style", string(style)).Msg("failed to render template")
+	}
+	return buf.String()
+}
+
+// Escape escapes text for messages sent with the HTML parse mode, the same
+// way the templates do.
+func Escape(s string) string {
+	return template.HTMLEscapeString(s)
+}
+
+// Truncate shortens s to n characters, ending it with an ellipsis.
+func Truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
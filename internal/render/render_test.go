@@ -0,0 +1,196 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func sampleTask() TaskView {
+	return TaskView{
+		Description: "Prepare <quarterly> report & slides",
+		Deadline:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		Importance:  4,
+		Frequency:   domain.FrequencyDaily,
+		Days:        5,
+		WorkHours:   40,
+	}
+}
+
+func sampleDigest(l i18n.Lang) DigestView {
+	completedAt := time.Date(2025, 1, 12, 18, 30, 0, 0, time.UTC)
+	long := sampleTask()
+	long.Description = "A task with a description long enough to be cut short in every list style"
+	long.Frequency = domain.FrequencyWeekly
+	long.Importance = 1
+
+	return DigestView{
+		Icon:    "📋",
+		Title:   l.T("list.title"),
+		Details: []string{l.T("list.filter_title.all"), l.T("list.sort_title.deadline")},
+		Summary: l.T("list.summary", 12, 1, 2),
+		Items: []ItemView{
+			{TaskView: sampleTask(), Number: 1},
+			{TaskView: long, Number: 2, Overdue: true},
+			{TaskView: sampleTask(), Number: 3, Completed: true, CompletedAt: &completedAt},
+			{TaskView: sampleTask(), Number: 4, Completed: true},
+		},
+		Footer: l.T("archive.reopen_hint"),
+	}
+}
+
+func sampleSectionDigest(l i18n.Lang) DigestView {
+	blocked := sampleTask()
+	blocked.BlockedBy = [][]string{{"Get the figures from accounting", "Close the books"}}
+	until := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	paused := sampleTask()
+	paused.Paused = true
+	paused.PausedUntil = &until
+	indefinitely := sampleTask()
+	indefinitely.Paused = true
+
+	return DigestView{
+		Icon:    "📋",
+		Title:   l.T("list.title"),
+		Details: []string{l.T("list.filter_title.all"), l.T("list.sort_title.deadline")},
+		Summary: l.T("list.summary", 1, 1, 1),
+		Items:   []ItemView{{TaskView: blocked, Number: 1}},
+		Sections: []DigestSection{{
+			Title: l.T("list.paused_title", 2),
+			Items: []ItemView{{TaskView: paused, Number: 2}, {TaskView: indefinitely, Number: 3}},
+		}},
+	}
+}
+
+// renderAll renders every template of a style with sample data, so that one
+// golden file per style and language covers all of them.
+func renderAll(l i18n.Lang, style Style) []byte {
+	task := sampleTask()
+	unknown := task
+	unknown.Frequency = "monthly"
+	unknown.Days = 1
+	unknown.WorkHours = 1
+	until := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	paused := task
+	paused.Paused = true
+	paused.PausedUntil = &until
+	blocked := task
+	blocked.BlockedBy = [][]string{{"Get the figures from accounting", "Close the books"}, {"Book a room"}}
+
+	sections := []struct {
+		name string
+		text string
+	}{
+		{"task", Task(l, style, task)},
+		{"task with an unknown frequency", Task(l, style, unknown)},
+		{"reminder", Reminder(l, style, ReminderView{TaskView: task, Number: 2, PerDay: 4})},
+		{"reminder (firm)", Reminder(l, style, ReminderView{TaskView: task, Number: 1, PerDay: 3, Tone: ToneFirm})},
+		{"reminder (urgent)", Reminder(l, style, ReminderView{TaskView: task, Number: 5, PerDay: 5, Tone: ToneUrgent})},
+		{"paused task", Task(l, style, paused)},
+		{"blocked task", Task(l, style, blocked)},
+		{"digest", Digest(l, style, sampleDigest(l))},
+		{"digest with blocked and paused tasks", Digest(l, style, sampleSectionDigest(l))},
+		{"confirmation", Confirmation(l, style, ConfirmationView{
+			Title: l.T("assign.received", "@ivan"),
+			Task:  task,
+		})},
+	}
+
+	var buf bytes.Buffer
+	for _, s := range sections {
+		fmt.Fprintf(&buf, "=== %s ===\n%s\n", s.name, s.text)
+	}
+	return buf.Bytes()
+}
+
+func TestTemplates_Golden(t *testing.T) {
+	for _, style := range Styles {
+		for _, l := range i18n.Languages {
+			name := fmt.Sprintf("%s.%s", style, l)
+			t.Run(name, func(t *testing.T) {
+				got := renderAll(l, style)
+
+				golden := filepath.Join("testdata", name+".golden")
+				if *update {
+					if err := os.WriteFile(golden, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("missing golden file: %v (run go test -update)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("output differs from %s:\n%s", golden, got)
+				}
+			})
+		}
+	}
+}
+
+func TestTemplatesDefineEverything(t *testing.T) {
+	for _, style := range Styles {
+		for _, name := range []string{"task", "reminder", "list_item", "digest", "confirmation"} {
+			if templates[style].Lookup(name) == nil {
+				t.Errorf("style %s has no %q template", style, name)
+			}
+		}
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Style
+	}{
+		{"detailed", StyleDetailed},
+		{"compact", StyleCompact},
+		{"", DefaultStyle},
+		{"fancy", DefaultStyle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ParseStyle(tt.input); got != tt.want {
+				t.Errorf("ParseStyle(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input string
+		n     int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"eleven chars", 10, "eleven ch…"},
+		{"Подготовить отчёт", 8, "Подгото…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.n); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if got, want := Escape(`<b>Tom & "Jerry"</b>`), "&lt;b&gt;Tom &amp; &#34;Jerry&#34;&lt;/b&gt;"; got != want {
+		t.Errorf("Escape() = %q, want %q", got, want)
+	}
+}
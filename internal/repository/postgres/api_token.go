@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type APITokenRepository struct {
+	db *DB
+}
+
+func NewAPITokenRepository(db *DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+func (r *APITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	query := `
+		INSERT INTO api_tokens (user_id, token_hash)
+		VALUES ($1, $2)
+		RETURNING id, created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, token.UserID, token.TokenHash).Scan(&token.ID, &token.CreatedAt)
+}
+
+func (r *APITokenRepository) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, created_at, last_used_at
+		FROM api_tokens
+		WHERE token_hash = $1`
+
+	token := &domain.APIToken{}
+	err := r.db.Pool.QueryRow(ctx, query, hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.LastUsedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *APITokenRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.APIToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, created_at, last_used_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.APIToken
+	for rows.Next() {
+		token := &domain.APIToken{}
+		if err := rows.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.CreatedAt, &token.LastUsedAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *APITokenRepository) Touch(ctx context.Context, id int64) error {
+	query := `UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
+
+func (r *APITokenRepository) DeleteByUserID(ctx context.Context, userID int64) (int64, error) {
+	query := `DELETE FROM api_tokens WHERE user_id = $1`
+	tag, err := r.db.Pool.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+const attachmentColumns = `id, task_id, kind, file_id, file_name, caption, url, chat_id, message_id, created_at`
+
+type AttachmentRepository struct {
+	db *DB
+}
+
+func NewAttachmentRepository(db *DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func scanAttachment(row pgx.Row) (*domain.Attachment, error) {
+	a := &domain.Attachment{}
+	err := row.Scan(&a.ID, &a.TaskID, &a.Kind, &a.FileID, &a.FileName, &a.Caption, &a.URL, &a.ChatID, &a.MessageID, &a.CreatedAt)
+	return a, err
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *domain.Attachment) error {
+	query := `
+		INSERT INTO task_attachments (task_id, kind, file_id, file_name, caption, url, chat_id, message_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, a.TaskID, a.Kind, a.FileID, a.FileName, a.Caption, a.URL, a.ChatID, a.MessageID).
+		Scan(&a.ID, &a.CreatedAt)
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id int64) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM task_attachments WHERE id = $1`
+
+	a, err := scanAttachment(r.db.Pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ListByTaskID returns the task's attachments in the order they were added.
+func (r *AttachmentRepository) ListByTaskID(ctx context.Context, taskID int64) ([]*domain.Attachment, error) {
+	return r.list(ctx, `task_id = $1`, taskID)
+}
+
+// ListByUserID returns the attachments of the user's tasks that are not
+// deleted, in the order they were added.
+func (r *AttachmentRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.Attachment, error) {
+	return r.list(ctx, `task_id IN (SELECT id FROM tasks WHERE user_id = $1 AND deleted_at IS NULL)`, userID)
+}
+
+func (r *AttachmentRepository) list(ctx context.Context, where string, arg int64) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM task_attachments
+		WHERE ` + where + `
+		ORDER BY id ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*domain.Attachment
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentRepository) CountByTaskID(ctx context.Context, taskID int64) (int, error) {
+	var count int
+	err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM task_attachments WHERE task_id = $1`, taskID).Scan(&count)
+	return count, err
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM task_attachments WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+const chatColumns = `id, telegram_id, title, timezone, work_hours_per_day, work_start_hour, work_end_hour, language,
+		       left_at, created_at, updated_at`
+
+type ChatRepository struct {
+	db *DB
+}
+
+func NewChatRepository(db *DB) *ChatRepository {
+	return &ChatRepository{db: db}
+}
+
+// scanChat reads a row of chatColumns, returning nil, nil when there is none.
+func scanChat(row pgx.Row) (*domain.Chat, error) {
+	chat := &domain.Chat{}
+	err := row.Scan(
+		&chat.ID,
+		&chat.TelegramID,
+		&chat.Title,
+		&chat.Timezone,
+		&chat.WorkHoursPerDay,
+		&chat.WorkStartHour,
+		&chat.WorkEndHour,
+		&chat.Language,
+		&chat.LeftAt,
+		&chat.CreatedAt,
+		&chat.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// Create registers the chat. If it is already registered, for example by
+// another member at the same moment, the stored row is loaded into chat
+// instead and only the title is refreshed.
+func (r *ChatRepository) Create(ctx context.Context, chat *domain.Chat) error {
+	query := `
+		INSERT INTO chats (telegram_id, title, timezone, work_hours_per_day, work_start_hour, work_end_hour, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (telegram_id) DO UPDATE SET title = EXCLUDED.title, updated_at = NOW()
+		RETURNING ` + chatColumns
+
+	stored, err := scanChat(r.db.Pool.QueryRow(ctx, query,
+		chat.TelegramID,
+		chat.Title,
+		chat.Timezone,
+		chat.WorkHoursPerDay,
+		chat.WorkStartHour,
+		chat.WorkEndHour,
+		chat.Language,
+	))
+	if err != nil {
+		return err
+	}
+
+	*chat = *stored
+	return nil
+}
+
+func (r *ChatRepository) GetByID(ctx context.Context, id int64) (*domain.Chat, error) {
+	query := `SELECT ` + chatColumns + ` FROM chats WHERE id = $1`
+	return scanChat(r.db.Pool.QueryRow(ctx, query, id))
+}
+
+func (r *ChatRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.Chat, error) {
+	query := `SELECT ` + chatColumns + ` FROM chats WHERE telegram_id = $1`
+	return scanChat(r.db.Pool.QueryRow(ctx, query, telegramID))
+}
+
+// SetLeft records that the bot was removed from the chat, or clears the
+// mark when it is added back.
+func (r *ChatRepository) SetLeft(ctx context.Context, id int64, left bool) error {
+	query := `
+		UPDATE chats
+		SET left_at = CASE WHEN $2 THEN COALESCE(left_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id, left)
+	return err
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type DependencyRepository struct {
+	db *DB
+}
+
+func NewDependencyRepository(db *DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+func (r *DependencyRepository) Create(ctx context.Context, dep *domain.TaskDependency) error {
+	query := `
+		INSERT INTO task_dependencies (task_id, blocker_id)
+		VALUES ($1, $2)
+		ON CONFLICT (task_id, blocker_id) DO UPDATE SET task_id = EXCLUDED.task_id
+		RETURNING created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, dep.TaskID, dep.BlockerID).Scan(&dep.CreatedAt)
+}
+
+func (r *DependencyRepository) Delete(ctx context.Context, taskID, blockerID int64) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2`
+	_, err := r.db.Pool.Exec(ctx, query, taskID, blockerID)
+	return err
+}
+
+// ListByUserID returns the dependencies between the user's personal tasks.
+func (r *DependencyRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.TaskDependency, error) {
+	return r.list(ctx, `t.user_id = $1 AND t.chat_id IS NULL`, userID)
+}
+
+// ListByChatID returns the dependencies between the shared tasks of a group
+// chat.
+func (r *DependencyRepository) ListByChatID(ctx context.Context, chatID int64) ([]*domain.TaskDependency, error) {
+	return r.list(ctx, `t.chat_id = $1`, chatID)
+}
+
+func (r *DependencyRepository) list(ctx context.Context, owner string, ownerID int64) ([]*domain.TaskDependency, error) {
+	query := `
+		SELECT d.task_id, d.blocker_id, d.created_at
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE ` + owner + `
+		ORDER BY d.created_at ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var deps []*domain.TaskDependency
+	for rows.Next() {
+		dep := &domain.TaskDependency{}
+		if err := rows.Scan(&dep.TaskID, &dep.BlockerID, &dep.CreatedAt); err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+
+	return deps, rows.Err()
+}
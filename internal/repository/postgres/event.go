@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type EventRepository struct {
+	db *DB
+}
+
+func NewEventRepository(db *DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+func (r *EventRepository) Create(ctx context.Context, event *domain.TaskEvent) error {
+	query := `
+		INSERT INTO task_events (task_id, user_id, chat_id, type, importance, deadline)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	return r.db.Pool.QueryRow(ctx, query,
+		event.TaskID,
+		event.UserID,
+		event.ChatID,
+		event.Type,
+		event.Importance,
+		event.Deadline,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (r *EventRepository) ListByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.TaskEvent, error) {
+	query := `
+		SELECT id, task_id, user_id, chat_id, type, importance, deadline, created_at
+		FROM task_events
+		WHERE user_id = $1 AND created_at >= $2
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.TaskEvent
+	for rows.Next() {
+		event := &domain.TaskEvent{}
+		var eventType string
+		err := rows.Scan(
+			&event.ID,
+			&event.TaskID,
+			&event.UserID,
+			&event.ChatID,
+			&eventType,
+			&event.Importance,
+			&event.Deadline,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.Type = domain.TaskEventType(eventType)
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
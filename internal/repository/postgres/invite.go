@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type InviteRepository struct {
+	db *DB
+}
+
+func NewInviteRepository(db *DB) *InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+func (r *InviteRepository) Create(ctx context.Context, invite *domain.Invite) error {
+	query := `
+		INSERT INTO invite_codes (code, created_by, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, invite.Code, invite.CreatedBy, invite.ExpiresAt).Scan(&invite.CreatedAt)
+}
+
+// Redeem marks an unused, unexpired code as used by telegramID. It reports
+// whether the code was valid.
+func (r *InviteRepository) Redeem(ctx context.Context, code string, telegramID int64) (bool, error) {
+	query := `
+		UPDATE invite_codes
+		SET used_by = $2, used_at = NOW()
+		WHERE code = $1 AND used_at IS NULL AND expires_at > NOW()`
+
+	tag, err := r.db.Pool.Exec(ctx, query, code, telegramID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Forget clears telegramID from the codes it created or redeemed.
+func (r *InviteRepository) Forget(ctx context.Context, telegramID int64) error {
+	query := `
+		UPDATE invite_codes
+		SET created_by = CASE WHEN created_by = $1 THEN NULL ELSE created_by END,
+		    used_by = CASE WHEN used_by = $1 THEN NULL ELSE used_by END
+		WHERE created_by = $1 OR used_by = $1`
+
+	_, err := r.db.Pool.Exec(ctx, query, telegramID)
+	return err
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"telegram-reminder-bot/internal/tracing"
 )
 
 type DB struct {
@@ -12,7 +14,13 @@ type DB struct {
 }
 
 func New(ctx context.Context, databaseURL string) (*DB, error) {
-	pool, err := pgxpool.New(ctx, databaseURL)
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database url: %w", err)
+	}
+	config.ConnConfig.Tracer = tracing.QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -30,6 +38,10 @@ func New(ctx context.Context, databaseURL string) (*DB, error) {
 	return db, nil
 }
 
+func (db *DB) Ping(ctx context.Context) error {
+	return db.Pool.Ping(ctx)
+}
+
 func (db *DB) runMigrations(ctx context.Context) error {
 	migration := `
 CREATE TABLE IF NOT EXISTS users (
@@ -63,6 +75,177 @@ CREATE TABLE IF NOT EXISTS tasks (
 CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks(user_id);
 CREATE INDEX IF NOT EXISTS idx_tasks_deadline ON tasks(deadline);
 CREATE INDEX IF NOT EXISTS idx_tasks_active ON tasks(user_id, is_completed, deadline) WHERE is_completed = FALSE;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_completed ON tasks(user_id, completed_at DESC) WHERE is_completed = TRUE;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS task_events (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    importance INT NOT NULL,
+    deadline DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_user ON task_events(user_id, created_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token VARCHAR(64) UNIQUE;
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user ON api_tokens(user_id);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user ON webhooks(user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL,
+    task_id BIGINT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS invite_codes (
+    code VARCHAR(64) PRIMARY KEY,
+    created_by BIGINT NOT NULL,
+    used_by BIGINT,
+    used_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS chats (
+    id BIGSERIAL PRIMARY KEY,
+    telegram_id BIGINT UNIQUE NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    timezone VARCHAR(50) DEFAULT 'Europe/Moscow',
+    work_hours_per_day INT DEFAULT 8,
+    work_start_hour INT DEFAULT 9,
+    work_end_hour INT DEFAULT 18,
+    left_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_by VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_chat_id ON tasks(chat_id);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assigned_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignment_status VARCHAR(16) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_users_username ON users(LOWER(username));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS message_style VARCHAR(16) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS reminders (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    outcome VARCHAR(16) NOT NULL DEFAULT 'pending',
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_user_sent ON reminders(user_id, sent_at);
+CREATE INDEX IF NOT EXISTS idx_reminders_task_pending ON reminders(task_id) WHERE outcome = 'pending';
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS quiet_periods (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_minute INT NOT NULL,
+    end_minute INT NOT NULL,
+    weekdays SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiet_periods_user_id ON quiet_periods(user_id);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS dnd_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS quiet_mode VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deferred BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS paused_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS paused_until DATE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_paused_until ON tasks(paused_until) WHERE paused_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocker_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    file_id TEXT NOT NULL DEFAULT '',
+    file_name TEXT NOT NULL DEFAULT '',
+    caption TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    chat_id BIGINT NOT NULL DEFAULT 0,
+    message_id INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments(task_id);
+
+ALTER TABLE tasks ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE task_events ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE reminders ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE invite_codes ALTER COLUMN created_by DROP NOT NULL;
+
+ALTER TABLE task_events ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+UPDATE task_events e SET chat_id = t.chat_id FROM tasks t WHERE e.task_id = t.id AND t.chat_id IS NOT NULL AND e.chat_id IS NULL;
+
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+UPDATE reminders r SET chat_id = t.chat_id FROM tasks t WHERE r.task_id = t.id AND t.chat_id IS NOT NULL AND r.chat_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reminders_chat_sent ON reminders(chat_id, sent_at) WHERE chat_id IS NOT NULL;
 `
 
 	_, err := db.Pool.Exec(ctx, migration)
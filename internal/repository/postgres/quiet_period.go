@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type QuietPeriodRepository struct {
+	db *DB
+}
+
+func NewQuietPeriodRepository(db *DB) *QuietPeriodRepository {
+	return &QuietPeriodRepository{db: db}
+}
+
+func (r *QuietPeriodRepository) Create(ctx context.Context, period *domain.QuietPeriod) error {
+	query := `
+		INSERT INTO quiet_periods (user_id, start_minute, end_minute, weekdays)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, period.UserID, period.Start, period.End, period.Weekdays).
+		Scan(&period.ID, &period.CreatedAt)
+}
+
+func (r *QuietPeriodRepository) GetByID(ctx context.Context, id int64) (*domain.QuietPeriod, error) {
+	query := `
+		SELECT id, user_id, start_minute, end_minute, weekdays, created_at
+		FROM quiet_periods
+		WHERE id = $1`
+
+	period := &domain.QuietPeriod{}
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&period.ID,
+		&period.UserID,
+		&period.Start,
+		&period.End,
+		&period.Weekdays,
+		&period.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+// ListByUserID returns the user's quiet periods by time of day.
+func (r *QuietPeriodRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.QuietPeriod, error) {
+	query := `
+		SELECT id, user_id, start_minute, end_minute, weekdays, created_at
+		FROM quiet_periods
+		WHERE user_id = $1
+		ORDER BY start_minute ASC, id ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []*domain.QuietPeriod
+	for rows.Next() {
+		period := &domain.QuietPeriod{}
+		if err := rows.Scan(&period.ID, &period.UserID, &period.Start, &period.End, &period.Weekdays, &period.CreatedAt); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+func (r *QuietPeriodRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM quiet_periods WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
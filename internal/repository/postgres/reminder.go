@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+type ReminderRepository struct {
+	db *DB
+}
+
+func NewReminderRepository(db *DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	query := `
+		INSERT INTO reminders (task_id, user_id, chat_id, number, outcome)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+		RETURNING id, sent_at`
+
+	return r.db.Pool.QueryRow(ctx, query,
+		reminder.TaskID,
+		reminder.UserID,
+		reminder.ChatID,
+		reminder.Number,
+		reminder.Outcome,
+	).Scan(&reminder.ID, &reminder.SentAt)
+}
+
+func (r *ReminderRepository) Respond(ctx context.Context, taskID int64, outcome domain.ReminderOutcome) error {
+	query := `
+		UPDATE reminders
+		SET outcome = $2, responded_at = NOW()
+		WHERE id = (
+			SELECT id FROM reminders
+			WHERE task_id = $1 AND outcome = 'pending'
+			ORDER BY sent_at DESC, id DESC
+			LIMIT 1
+		)`
+
+	_, err := r.db.Pool.Exec(ctx, query, taskID, outcome)
+	return err
+}
+
+func (r *ReminderRepository) IgnorePending(ctx context.Context, taskID int64, before time.Time) error {
+	query := `
+		UPDATE reminders
+		SET outcome = 'ignored'
+		WHERE outcome = 'pending' AND sent_at < $2 AND ($1::bigint = 0 OR task_id = $1::bigint)`
+
+	_, err := r.db.Pool.Exec(ctx, query, taskID, before)
+	return err
+}
+
+func (r *ReminderRepository) ListByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.Reminder, error) {
+	query := `
+		SELECT id, task_id, COALESCE(user_id, 0), chat_id, number, outcome, sent_at, responded_at
+		FROM reminders
+		WHERE user_id = $1 AND chat_id IS NULL AND sent_at >= $2
+		ORDER BY sent_at ASC, id ASC`
+
+	return r.list(ctx, query, userID, since)
+}
+
+func (r *ReminderRepository) ListByChatID(ctx context.Context, chatID int64, since time.Time) ([]*domain.Reminder, error) {
+	query := `
+		SELECT id, task_id, COALESCE(user_id, 0), chat_id, number, outcome, sent_at, responded_at
+		FROM reminders
+		WHERE chat_id = $1 AND sent_at >= $2
+		ORDER BY sent_at ASC, id ASC`
+
+	return r.list(ctx, query, chatID, since)
+}
+
+func (r *ReminderRepository) list(ctx context.Context, query string, args ...any) ([]*domain.Reminder, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []*domain.Reminder
+	for rows.Next() {
+		reminder := &domain.Reminder{}
+		var outcome string
+		err := rows.Scan(
+			&reminder.ID,
+			&reminder.TaskID,
+			&reminder.UserID,
+			&reminder.ChatID,
+			&reminder.Number,
+			&outcome,
+			&reminder.SentAt,
+			&reminder.RespondedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reminder.Outcome = domain.ReminderOutcome(outcome)
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+func (r *ReminderRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM reminders WHERE sent_at < $1`
+	tag, err := r.db.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
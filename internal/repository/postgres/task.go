@@ -3,12 +3,25 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	"telegram-reminder-bot/internal/domain"
 )
 
+// user_id is NULL for a shared task whose creator deleted their account; it
+// is read as 0.
+const taskColumns = `id, COALESCE(user_id, 0), chat_id, assigned_by, assignment_status, description, deadline, importance, frequency, is_completed,
+		       last_reminder_date, reminders_sent_today, completed_at, completed_by, deleted_at, overdue_at, snoozed_until, deferred, paused_at, paused_until, created_at, updated_at`
+
+// hasActiveBlocker is true for a task that waits for a task that is neither
+// completed nor deleted.
+const hasActiveBlocker = `EXISTS (
+		SELECT 1 FROM task_dependencies d JOIN tasks b ON b.id = d.blocker_id
+		WHERE d.task_id = tasks.id AND b.is_completed = false AND b.deleted_at IS NULL)`
+
 type TaskRepository struct {
 	db *DB
 }
@@ -17,14 +30,67 @@ func NewTaskRepository(db *DB) *TaskRepository {
 	return &TaskRepository{db: db}
 }
 
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	task := &domain.Task{}
+	var freq, assignment string
+	err := row.Scan(
+		&task.ID,
+		&task.UserID,
+		&task.ChatID,
+		&task.AssignedBy,
+		&assignment,
+		&task.Description,
+		&task.Deadline,
+		&task.Importance,
+		&freq,
+		&task.IsCompleted,
+		&task.LastReminderDate,
+		&task.RemindersSentToday,
+		&task.CompletedAt,
+		&task.CompletedBy,
+		&task.DeletedAt,
+		&task.OverdueAt,
+		&task.SnoozedUntil,
+		&task.Deferred,
+		&task.PausedAt,
+		&task.PausedUntil,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	task.Frequency = domain.Frequency(freq)
+	task.AssignmentStatus = domain.AssignmentStatus(assignment)
+	return task, nil
+}
+
+func collectTasks(rows pgx.Rows) ([]*domain.Task, error) {
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
 func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 	query := `
-		INSERT INTO tasks (user_id, description, deadline, importance, frequency)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO tasks (user_id, chat_id, assigned_by, assignment_status, description, deadline, importance, frequency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at`
 
 	return r.db.Pool.QueryRow(ctx, query,
 		task.UserID,
+		task.ChatID,
+		task.AssignedBy,
+		task.AssignmentStatus,
 		task.Description,
 		task.Deadline,
 		task.Importance,
@@ -34,28 +100,11 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 
 func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
 	query := `
-		SELECT id, user_id, description, deadline, importance, frequency, is_completed,
-		       last_reminder_date, reminders_sent_today, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE id = $1`
-
-	task := &domain.Task{}
-	var freq string
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
-		&task.ID,
-		&task.UserID,
-		&task.Description,
-		&task.Deadline,
-		&task.Importance,
-		&freq,
-		&task.IsCompleted,
-		&task.LastReminderDate,
-		&task.RemindersSentToday,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-	)
-	task.Frequency = domain.Frequency(freq)
+		WHERE id = $1 AND deleted_at IS NULL`
 
+	task, err := scanTask(r.db.Pool.QueryRow(ctx, query, id))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -66,92 +115,219 @@ func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, e
 	return task, nil
 }
 
+// GetActiveByChatID returns all active shared tasks of a group chat.
+func (r *TaskRepository) GetActiveByChatID(ctx context.Context, chatID int64) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE chat_id = $1 AND is_completed = false AND deleted_at IS NULL
+		ORDER BY deadline ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTasks(rows)
+}
+
 func (r *TaskRepository) GetActiveByUserID(ctx context.Context, userID int64) ([]*domain.Task, error) {
 	query := `
-		SELECT id, user_id, description, deadline, importance, frequency, is_completed,
-		       last_reminder_date, reminders_sent_today, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE user_id = $1 AND is_completed = false
+		WHERE user_id = $1 AND chat_id IS NULL AND is_completed = false AND deleted_at IS NULL
 		ORDER BY deadline ASC`
 
 	rows, err := r.db.Pool.Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var tasks []*domain.Task
-	for rows.Next() {
-		task := &domain.Task{}
-		var freq string
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Description,
-			&task.Deadline,
-			&task.Importance,
-			&freq,
-			&task.IsCompleted,
-			&task.LastReminderDate,
-			&task.RemindersSentToday,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		task.Frequency = domain.Frequency(freq)
-		tasks = append(tasks, task)
+	return collectTasks(rows)
+}
+
+// ListByUserID returns all of the user's tasks, active and completed.
+func (r *TaskRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return tasks, rows.Err()
+	return collectTasks(rows)
+}
+
+// ListActiveByUserID returns one page of the user's personal active tasks
+// together with the total number of tasks matching the filter.
+func (r *TaskRepository) ListActiveByUserID(ctx context.Context, userID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	return r.listActive(ctx, `user_id = $1 AND chat_id IS NULL`, userID, opts)
+}
+
+// ListActiveByChatID is ListActiveByUserID for the shared tasks of a group
+// chat.
+func (r *TaskRepository) ListActiveByChatID(ctx context.Context, chatID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	return r.listActive(ctx, `chat_id = $1`, chatID, opts)
+}
+
+func (r *TaskRepository) listActive(ctx context.Context, owner string, ownerID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	where := owner + ` AND is_completed = false AND deleted_at IS NULL`
+	args := []any{ownerID}
+
+	switch opts.Paused {
+	case domain.PausedExclude:
+		where += ` AND paused_at IS NULL`
+	case domain.PausedOnly:
+		where += ` AND paused_at IS NOT NULL`
+	}
+
+	switch opts.Filter {
+	case domain.TaskFilterToday:
+		where += ` AND deadline = $2`
+		args = append(args, opts.Today)
+	case domain.TaskFilterWeek:
+		where += ` AND deadline BETWEEN $2 AND $2::date + 6`
+		args = append(args, opts.Today)
+	case domain.TaskFilterOverdue:
+		where += ` AND deadline < $2`
+		args = append(args, opts.Today)
+	}
+
+	var total int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM tasks WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := `deadline ASC, importance DESC, id ASC`
+	switch opts.Sort {
+	case domain.TaskSortImportance:
+		orderBy = `importance DESC, deadline ASC, id ASC`
+	case domain.TaskSortCreated:
+		orderBy = `created_at DESC, id DESC`
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, where, orderBy, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Pool.Query(ctx, query, append(args, opts.Limit, opts.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := collectTasks(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, total, nil
+}
+
+// ListCompletedByUserID returns one page of the user's personal completed tasks,
+// most recently completed first, together with the total count.
+func (r *TaskRepository) ListCompletedByUserID(ctx context.Context, userID int64, limit, offset int) ([]*domain.Task, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND chat_id IS NULL AND is_completed = true AND deleted_at IS NULL`
+	if err := r.db.Pool.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE user_id = $1 AND chat_id IS NULL AND is_completed = true AND deleted_at IS NULL
+		ORDER BY completed_at DESC NULLS LAST, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := collectTasks(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, total, nil
 }
 
 func (r *TaskRepository) GetTasksForReminder(ctx context.Context) ([]*domain.Task, error) {
 	query := `
-		SELECT id, user_id, description, deadline, importance, frequency, is_completed,
-		       last_reminder_date, reminders_sent_today, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE is_completed = false AND deadline >= CURRENT_DATE`
+		WHERE is_completed = false AND deleted_at IS NULL AND paused_at IS NULL AND deadline >= CURRENT_DATE
+		  AND NOT ` + hasActiveBlocker
 
 	rows, err := r.db.Pool.Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var tasks []*domain.Task
-	for rows.Next() {
-		task := &domain.Task{}
-		var freq string
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Description,
-			&task.Deadline,
-			&task.Importance,
-			&freq,
-			&task.IsCompleted,
-			&task.LastReminderDate,
-			&task.RemindersSentToday,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		task.Frequency = domain.Frequency(freq)
-		tasks = append(tasks, task)
+	return collectTasks(rows)
+}
+
+// ListOverdue returns active tasks with a deadline before the given date
+// that have not been marked overdue yet.
+func (r *TaskRepository) ListOverdue(ctx context.Context, before time.Time) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE is_completed = false AND deleted_at IS NULL AND overdue_at IS NULL AND deadline < $1`
+
+	rows, err := r.db.Pool.Query(ctx, query, before)
+	if err != nil {
+		return nil, err
 	}
 
-	return tasks, rows.Err()
+	return collectTasks(rows)
+}
+
+// ListPausedUntil returns active tasks paused until a date on or before the
+// given one.
+func (r *TaskRepository) ListPausedUntil(ctx context.Context, date time.Time) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE is_completed = false AND deleted_at IS NULL AND paused_at IS NOT NULL AND paused_until <= $1`
+
+	rows, err := r.db.Pool.Query(ctx, query, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTasks(rows)
+}
+
+func (r *TaskRepository) ListReadyDependents(ctx context.Context, blockerID int64) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id IN (SELECT task_id FROM task_dependencies WHERE blocker_id = $1)
+		  AND is_completed = false AND deleted_at IS NULL AND NOT ` + hasActiveBlocker
+
+	rows, err := r.db.Pool.Query(ctx, query, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectTasks(rows)
 }
 
 func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	query := `
 		UPDATE tasks
 		SET description = $2, deadline = $3, importance = $4, frequency = $5,
-		    is_completed = $6, last_reminder_date = $7, reminders_sent_today = $8, updated_at = NOW()
+		    is_completed = $6, last_reminder_date = $7, reminders_sent_today = $8, completed_at = $9,
+		    completed_by = $10, overdue_at = $11, user_id = NULLIF($12, 0), assignment_status = $13,
+		    snoozed_until = $14, deferred = $15, paused_at = $16, paused_until = $17, updated_at = NOW()
 		WHERE id = $1`
 
 	_, err := r.db.Pool.Exec(ctx, query,
@@ -163,6 +339,15 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		task.IsCompleted,
 		task.LastReminderDate,
 		task.RemindersSentToday,
+		task.CompletedAt,
+		task.CompletedBy,
+		task.OverdueAt,
+		task.UserID,
+		task.AssignmentStatus,
+		task.SnoozedUntil,
+		task.Deferred,
+		task.PausedAt,
+		task.PausedUntil,
 	)
 	return err
 }
@@ -173,8 +358,162 @@ func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
 	return err
 }
 
+func (r *TaskRepository) SoftDelete(ctx context.Context, id int64) error {
+	query := `UPDATE tasks SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
+
+// GetDeletedByID returns a task soft-deleted at or after since, or nil when
+// there is none.
+func (r *TaskRepository) GetDeletedByID(ctx context.Context, id int64, since time.Time) (*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = $1 AND deleted_at >= $2`
+
+	task, err := scanTask(r.db.Pool.QueryRow(ctx, query, id, since))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Restore undoes SoftDelete for a shared task of chatID, or a personal task
+// of userID when chatID is nil, deleted at or after since. It reports
+// whether the task was restored.
+func (r *TaskRepository) Restore(ctx context.Context, id, userID int64, chatID *int64, since time.Time) (bool, error) {
+	query := `
+		UPDATE tasks SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at >= $2
+		  AND CASE WHEN $4::bigint IS NULL THEN chat_id IS NULL AND user_id = $3 ELSE chat_id = $4 END`
+	tag, err := r.db.Pool.Exec(ctx, query, id, since, userID, chatID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteSoftDeletedBefore permanently removes tasks soft-deleted before the
+// given time and returns the number of removed rows.
+func (r *TaskRepository) DeleteSoftDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM tasks WHERE deleted_at < $1`
+	tag, err := r.db.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteCompletedBefore removes tasks completed before the given time and
+// returns the number of removed rows.
+func (r *TaskRepository) DeleteCompletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM tasks WHERE is_completed = true AND completed_at < $1`
+	tag, err := r.db.Pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *TaskRepository) CountByState(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT CASE
+		           WHEN deleted_at IS NOT NULL THEN 'deleted'
+		           WHEN is_completed THEN 'completed'
+		           WHEN overdue_at IS NOT NULL THEN 'overdue'
+		           ELSE 'active'
+		       END AS state,
+		       COUNT(*)
+		FROM tasks
+		GROUP BY state`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var state string
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, err
+		}
+		counts[state] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (r *TaskRepository) ResetDailyReminders(ctx context.Context) error {
 	query := `UPDATE tasks SET reminders_sent_today = 0, last_reminder_date = NULL WHERE is_completed = false`
 	_, err := r.db.Pool.Exec(ctx, query)
 	return err
 }
+
+// The scheduler changes tasks loaded at the start of its run with the
+// targeted updates below rather than Update, so that it cannot undo a
+// completion, pause or reassignment made meanwhile.
+
+// RecordReminderSent counts a reminder sent at the given time and ends any
+// snooze or deferral.
+func (r *TaskRepository) RecordReminderSent(ctx context.Context, id int64, at time.Time) error {
+	query := `
+		UPDATE tasks
+		SET reminders_sent_today = reminders_sent_today + 1, last_reminder_date = $2,
+		    snoozed_until = NULL, deferred = false, updated_at = NOW()
+		WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id, at)
+	return err
+}
+
+// Snooze holds the task's reminders back until the given time; deferred
+// tells a deferral by quiet hours from a snooze by the user.
+func (r *TaskRepository) Snooze(ctx context.Context, id int64, until time.Time, deferred bool) error {
+	query := `UPDATE tasks SET snoozed_until = $2, deferred = $3, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id, until, deferred)
+	return err
+}
+
+// MarkOverdue records when the deadline of an active task was found to have
+// passed. It reports false if the task was already marked or is completed.
+func (r *TaskRepository) MarkOverdue(ctx context.Context, id int64, at time.Time) (bool, error) {
+	query := `
+		UPDATE tasks SET overdue_at = $2, updated_at = NOW()
+		WHERE id = $1 AND overdue_at IS NULL AND is_completed = false`
+	tag, err := r.db.Pool.Exec(ctx, query, id, at)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetPaused pauses the task at the given time until a date, or resumes it
+// when at is nil.
+func (r *TaskRepository) SetPaused(ctx context.Context, id int64, at, until *time.Time) error {
+	query := `UPDATE tasks SET paused_at = $2, paused_until = $3, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id, at, until)
+	return err
+}
+
+// DetachShared clears the creator of the user's shared group tasks, so that
+// they stay with the group when the user's account is deleted.
+func (r *TaskRepository) DetachShared(ctx context.Context, userID int64) error {
+	query := `UPDATE tasks SET user_id = NULL, updated_at = NOW() WHERE user_id = $1 AND chat_id IS NOT NULL`
+	_, err := r.db.Pool.Exec(ctx, query, userID)
+	return err
+}
+
+// ReleaseDeferred ends the deferral of the user's tasks deferred by quiet
+// hours, so that the scheduler decides about them afresh.
+func (r *TaskRepository) ReleaseDeferred(ctx context.Context, userID int64) error {
+	query := `UPDATE tasks SET snoozed_until = NOW(), updated_at = NOW() WHERE user_id = $1 AND deferred AND snoozed_until > NOW()`
+	_, err := r.db.Pool.Exec(ctx, query, userID)
+	return err
+}
@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	"telegram-reminder-bot/internal/domain"
 )
 
+const userColumns = `id, telegram_id, username, timezone, work_hours_per_day, work_start_hour, work_end_hour,
+		       COALESCE(calendar_token, ''), language, message_style, dnd_until, quiet_mode, banned_at, created_at, updated_at`
+
 type UserRepository struct {
 	db *DB
 }
@@ -17,30 +21,10 @@ func NewUserRepository(db *DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
-	query := `
-		INSERT INTO users (telegram_id, username, timezone, work_hours_per_day, work_start_hour, work_end_hour)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at`
-
-	return r.db.Pool.QueryRow(ctx, query,
-		user.TelegramID,
-		user.Username,
-		user.Timezone,
-		user.WorkHoursPerDay,
-		user.WorkStartHour,
-		user.WorkEndHour,
-	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
-}
-
-func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
-	query := `
-		SELECT id, telegram_id, username, timezone, work_hours_per_day, work_start_hour, work_end_hour, created_at, updated_at
-		FROM users
-		WHERE id = $1`
-
+// scanUser reads a row of userColumns, returning nil, nil when there is none.
+func scanUser(row pgx.Row) (*domain.User, error) {
 	user := &domain.User{}
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.TelegramID,
 		&user.Username,
@@ -48,6 +32,12 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
 		&user.WorkHoursPerDay,
 		&user.WorkStartHour,
 		&user.WorkEndHour,
+		&user.CalendarToken,
+		&user.Language,
+		&user.MessageStyle,
+		&user.DNDUntil,
+		&user.QuietMode,
+		&user.BannedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -62,39 +52,46 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
 	return user, nil
 }
 
-func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error) {
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		SELECT id, telegram_id, username, timezone, work_hours_per_day, work_start_hour, work_end_hour, created_at, updated_at
-		FROM users
-		WHERE telegram_id = $1`
+		INSERT INTO users (telegram_id, username, timezone, work_hours_per_day, work_start_hour, work_end_hour, language, message_style)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
 
-	user := &domain.User{}
-	err := r.db.Pool.QueryRow(ctx, query, telegramID).Scan(
-		&user.ID,
-		&user.TelegramID,
-		&user.Username,
-		&user.Timezone,
-		&user.WorkHoursPerDay,
-		&user.WorkStartHour,
-		&user.WorkEndHour,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	return r.db.Pool.QueryRow(ctx, query,
+		user.TelegramID,
+		user.Username,
+		user.Timezone,
+		user.WorkHoursPerDay,
+		user.WorkStartHour,
+		user.WorkEndHour,
+		user.Language,
+		user.MessageStyle,
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+}
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	return scanUser(r.db.Pool.QueryRow(ctx, query, id))
+}
 
-	return user, nil
+func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE telegram_id = $1`
+	return scanUser(r.db.Pool.QueryRow(ctx, query, telegramID))
+}
+
+// GetByUsername finds a user by Telegram username, ignoring case. Usernames
+// can move between accounts, so the most recently updated match wins.
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(username) = LOWER($1) ORDER BY updated_at DESC LIMIT 1`
+	return scanUser(r.db.Pool.QueryRow(ctx, query, username))
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET username = $2, timezone = $3, work_hours_per_day = $4, work_start_hour = $5, work_end_hour = $6, updated_at = NOW()
+		SET username = $2, timezone = $3, work_hours_per_day = $4, work_start_hour = $5, work_end_hour = $6, language = $7,
+		    message_style = $8, dnd_until = $9, quiet_mode = $10, updated_at = NOW()
 		WHERE id = $1`
 
 	_, err := r.db.Pool.Exec(ctx, query,
@@ -104,6 +101,62 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		user.WorkHoursPerDay,
 		user.WorkStartHour,
 		user.WorkEndHour,
+		user.Language,
+		user.MessageStyle,
+		user.DNDUntil,
+		user.QuietMode,
 	)
 	return err
 }
+
+func (r *UserRepository) GetByCalendarToken(ctx context.Context, token string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE calendar_token = $1`
+	return scanUser(r.db.Pool.QueryRow(ctx, query, token))
+}
+
+func (r *UserRepository) SetCalendarToken(ctx context.Context, userID int64, token string) error {
+	query := `UPDATE users SET calendar_token = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, userID, token)
+	return err
+}
+
+// SetBanned blocks or unblocks the user.
+func (r *UserRepository) SetBanned(ctx context.Context, userID int64, banned bool) error {
+	query := `
+		UPDATE users
+		SET banned_at = CASE WHEN $2 THEN COALESCE(banned_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, userID, banned)
+	return err
+}
+
+func (r *UserRepository) Count(ctx context.Context, since time.Time) (domain.UserCounts, error) {
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE created_at >= $1),
+		       COUNT(*) FILTER (WHERE banned_at IS NOT NULL)
+		FROM users`
+
+	var counts domain.UserCounts
+	err := r.db.Pool.QueryRow(ctx, query, since).Scan(&counts.Total, &counts.Recent, &counts.Banned)
+	return counts, err
+}
+
+// ListTelegramIDs returns the Telegram IDs of all users who are not banned.
+func (r *UserRepository) ListTelegramIDs(ctx context.Context) ([]int64, error) {
+	query := `SELECT telegram_id FROM users WHERE banned_at IS NULL ORDER BY id`
+
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+// Delete removes the user. Tasks and history are removed by ON DELETE CASCADE.
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM users WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
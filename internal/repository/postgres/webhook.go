@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+// deliveryLogSize is how many delivery attempts are kept per webhook.
+const deliveryLogSize = 100
+
+type WebhookRepository struct {
+	db *DB
+}
+
+func NewWebhookRepository(db *DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (user_id, url, secret)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	return r.db.Pool.QueryRow(ctx, query, webhook.UserID, webhook.URL, webhook.Secret).Scan(&webhook.ID, &webhook.CreatedAt)
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, created_at
+		FROM webhooks
+		WHERE id = $1`
+
+	webhook := &domain.Webhook{}
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) ListByUserID(ctx context.Context, userID int64) ([]*domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, created_at
+		FROM webhooks
+		WHERE user_id = $1
+		ORDER BY id ASC`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*domain.Webhook
+	for rows.Next() {
+		webhook := &domain.Webhook{}
+		if err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Secret, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM webhooks WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, task_id, attempt, status_code, error, success)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.db.Pool.QueryRow(ctx, query,
+		delivery.WebhookID,
+		delivery.EventType,
+		delivery.TaskID,
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Success,
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	trim := `
+		DELETE FROM webhook_deliveries
+		WHERE webhook_id = $1 AND id < (
+			SELECT id FROM webhook_deliveries
+			WHERE webhook_id = $1
+			ORDER BY id DESC
+			OFFSET $2 LIMIT 1
+		)`
+	_, err = r.db.Pool.Exec(ctx, trim, delivery.WebhookID, deliveryLogSize-1)
+	return err
+}
+
+func (r *WebhookRepository) ListDeliveriesByUserID(ctx context.Context, userID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_type, d.task_id, d.attempt, d.status_code, d.error, d.success, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE w.user_id = $1
+		ORDER BY d.id DESC
+		LIMIT $2`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d := &domain.WebhookDelivery{}
+		var eventType string
+		err := rows.Scan(&d.ID, &d.WebhookID, &eventType, &d.TaskID, &d.Attempt, &d.StatusCode, &d.Error, &d.Success, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.EventType = domain.TaskEventType(eventType)
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"telegram-reminder-bot/internal/domain"
 )
@@ -10,15 +11,135 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id int64) (*domain.User, error)
 	GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error)
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	GetByCalendarToken(ctx context.Context, token string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	SetCalendarToken(ctx context.Context, userID int64, token string) error
+	SetBanned(ctx context.Context, userID int64, banned bool) error
+	Count(ctx context.Context, since time.Time) (domain.UserCounts, error)
+	ListTelegramIDs(ctx context.Context) ([]int64, error)
+	Delete(ctx context.Context, id int64) error
 }
 
 type TaskRepository interface {
 	Create(ctx context.Context, task *domain.Task) error
 	GetByID(ctx context.Context, id int64) (*domain.Task, error)
 	GetActiveByUserID(ctx context.Context, userID int64) ([]*domain.Task, error)
+	GetActiveByChatID(ctx context.Context, chatID int64) ([]*domain.Task, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.Task, error)
+	ListActiveByUserID(ctx context.Context, userID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error)
+	ListActiveByChatID(ctx context.Context, chatID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error)
+	ListCompletedByUserID(ctx context.Context, userID int64, limit, offset int) ([]*domain.Task, int, error)
 	GetTasksForReminder(ctx context.Context) ([]*domain.Task, error)
+	ListOverdue(ctx context.Context, before time.Time) ([]*domain.Task, error)
+	ListPausedUntil(ctx context.Context, date time.Time) ([]*domain.Task, error)
+	// ListReadyDependents returns the active tasks blocked by blockerID that
+	// no longer wait for any active task.
+	ListReadyDependents(ctx context.Context, blockerID int64) ([]*domain.Task, error)
 	Update(ctx context.Context, task *domain.Task) error
 	Delete(ctx context.Context, id int64) error
+	SoftDelete(ctx context.Context, id int64) error
+	// GetDeletedByID returns a soft-deleted task deleted at or after since.
+	GetDeletedByID(ctx context.Context, id int64, since time.Time) (*domain.Task, error)
+	// Restore undoes SoftDelete for a task of chatID, or a personal task of
+	// userID when chatID is nil, deleted at or after since.
+	Restore(ctx context.Context, id, userID int64, chatID *int64, since time.Time) (bool, error)
+	DeleteSoftDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteCompletedBefore(ctx context.Context, before time.Time) (int64, error)
 	ResetDailyReminders(ctx context.Context) error
+	// RecordReminderSent counts a reminder sent at the given time and ends
+	// any snooze or deferral.
+	RecordReminderSent(ctx context.Context, id int64, at time.Time) error
+	// Snooze holds the task's reminders back until the given time.
+	Snooze(ctx context.Context, id int64, until time.Time, deferred bool) error
+	// MarkOverdue marks an active task overdue once; it reports whether it
+	// did.
+	MarkOverdue(ctx context.Context, id int64, at time.Time) (bool, error)
+	// SetPaused pauses the task, or resumes it when at is nil.
+	SetPaused(ctx context.Context, id int64, at, until *time.Time) error
+	// DetachShared clears the creator of the user's shared group tasks.
+	DetachShared(ctx context.Context, userID int64) error
+	// ReleaseDeferred ends the deferral of the user's tasks deferred by
+	// quiet hours.
+	ReleaseDeferred(ctx context.Context, userID int64) error
+	// CountByState counts all tasks by active, overdue, completed and deleted.
+	CountByState(ctx context.Context) (map[string]int, error)
+}
+
+type EventRepository interface {
+	Create(ctx context.Context, event *domain.TaskEvent) error
+	ListByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.TaskEvent, error)
+}
+
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *domain.Reminder) error
+	// Respond sets the outcome of the task's latest pending reminder, if
+	// there is one.
+	Respond(ctx context.Context, taskID int64, outcome domain.ReminderOutcome) error
+	// IgnorePending marks pending reminders sent before the given time as
+	// ignored, only those of taskID when it is not zero.
+	IgnorePending(ctx context.Context, taskID int64, before time.Time) error
+	// ListByUserID returns the reminders of the user's personal tasks.
+	ListByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.Reminder, error)
+	ListByChatID(ctx context.Context, chatID int64, since time.Time) ([]*domain.Reminder, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type QuietPeriodRepository interface {
+	Create(ctx context.Context, period *domain.QuietPeriod) error
+	GetByID(ctx context.Context, id int64) (*domain.QuietPeriod, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.QuietPeriod, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type APITokenRepository interface {
+	Create(ctx context.Context, token *domain.APIToken) error
+	GetByHash(ctx context.Context, hash string) (*domain.APIToken, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.APIToken, error)
+	Touch(ctx context.Context, id int64) error
+	DeleteByUserID(ctx context.Context, userID int64) (int64, error)
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	GetByID(ctx context.Context, id int64) (*domain.Webhook, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	// CreateDelivery logs a delivery attempt, keeping only the most recent
+	// attempts per webhook.
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListDeliveriesByUserID(ctx context.Context, userID int64, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+// DependencyRepository stores which tasks block which. The lists include
+// dependencies on soft-deleted tasks, which come back when a delete is
+// undone and so must be seen by the cycle check.
+type DependencyRepository interface {
+	Create(ctx context.Context, dep *domain.TaskDependency) error
+	Delete(ctx context.Context, taskID, blockerID int64) error
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.TaskDependency, error)
+	ListByChatID(ctx context.Context, chatID int64) ([]*domain.TaskDependency, error)
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) error
+	GetByID(ctx context.Context, id int64) (*domain.Attachment, error)
+	ListByTaskID(ctx context.Context, taskID int64) ([]*domain.Attachment, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*domain.Attachment, error)
+	CountByTaskID(ctx context.Context, taskID int64) (int, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type ChatRepository interface {
+	Create(ctx context.Context, chat *domain.Chat) error
+	GetByID(ctx context.Context, id int64) (*domain.Chat, error)
+	GetByTelegramID(ctx context.Context, telegramID int64) (*domain.Chat, error)
+	SetLeft(ctx context.Context, id int64, left bool) error
+}
+
+type InviteRepository interface {
+	Create(ctx context.Context, invite *domain.Invite) error
+	Redeem(ctx context.Context, code string, telegramID int64) (bool, error)
+	// Forget clears telegramID from the codes it created or redeemed.
+	Forget(ctx context.Context, telegramID int64) error
 }
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+)
+
+// EscalationStage applies to tasks due within Days days.
+type EscalationStage struct {
+	Days int
+	// Frequency is the least often a task in the stage is reminded of;
+	// tasks with a more frequent schedule keep theirs.
+	Frequency domain.Frequency
+	// MinPerDay is the least number of reminders a day.
+	MinPerDay int
+	Tone      render.Tone
+}
+
+// EscalationCurve lists stages by Days, descending. A task is in the last
+// stage it is within.
+type EscalationCurve []EscalationStage
+
+// DefaultEscalationCurve, selected with ESCALATION_CURVE=default, reminds daily in the last week, at least three
+// times a day in the last two days and five times on the deadline.
+var DefaultEscalationCurve = EscalationCurve{
+	{Days: 7, Frequency: domain.FrequencyDaily, MinPerDay: 1},
+	{Days: 2, Frequency: domain.FrequencyDaily, MinPerDay: 3, Tone: render.ToneFirm},
+	{Days: 0, Frequency: domain.FrequencyDaily, MinPerDay: 5, Tone: render.ToneUrgent},
+}
+
+// ParseEscalationCurve parses ESCALATION_CURVE: comma-separated stages of
+// "days:frequency:min_per_day[:tone]" with days descending, e.g.
+// "7:daily:1,2:daily:3:firm,0:daily:5:urgent". "default" means
+// DefaultEscalationCurve; empty or "off" disables escalation.
+func ParseEscalationCurve(s string) (EscalationCurve, error) {
+	switch strings.TrimSpace(s) {
+	case "", "off":
+		return nil, nil
+	case "default":
+		return DefaultEscalationCurve, nil
+	}
+
+	var curve EscalationCurve
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		if len(fields) != 3 && len(fields) != 4 {
+			return nil, fmt.Errorf("escalation stage %q: want days:frequency:min_per_day[:tone]", part)
+		}
+
+		days, err := strconv.Atoi(fields[0])
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("escalation stage %q: invalid days", part)
+		}
+		if len(curve) > 0 && days >= curve[len(curve)-1].Days {
+			return nil, fmt.Errorf("escalation stage %q: days must be descending", part)
+		}
+
+		frequency, ok := domain.ParseFrequency(fields[1])
+		if !ok {
+			return nil, fmt.Errorf("escalation stage %q: unknown frequency %q", part, fields[1])
+		}
+
+		perDay, err := strconv.Atoi(fields[2])
+		if err != nil || perDay < 0 {
+			return nil, fmt.Errorf("escalation stage %q: invalid reminders per day", part)
+		}
+
+		var tone render.Tone
+		if len(fields) == 4 {
+			tone = render.Tone(fields[3])
+			if tone == render.ToneNormal || !slices.Contains(render.Tones, tone) {
+				return nil, fmt.Errorf("escalation stage %q: unknown tone %q", part, fields[3])
+			}
+		}
+
+		curve = append(curve, EscalationStage{Days: days, Frequency: frequency, MinPerDay: perDay, Tone: tone})
+	}
+
+	return curve, nil
+}
+
+// stage returns the stage of a task due in days; ok is false for tasks not
+// within the first stage yet.
+func (c EscalationCurve) stage(days int) (stage EscalationStage, ok bool) {
+	for _, s := range c {
+		if days <= s.Days {
+			stage, ok = s, true
+		}
+	}
+	return stage, ok
+}
+
+// frequencyRank orders frequencies from the least frequent.
+var frequencyRank = map[domain.Frequency]int{
+	domain.FrequencyWeekly:        1,
+	domain.FrequencyEveryOtherDay: 2,
+	domain.FrequencyDaily:         3,
+}
+
+// EscalatingPolicy raises the reminders of Base as deadlines approach:
+// within a stage of Curve a task is reminded at least as often and as many
+// times a day as the stage says, in the stage's tone.
+type EscalatingPolicy struct {
+	Base  ReminderPolicy
+	Curve EscalationCurve
+}
+
+func (p EscalatingPolicy) Decide(req ReminderRequest) ReminderDecision {
+	stage, ok := p.Curve.stage(req.Task.DaysUntil(req.Now))
+	if !ok {
+		return p.Base.Decide(req)
+	}
+
+	task := *req.Task
+	if frequencyRank[stage.Frequency] > frequencyRank[task.Frequency] {
+		task.Frequency = stage.Frequency
+	}
+	req.Task = &task
+
+	d := decide(req, max(p.Base.Decide(req).PerDay, stage.MinPerDay))
+	d.Tone = stage.Tone
+	return d
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+)
+
+// simulateDay runs policy every five minutes of day the way the scheduler
+// does, and returns the reminders sent and the tone of the last one.
+func simulateDay(policy ReminderPolicy, task *domain.Task, day time.Time) (int, render.Tone) {
+	task.RemindersSentToday = 0
+	var tone render.Tone
+	for now := day; now.Before(day.AddDate(0, 0, 1)); now = now.Add(5 * time.Minute) {
+		decision := policy.Decide(request(task, now))
+		if decision.Send {
+			task.RemindersSentToday++
+			tone = decision.Tone
+		}
+	}
+	return task.RemindersSentToday, tone
+}
+
+func TestEscalatingPolicy_Scenario(t *testing.T) {
+	policy := EscalatingPolicy{Base: FixedPolicy{}, Curve: DefaultEscalationCurve}
+	task := &domain.Task{
+		ID:         1,
+		Deadline:   time.Date(2025, time.January, 20, 18, 0, 0, 0, time.UTC), // Monday
+		Frequency:  domain.FrequencyWeekly,
+		Importance: 2,
+	}
+
+	tests := []struct {
+		day      int
+		want     int
+		wantTone render.Tone
+	}{
+		{6, 2, render.ToneNormal},  // Monday two weeks before: weekly
+		{8, 0, render.ToneNormal},  // not the deadline's weekday
+		{12, 0, render.ToneNormal}, // 8 days left
+		{13, 2, render.ToneNormal}, // a week left: daily
+		{15, 2, render.ToneNormal},
+		{18, 3, render.ToneFirm}, // two days left
+		{19, 3, render.ToneFirm},
+		{20, 5, render.ToneUrgent}, // the deadline
+		{21, 0, render.ToneNormal}, // overdue
+	}
+
+	for _, tt := range tests {
+		day := time.Date(2025, time.January, tt.day, 0, 0, 0, 0, time.UTC)
+		t.Run(day.Format("Jan 2"), func(t *testing.T) {
+			got, tone := simulateDay(policy, task, day)
+			if got != tt.want || tone != tt.wantTone {
+				t.Errorf("sent %d reminders in tone %q, want %d in tone %q", got, tone, tt.want, tt.wantTone)
+			}
+		})
+	}
+}
+
+func TestEscalatingPolicy_KeepsHigherBase(t *testing.T) {
+	policy := EscalatingPolicy{Base: FixedPolicy{}, Curve: DefaultEscalationCurve}
+
+	// Importance 5 already asks for more than the two-day stage's three.
+	got, _ := simulateDay(policy, policyTask(5, 0, 2), noon().Truncate(24*time.Hour))
+	if got != 5 {
+		t.Errorf("sent %d reminders, want 5", got)
+	}
+}
+
+func TestParseEscalationCurve(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    EscalationCurve
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"off", nil, false},
+		{"default", DefaultEscalationCurve, false},
+		{"7:daily:1,2:daily:3:firm,0:daily:5:urgent", DefaultEscalationCurve, false},
+		{"14:every_other_day:1, 3:daily:4:urgent", EscalationCurve{
+			{Days: 14, Frequency: domain.FrequencyEveryOtherDay, MinPerDay: 1},
+			{Days: 3, Frequency: domain.FrequencyDaily, MinPerDay: 4, Tone: render.ToneUrgent},
+		}, false},
+		{"7:daily", nil, true},
+		{"x:daily:1", nil, true},
+		{"-1:daily:1", nil, true},
+		{"7:hourly:1", nil, true},
+		{"7:daily:many", nil, true},
+		{"7:daily:1:angry", nil, true},
+		{"2:daily:3,7:daily:1", nil, true},
+		{"2:daily:3,2:daily:5", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseEscalationCurve(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEscalationCurve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEscalationCurve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/eventbus"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/render"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// Notifier tells users who assigned a task when the assignee completes it or
+// lets it go overdue, and the owners of blocked tasks when nothing blocks
+// them any more. It subscribes to the event bus, so completions from the bot
+// and the API are both covered.
+type Notifier struct {
+	userRepo repository.UserRepository
+	chatRepo repository.ChatRepository
+	sender   ReminderSender
+}
+
+func NewNotifier(userRepo repository.UserRepository, chatRepo repository.ChatRepository, sender ReminderSender) *Notifier {
+	return &Notifier{userRepo: userRepo, chatRepo: chatRepo, sender: sender}
+}
+
+// Handle is an eventbus.Handler. Messages are sent on their own goroutine.
+func (n *Notifier) Handle(ctx context.Context, event eventbus.Event) {
+	switch {
+	case event.Type == domain.TaskEventUnblocked:
+		go n.notifyUnblocked(context.WithoutCancel(ctx), event)
+	case event.Task.IsDelegated() && (event.Type == domain.TaskEventCompleted || event.Type == domain.TaskEventOverdue):
+		go n.notify(context.WithoutCancel(ctx), event)
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context, event eventbus.Event) {
+	task := event.Task
+
+	assigner, err := n.userRepo.GetByID(ctx, *task.AssignedBy)
+	if err != nil || assigner == nil || assigner.IsBanned() {
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get assigner")
+		}
+		return
+	}
+
+	assignee, err := n.userRepo.GetByID(ctx, task.UserID)
+	if err != nil || assignee == nil {
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get assignee")
+		}
+		return
+	}
+
+	l := i18n.Parse(assigner.Language)
+	var message string
+	switch event.Type {
+	case domain.TaskEventCompleted:
+		message = l.T("assign.completed_notice", render.Escape(assignee.Mention()), render.Escape(task.Description))
+	case domain.TaskEventOverdue:
+		message = l.T("assign.overdue_notice",
+			render.Escape(task.Description), render.Escape(assignee.Mention()), task.Deadline.Format("02.01.2006"))
+	}
+
+	if err := n.sender.SendNotification(ctx, assigner.TelegramID, message); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to notify assigner")
+	}
+}
+
+// notifyUnblocked tells the owner of a task, or its group, that the task
+// waits for nothing any more.
+func (n *Notifier) notifyUnblocked(ctx context.Context, event eventbus.Event) {
+	task := event.Task
+
+	var (
+		telegramID int64
+		l          i18n.Lang
+	)
+	if task.ChatID != nil {
+		chat, err := n.chatRepo.GetByID(ctx, *task.ChatID)
+		if err != nil || chat == nil || chat.LeftAt != nil {
+			if err != nil {
+				log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get chat")
+			}
+			return
+		}
+		telegramID, l = chat.TelegramID, i18n.Parse(chat.Language)
+	} else {
+		owner, err := n.userRepo.GetByID(ctx, task.UserID)
+		if err != nil || owner == nil || owner.IsBanned() {
+			if err != nil {
+				log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get task owner")
+			}
+			return
+		}
+		telegramID, l = owner.TelegramID, i18n.Parse(owner.Language)
+	}
+
+	message := l.T("dependency.unblocked_notice", render.Escape(task.Description))
+	if err := n.sender.SendNotification(ctx, telegramID, message); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to notify about unblocked task")
+	}
+}
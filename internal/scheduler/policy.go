@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/render"
+)
+
+// ReminderRequest is what a policy knows when deciding on a task's next
+// reminder.
+type ReminderRequest struct {
+	Task *domain.Task
+	// Now is the current time in the recipient's timezone.
+	Now           time.Time
+	WorkStartHour int
+	WorkEndHour   int
+	// History is the task owner's recent reminders, oldest first.
+	History []*domain.Reminder
+}
+
+// ReminderDecision says whether to send a reminder now and, when not, why.
+type ReminderDecision struct {
+	Send bool
+	// Reason explains a skipped reminder in traces.
+	Reason string
+	// PerDay is how many reminders the task gets today.
+	PerDay int
+	Tone   render.Tone
+}
+
+// ReminderPolicy decides when tasks get reminders.
+type ReminderPolicy interface {
+	Decide(req ReminderRequest) ReminderDecision
+}
+
+// Reminder policies selectable with REMINDER_POLICY.
+const (
+	PolicyFixed    = "fixed"
+	PolicyAdaptive = "adaptive"
+)
+
+// NewPolicy returns the policy with the given name, empty meaning fixed,
+// escalated along curve unless it is empty.
+func NewPolicy(name string, curve EscalationCurve) (ReminderPolicy, error) {
+	var policy ReminderPolicy
+	switch name {
+	case PolicyFixed, "":
+		policy = FixedPolicy{}
+	case PolicyAdaptive:
+		policy = AdaptivePolicy{}
+	default:
+		return nil, fmt.Errorf("unknown reminder policy %q", name)
+	}
+
+	if len(curve) > 0 {
+		policy = EscalatingPolicy{Base: policy, Curve: curve}
+	}
+	return policy, nil
+}
+
+// FixedPolicy sends as many reminders a day as the task's importance,
+// spread evenly over the working day.
+type FixedPolicy struct{}
+
+func (FixedPolicy) Decide(req ReminderRequest) ReminderDecision {
+	return decide(req, req.Task.Importance)
+}
+
+const (
+	// backOffSample is how many first reminders of the day must have been
+	// answered or ignored before the policy backs off.
+	backOffSample = 5
+	// backOffPercent of those must have been acted on.
+	backOffPercent = 80
+	// escalateIgnored reminders of a task ignored in a row escalate it
+	// within escalateDays of its deadline.
+	escalateIgnored = 2
+	escalateDays    = 2
+)
+
+// AdaptivePolicy starts from the fixed number of reminders and adjusts it to
+// how the owner responds: users who act on the first reminder of the day
+// most of the time get half as many, and a task whose reminders keep being
+// ignored gets two more a day as its deadline approaches.
+type AdaptivePolicy struct{}
+
+func (AdaptivePolicy) Decide(req ReminderRequest) ReminderDecision {
+	return decide(req, adaptiveRemindersPerDay(req, domain.SummarizeReminders(req.History, req.Task.ID)))
+}
+
+func adaptiveRemindersPerDay(req ReminderRequest, r domain.Responsiveness) int {
+	base := req.Task.Importance
+	switch {
+	case r.IgnoredInARow >= escalateIgnored && req.Task.DaysUntil(req.Now) <= escalateDays:
+		return base + 2
+	case r.FirstReminders >= backOffSample && r.FirstClicked*100 >= r.FirstReminders*backOffPercent:
+		return max((base+1)/2, 1)
+	}
+	return base
+}
+
+// decide spreads perDay reminders over the working day. A reminder is due
+// right away when a snooze is over, even after the day's last one.
+func decide(req ReminderRequest, perDay int) ReminderDecision {
+	task := req.Task
+	d := ReminderDecision{PerDay: perDay}
+
+	switch {
+	case !task.ShouldRemindOn(req.Now):
+		d.Reason = "not due by frequency"
+	case !IsWithinWorkHours(req.WorkStartHour, req.WorkEndHour, req.Now):
+		d.Reason = "outside work hours"
+	case task.SnoozedUntil != nil && req.Now.Before(*task.SnoozedUntil) && task.Deferred:
+		d.Reason = "deferred by quiet hours"
+	case task.SnoozedUntil != nil && req.Now.Before(*task.SnoozedUntil):
+		d.Reason = "snoozed"
+	case task.SnoozedUntil != nil:
+		d.Send = true
+		d.PerDay = max(perDay, task.RemindersSentToday+1)
+	case task.RemindersSentToday >= perDay:
+		d.Reason = "all reminders for today sent"
+	case !ShouldSendReminder(CalculateReminderTimes(perDay, req.WorkStartHour, req.WorkEndHour, req.Now), task.RemindersSentToday, req.Now):
+		d.Reason = "next reminder time not reached"
+	default:
+		d.Send = true
+	}
+
+	return d
+}
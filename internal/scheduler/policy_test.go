@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+)
+
+// noon is within the default 9-18 working day of a fixed Wednesday, so
+// that policies are tested against the same calendar every run.
+func noon() time.Time {
+	return time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+}
+
+func policyTask(importance, sent, daysLeft int) *domain.Task {
+	return &domain.Task{
+		ID:                 1,
+		Deadline:           noon().Truncate(24*time.Hour).AddDate(0, 0, daysLeft),
+		Frequency:          domain.FrequencyDaily,
+		Importance:         importance,
+		RemindersSentToday: sent,
+	}
+}
+
+func request(task *domain.Task, now time.Time, history ...*domain.Reminder) ReminderRequest {
+	return ReminderRequest{Task: task, Now: now, WorkStartHour: 9, WorkEndHour: 18, History: history}
+}
+
+func outcomes(taskID int64, number int, list ...domain.ReminderOutcome) []*domain.Reminder {
+	var result []*domain.Reminder
+	for _, outcome := range list {
+		result = append(result, &domain.Reminder{TaskID: taskID, Number: number, Outcome: outcome})
+	}
+	return result
+}
+
+func TestFixedPolicy(t *testing.T) {
+	past := noon().Add(-time.Minute)
+	future := noon().Add(time.Hour)
+
+	completed := policyTask(3, 0, 5)
+	completed.IsCompleted = true
+	snoozed := policyTask(3, 1, 5)
+	snoozed.SnoozedUntil = &future
+	snoozeOver := policyTask(3, 3, 5)
+	snoozeOver.SnoozedUntil = &past
+	deferred := policyTask(3, 0, 5)
+	deferred.SnoozedUntil = &future
+	deferred.Deferred = true
+	deferralOver := policyTask(3, 1, 5)
+	deferralOver.SnoozedUntil = &past
+	deferralOver.Deferred = true
+
+	tests := []struct {
+		name       string
+		req        ReminderRequest
+		wantSend   bool
+		wantReason string
+		wantPerDay int
+	}{
+		{"first slot passed", request(policyTask(3, 0, 5), noon()), true, "", 3},
+		{"second slot not reached", request(policyTask(3, 1, 5), noon()), false, "next reminder time not reached", 3},
+		{"all sent", request(policyTask(3, 3, 5), noon()), false, "all reminders for today sent", 3},
+		{"outside work hours", request(policyTask(3, 0, 5), noon().Add(8*time.Hour)), false, "outside work hours", 3},
+		{"completed", request(completed, noon()), false, "not due by frequency", 3},
+		{"snoozed", request(snoozed, noon()), false, "snoozed", 3},
+		{"snooze over after the last reminder", request(snoozeOver, noon()), true, "", 4},
+		{"deferred by quiet hours", request(deferred, noon()), false, "deferred by quiet hours", 3},
+		{"quiet hours over", request(deferralOver, noon()), true, "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FixedPolicy{}.Decide(tt.req)
+			if got.Send != tt.wantSend || got.Reason != tt.wantReason || got.PerDay != tt.wantPerDay {
+				t.Errorf("Decide() = %+v, want send %v, reason %q, per day %d", got, tt.wantSend, tt.wantReason, tt.wantPerDay)
+			}
+		})
+	}
+}
+
+func TestAdaptivePolicy_PerDay(t *testing.T) {
+	clicked, ignored := domain.ReminderClicked, domain.ReminderIgnored
+
+	tests := []struct {
+		name    string
+		task    *domain.Task
+		history []*domain.Reminder
+		want    int
+	}{
+		{"no history", policyTask(4, 0, 5), nil, 4},
+		{"acts on first reminders", policyTask(4, 0, 5), outcomes(2, 1, clicked, clicked, clicked, clicked, clicked), 2},
+		{"acts on 80% of first reminders", policyTask(5, 0, 5), outcomes(2, 1, clicked, clicked, ignored, clicked, clicked), 3},
+		{"acts on 60% of first reminders", policyTask(4, 0, 5), outcomes(2, 1, clicked, ignored, clicked, ignored, clicked), 4},
+		{"too little history to back off", policyTask(4, 0, 5), outcomes(2, 1, clicked, clicked, clicked, clicked), 4},
+		{"never below one", policyTask(1, 0, 5), outcomes(2, 1, clicked, clicked, clicked, clicked, clicked), 1},
+		{"ignored close to the deadline", policyTask(3, 0, 1), outcomes(1, 2, ignored, ignored), 5},
+		{"pending counts as ignored", policyTask(3, 0, 2), outcomes(1, 1, ignored, domain.ReminderPending), 5},
+		{"ignored far from the deadline", policyTask(3, 0, 7), outcomes(1, 2, ignored, ignored), 3},
+		{"answered after ignoring", policyTask(3, 0, 1), outcomes(1, 2, ignored, ignored, domain.ReminderSnoozed), 3},
+		{
+			"escalation wins over backing off",
+			policyTask(4, 0, 0),
+			append(outcomes(2, 1, clicked, clicked, clicked, clicked, clicked), outcomes(1, 2, ignored, ignored)...),
+			6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (AdaptivePolicy{}).Decide(request(tt.task, noon(), tt.history...)).PerDay; got != tt.want {
+				t.Errorf("PerDay = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptivePolicy_Slots(t *testing.T) {
+	history := outcomes(2, 1, domain.ReminderClicked, domain.ReminderClicked, domain.ReminderClicked, domain.ReminderClicked, domain.ReminderClicked)
+
+	// Backed off from 4 to 2 reminders at 11:15 and 15:45: the second one is
+	// not due at 12:30, when the fixed policy sends its second one.
+	got := AdaptivePolicy{}.Decide(request(policyTask(4, 1, 5), noon().Add(30*time.Minute), history...))
+	if got.Send {
+		t.Errorf("Decide() = %+v, want no reminder", got)
+	}
+	if fixed := (FixedPolicy{}).Decide(request(policyTask(4, 1, 5), noon().Add(30*time.Minute))); !fixed.Send {
+		t.Errorf("fixed Decide() = %+v, want a reminder", fixed)
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		curve   EscalationCurve
+		want    ReminderPolicy
+		wantErr bool
+	}{
+		{"", nil, FixedPolicy{}, false},
+		{"adaptive", nil, AdaptivePolicy{}, false},
+		{"fixed", nil, FixedPolicy{}, false},
+		{"fixed", DefaultEscalationCurve, EscalatingPolicy{Base: FixedPolicy{}, Curve: DefaultEscalationCurve}, false},
+		{"random", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolicy(tt.name, tt.curve)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPolicy() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,67 +2,128 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/i18n"
+	"telegram-reminder-bot/internal/metrics"
+	"telegram-reminder-bot/internal/render"
 	"telegram-reminder-bot/internal/repository"
 	"telegram-reminder-bot/internal/service"
+	"telegram-reminder-bot/internal/tracing"
 )
 
 type ReminderSender interface {
-	SendReminder(ctx context.Context, telegramID int64, message string, taskID int64) error
+	// SendReminder sends an HTML reminder with buttons labelled in lang.
+	SendReminder(ctx context.Context, telegramID int64, lang i18n.Lang, message string, taskID int64) error
+	// SendNotification sends an HTML message without buttons.
+	SendNotification(ctx context.Context, telegramID int64, message string) error
 }
 
 type Scheduler struct {
-	scheduler   gocron.Scheduler
-	taskService *service.TaskService
-	userRepo    repository.UserRepository
-	sender      ReminderSender
+	scheduler       gocron.Scheduler
+	taskService     *service.TaskService
+	reminderService *service.ReminderService
+	quietService    *service.QuietService
+	userRepo        repository.UserRepository
+	chatRepo        repository.ChatRepository
+	sender          ReminderSender
+	policy          ReminderPolicy
+	// lastTick is the Unix time in nanoseconds of the last completed
+	// reminder check, or of Start before the first one.
+	lastTick atomic.Int64
 }
 
-func New(taskService *service.TaskService, userRepo repository.UserRepository, sender ReminderSender) (*Scheduler, error) {
+func New(taskService *service.TaskService, reminderService *service.ReminderService, quietService *service.QuietService, userRepo repository.UserRepository, chatRepo repository.ChatRepository, sender ReminderSender, policy ReminderPolicy) (*Scheduler, error) {
 	s, err := gocron.NewScheduler()
 	if err != nil {
 		return nil, err
 	}
 
 	return &Scheduler{
-		scheduler:   s,
-		taskService: taskService,
-		userRepo:    userRepo,
-		sender:      sender,
+		scheduler:       s,
+		taskService:     taskService,
+		reminderService: reminderService,
+		quietService:    quietService,
+		userRepo:        userRepo,
+		chatRepo:        chatRepo,
+		sender:          sender,
+		policy:          policy,
 	}, nil
 }
 
+// reminderInterval is how often checkReminders runs. Check reports the
+// scheduler as stalled when a run is overdue by more than one interval.
+const reminderInterval = 5 * time.Minute
+
 func (s *Scheduler) Start(ctx context.Context) error {
-	_, err := s.scheduler.NewJob(
-		gocron.DurationJob(5*time.Minute),
-		gocron.NewTask(func() {
-			s.checkReminders(ctx)
-		}),
-	)
-	if err != nil {
-		return err
+	jobs := []struct {
+		name       string
+		definition gocron.JobDefinition
+		run        func(context.Context)
+	}{
+		{"check_reminders", gocron.DurationJob(reminderInterval), s.checkReminders},
+		{"reset_daily_reminders", gocron.DailyJob(1, gocron.NewAtTimes(gocron.NewAtTime(0, 0, 0))), s.resetDailyReminders},
+		{"purge_archive", gocron.DailyJob(1, gocron.NewAtTimes(gocron.NewAtTime(3, 0, 0))), s.purgeArchive},
+		{"purge_deleted", gocron.DurationJob(time.Minute), s.purgeDeleted},
+		{"check_overdue", gocron.DurationJob(15 * time.Minute), s.checkOverdue},
+		{"resume_paused", gocron.DurationJob(15 * time.Minute), s.resumePaused},
+		{"update_task_metrics", gocron.DurationJob(time.Minute), s.updateTaskMetrics},
 	}
 
-	_, err = s.scheduler.NewJob(
-		gocron.DailyJob(1, gocron.NewAtTimes(gocron.NewAtTime(0, 0, 0))),
-		gocron.NewTask(func() {
-			s.resetDailyReminders(ctx)
-		}),
-	)
-	if err != nil {
-		return err
+	for _, job := range jobs {
+		_, err := s.scheduler.NewJob(
+			job.definition,
+			gocron.NewTask(s.instrument(ctx, job.name, job.run)),
+			gocron.WithName(job.name),
+		)
+		if err != nil {
+			return err
+		}
 	}
 
+	s.lastTick.Store(time.Now().UnixNano())
 	s.scheduler.Start()
-	log.Info().Msg("scheduler started")
+	log.Info().Ctx(ctx).Msg("scheduler started")
+
+	return nil
+}
 
+// instrument traces every run of a job and records its duration. Runs of
+// check_reminders also count as scheduler ticks for Check.
+func (s *Scheduler) instrument(ctx context.Context, name string, run func(context.Context)) func() {
+	return func() {
+		ctx, span := tracing.Start(ctx, "scheduler "+name)
+		defer span.End()
+
+		start := time.Now()
+		run(ctx)
+		metrics.SchedulerJobDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+		if name == "check_reminders" {
+			s.lastTick.Store(time.Now().UnixNano())
+		}
+	}
+}
+
+// Check reports an error when the scheduler has not been started or
+// check_reminders has not completed for more than two intervals.
+func (s *Scheduler) Check(context.Context) error {
+	last := s.lastTick.Load()
+	if last == 0 {
+		return errors.New("scheduler not started")
+	}
+	if since := time.Since(time.Unix(0, last)); since > 2*reminderInterval {
+		return fmt.Errorf("last reminder check %s ago", since.Round(time.Second))
+	}
 	return nil
 }
 
@@ -73,97 +134,393 @@ func (s *Scheduler) Stop() error {
 func (s *Scheduler) checkReminders(ctx context.Context) {
 	tasks, err := s.taskService.GetTasksForReminder(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get tasks for reminder")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to get tasks for reminder")
 		return
 	}
 
+	histories := make(map[int64][]*domain.Reminder)
+	summaries := make(map[int64]*summary)
 	for _, task := range tasks {
-		if !task.CanSendReminder() {
-			continue
-		}
+		s.remind(ctx, task, histories, summaries)
+	}
+	for _, sum := range summaries {
+		s.sendSummary(ctx, sum)
+	}
+}
 
-		user, err := s.userRepo.GetByID(ctx, task.UserID)
-		if err != nil || user == nil {
-			log.Error().Err(err).Int64("task_id", task.ID).Msg("failed to get user for task")
-			continue
-		}
+// summary collects the reminders deferred by a recipient's quiet hours that
+// are sent together in one message once they are over.
+type summary struct {
+	to    *recipient
+	tasks []*domain.Task
+}
 
-		now := time.Now().In(user.Location())
+// remind sends the next reminder for task if the policy says one is due.
+// Each decision is traced so that a missing reminder can be explained.
+// histories caches reminder histories by chat for the current check;
+// reminders going into summaries are collected in summaries by chat.
+func (s *Scheduler) remind(ctx context.Context, task *domain.Task, histories map[int64][]*domain.Reminder, summaries map[int64]*summary) {
+	ctx, span := tracing.Start(ctx, "scheduler remind", trace.WithAttributes(
+		attribute.Int64("task.id", task.ID),
+		attribute.Int64("user.id", task.UserID),
+		attribute.Int("task.reminders_sent_today", task.RemindersSentToday),
+	))
+	defer span.End()
 
-		if !IsWithinWorkHours(user.WorkStartHour, user.WorkEndHour, now) {
-			continue
+	to, err := s.recipientFor(ctx, task)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get recipient for task")
+		tracing.Fail(span, err)
+		return
+	}
+	if to == nil {
+		// The account or chat was deleted after the tasks were loaded.
+		tracing.Skip(ctx, "owner deleted")
+		return
+	}
+	if to.inactive != "" {
+		tracing.Skip(ctx, to.inactive)
+		return
+	}
+
+	history, ok := histories[to.telegramID]
+	if !ok {
+		history, err = s.reminderService.History(ctx, task)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get reminder history")
 		}
+		histories[to.telegramID] = history
+	}
 
-		reminderTimes := CalculateReminderTimes(task.Importance, user.WorkStartHour, user.WorkEndHour, now)
+	decision := s.policy.Decide(ReminderRequest{
+		Task:          task,
+		Now:           time.Now().In(to.location),
+		WorkStartHour: to.workStartHour,
+		WorkEndHour:   to.workEndHour,
+		History:       history,
+	})
+	span.SetAttributes(
+		attribute.Int("reminder.per_day", decision.PerDay),
+		attribute.String("reminder.tone", string(decision.Tone)),
+	)
+	if !decision.Send {
+		tracing.Skip(ctx, decision.Reason)
+		return
+	}
 
-		if !ShouldSendReminder(reminderTimes, task.RemindersSentToday, now) {
-			continue
+	if !to.quietUntil.IsZero() {
+		if err := s.reminderService.Defer(ctx, task, to.quietUntil); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to defer reminder")
+			tracing.Fail(span, err)
+			return
 		}
-
-		message := formatReminderMessage(task, user)
-		if err := s.sender.SendReminder(ctx, user.TelegramID, message, task.ID); err != nil {
-			log.Error().Err(err).Int64("task_id", task.ID).Msg("failed to send reminder")
-			continue
+		tracing.Skip(ctx, "quiet hours")
+		return
+	}
+	if to.summary && task.Deferred {
+		sum, ok := summaries[to.telegramID]
+		if !ok {
+			sum = &summary{to: to}
+			summaries[to.telegramID] = sum
 		}
+		sum.tasks = append(sum.tasks, task)
+		tracing.Skip(ctx, "batched into summary")
+		return
+	}
+
+	message := formatReminderMessage(to, task, decision)
+	if err := s.sender.SendReminder(ctx, to.telegramID, to.lang, message, task.ID); err != nil {
+		metrics.RemindersFailed.Inc()
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to send reminder")
+		tracing.Fail(span, err)
+		return
+	}
+	metrics.RemindersSent.Inc()
+
+	log.Info().Ctx(ctx).
+		Int64("task_id", task.ID).
+		Int64("chat_id", to.telegramID).
+		Int("reminder_number", task.RemindersSentToday+1).
+		Msg("reminder sent")
+
+	s.recordSent(ctx, task)
+}
 
-		if err := s.taskService.IncrementReminderCount(ctx, task); err != nil {
-			log.Error().Err(err).Int64("task_id", task.ID).Msg("failed to increment reminder count")
+// sendSummary sends the reminders deferred by quiet hours in one message.
+func (s *Scheduler) sendSummary(ctx context.Context, sum *summary) {
+	ctx, span := tracing.Start(ctx, "scheduler summary", trace.WithAttributes(
+		attribute.Int64("chat.id", sum.to.telegramID),
+		attribute.Int("summary.tasks", len(sum.tasks)),
+	))
+	defer span.End()
+
+	if err := s.sender.SendNotification(ctx, sum.to.telegramID, formatSummaryMessage(sum)); err != nil {
+		metrics.RemindersFailed.Add(float64(len(sum.tasks)))
+		log.Error().Ctx(ctx).Err(err).Int64("chat_id", sum.to.telegramID).Msg("failed to send reminder summary")
+		tracing.Fail(span, err)
+		return
+	}
+	metrics.RemindersSent.Add(float64(len(sum.tasks)))
+
+	log.Info().Ctx(ctx).
+		Int64("chat_id", sum.to.telegramID).
+		Int("tasks", len(sum.tasks)).
+		Msg("reminder summary sent")
+
+	for _, task := range sum.tasks {
+		s.recordSent(ctx, task)
+	}
+}
+
+// recordSent records a reminder of task as sent.
+func (s *Scheduler) recordSent(ctx context.Context, task *domain.Task) {
+	if err := s.reminderService.Sent(ctx, task); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to record reminder")
+	}
+	if err := s.taskService.IncrementReminderCount(ctx, task); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to increment reminder count")
+	}
+}
+
+// recipient is where a task's reminders go and whose working hours apply:
+// the owner of a personal task or the group of a shared one.
+type recipient struct {
+	telegramID      int64
+	location        *time.Location
+	workStartHour   int
+	workEndHour     int
+	workHoursPerDay int
+	lang            i18n.Lang
+	style           render.Style
+	// inactive is why reminders are not sent at the moment, if they are not.
+	inactive string
+	// quietUntil is when the recipient's quiet hours end, zero when they
+	// are not quiet; summary batches what they deferred into one message.
+	quietUntil time.Time
+	summary    bool
+}
+
+// recipientFor returns nil when the task's owner no longer exists.
+func (s *Scheduler) recipientFor(ctx context.Context, task *domain.Task) (*recipient, error) {
+	if task.ChatID != nil {
+		chat, err := s.chatRepo.GetByID(ctx, *task.ChatID)
+		if err != nil || chat == nil {
+			return nil, err
+		}
+		to := &recipient{
+			telegramID:      chat.TelegramID,
+			location:        chat.Location(),
+			workStartHour:   chat.WorkStartHour,
+			workEndHour:     chat.WorkEndHour,
+			workHoursPerDay: chat.WorkHoursPerDay,
+			lang:            i18n.Parse(chat.Language),
+			style:           render.DefaultStyle,
 		}
+		if chat.LeftAt != nil {
+			to.inactive = "bot left chat"
+		}
+		return to, nil
+	}
 
-		log.Info().
-			Int64("task_id", task.ID).
-			Int64("user_id", user.TelegramID).
-			Int("reminder_number", task.RemindersSentToday+1).
-			Msg("reminder sent")
+	user, err := s.userRepo.GetByID(ctx, task.UserID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	to := &recipient{
+		telegramID:      user.TelegramID,
+		location:        user.Location(),
+		workStartHour:   user.WorkStartHour,
+		workEndHour:     user.WorkEndHour,
+		workHoursPerDay: user.WorkHoursPerDay,
+		lang:            i18n.Parse(user.Language),
+		style:           render.ParseStyle(user.MessageStyle),
+	}
+	if user.IsBanned() {
+		to.inactive = "user banned"
 	}
+
+	until, quiet, err := s.quietService.Until(ctx, user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if quiet {
+		to.quietUntil = until
+	}
+	to.summary = user.QuietMode == domain.QuietSummary
+	return to, nil
 }
 
 func (s *Scheduler) resetDailyReminders(ctx context.Context) {
 	if err := s.taskService.ResetDailyReminders(ctx); err != nil {
-		log.Error().Err(err).Msg("failed to reset daily reminders")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to reset daily reminders")
 		return
 	}
-	log.Info().Msg("daily reminders reset")
+	log.Info().Ctx(ctx).Msg("daily reminders reset")
+
+	purged, err := s.reminderService.CloseDay(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to close reminder history for the day")
+		return
+	}
+	log.Info().Ctx(ctx).Int64("purged", purged).Msg("reminder history closed for the day")
 }
 
-func formatReminderMessage(task *domain.Task, user *domain.User) string {
-	days := task.DaysUntilDeadline()
-	hours := task.WorkHoursRemaining(user.WorkHoursPerDay)
+func (s *Scheduler) purgeArchive(ctx context.Context) {
+	purged, err := s.taskService.PurgeArchived(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to purge archived tasks")
+		return
+	}
+	log.Info().Ctx(ctx).Int64("purged", purged).Msg("archived tasks purged")
+}
 
-	daysText := "дней"
-	if days == 1 {
-		daysText = "день"
-	} else if days >= 2 && days <= 4 {
-		daysText = "дня"
+func (s *Scheduler) purgeDeleted(ctx context.Context) {
+	purged, err := s.taskService.PurgeDeleted(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to purge deleted tasks")
+		return
+	}
+	if purged > 0 {
+		log.Info().Ctx(ctx).Int64("purged", purged).Msg("deleted tasks purged")
 	}
+}
 
-	hoursText := "часов"
-	if hours == 1 {
-		hoursText = "час"
-	} else if hours >= 2 && hours <= 4 {
-		hoursText = "часа"
+// checkOverdue marks tasks whose deadline has passed in their owner's
+// timezone.
+func (s *Scheduler) checkOverdue(ctx context.Context) {
+	tasks, err := s.taskService.ListOverdueCandidates(ctx, earliestTomorrow())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list overdue tasks")
+		return
 	}
 
-	reminderNum := task.RemindersSentToday + 1
+	recipients := make(recipientCache)
+	for _, task := range tasks {
+		to := s.cachedRecipientFor(ctx, task, recipients)
+		if to == nil || !task.Deadline.Before(to.today()) {
+			continue
+		}
 
-	return fmt.Sprintf(`🔔 <b>Напоминание</b> (%d/%d за сегодня)
+		if err := s.taskService.MarkOverdue(ctx, task); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to mark task overdue")
+		}
+	}
+}
 
-📋 %s
+// resumePaused resumes paused tasks whose resume date has come in their
+// owner's timezone and lets the owner know.
+func (s *Scheduler) resumePaused(ctx context.Context) {
+	tasks, err := s.taskService.ListResumeCandidates(ctx, earliestTomorrow())
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to list paused tasks")
+		return
+	}
 
-⏰ До дедлайна: <b>%d %s</b>
-⏱ Рабочих часов: <b>%d %s</b>
-⚡ Важность: %s`,
-		reminderNum, task.Importance,
-		escapeHTML(task.Description),
-		days, daysText,
-		hours, hoursText,
-		task.ImportanceStars(),
-	)
+	recipients := make(recipientCache)
+	for _, task := range tasks {
+		to := s.cachedRecipientFor(ctx, task, recipients)
+		if to == nil || task.PausedUntil.After(to.today()) {
+			continue
+		}
+
+		if err := s.taskService.Resume(ctx, task); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to resume task")
+			continue
+		}
+		log.Info().Ctx(ctx).Int64("task_id", task.ID).Msg("paused task resumed")
+
+		if to.inactive != "" {
+			continue
+		}
+		if err := s.sender.SendNotification(ctx, to.telegramID, formatResumedMessage(to, task)); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to notify about resumed task")
+		}
+	}
+}
+
+// earliestTomorrow is tomorrow's date in UTC+14, the earliest timezone: jobs
+// acting on dates that have come list candidates before it and check each
+// owner's date.
+func earliestTomorrow() time.Time {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	return time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// today is the recipient's current date at midnight UTC, the way task dates
+// are stored.
+func (to *recipient) today() time.Time {
+	now := time.Now().In(to.location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// recipientCache holds the recipients of task owners, a user or a group,
+// for the duration of one job run.
+type recipientCache map[taskOwner]*recipient
+
+type taskOwner struct {
+	chat bool
+	id   int64
+}
+
+// cachedRecipientFor is recipientFor through cache. Errors are logged and
+// give nil, as does a deleted owner.
+func (s *Scheduler) cachedRecipientFor(ctx context.Context, task *domain.Task, cache recipientCache) *recipient {
+	key := taskOwner{id: task.UserID}
+	if task.ChatID != nil {
+		key = taskOwner{chat: true, id: *task.ChatID}
+	}
+
+	to, ok := cache[key]
+	if !ok {
+		var err error
+		to, err = s.recipientFor(ctx, task)
+		if err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Msg("failed to get recipient for task")
+			return nil
+		}
+		cache[key] = to
+	}
+	return to
+}
+
+func (s *Scheduler) updateTaskMetrics(ctx context.Context) {
+	counts, err := s.taskService.CountByState(ctx)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to count tasks")
+		return
+	}
+	metrics.SetTasks(counts)
+}
+
+func formatReminderMessage(to *recipient, task *domain.Task, decision ReminderDecision) string {
+	return render.Reminder(to.lang, to.style, render.ReminderView{
+		TaskView: render.NewTaskView(task, to.workHoursPerDay),
+		Number:   task.RemindersSentToday + 1,
+		PerDay:   decision.PerDay,
+		Tone:     decision.Tone,
+	})
+}
+
+func formatSummaryMessage(sum *summary) string {
+	l := sum.to.lang
+	view := render.DigestView{
+		Icon:   "🔕",
+		Title:  l.T("quiet.summary_title"),
+		Items:  make([]render.ItemView, 0, len(sum.tasks)),
+		Footer: l.T("quiet.summary_footer"),
+	}
+	for i, task := range sum.tasks {
+		view.Items = append(view.Items, render.ItemView{
+			TaskView: render.NewTaskView(task, sum.to.workHoursPerDay),
+			Number:   i + 1,
+		})
+	}
+	return render.Digest(l, sum.to.style, view)
 }
 
-func escapeHTML(s string) string {
-	s = strings.ReplaceAll(s, "&", "&amp;")
-	s = strings.ReplaceAll(s, "<", "&lt;")
-	s = strings.ReplaceAll(s, ">", "&gt;")
-	return s
+func formatResumedMessage(to *recipient, task *domain.Task) string {
+	return render.Confirmation(to.lang, to.style, render.ConfirmationView{
+		Title: to.lang.T("pause.resumed_auto"),
+		Task:  render.NewTaskView(task, to.workHoursPerDay),
+	})
 }
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/ical"
+	"telegram-reminder-bot/internal/service"
+)
+
+// RegisterCalendarFeed serves GET /calendar/{token}.ics with the active tasks
+// of the user owning the token.
+func (s *Server) RegisterCalendarFeed(userService *service.UserService, taskService *service.TaskService) {
+	s.Mux.HandleFunc("GET /calendar/{file}", func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+		if !ok || token == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		user, err := userService.GetByCalendarToken(r.Context(), token)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to get user by calendar token")
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if user == nil || user.IsBanned() {
+			http.NotFound(w, r)
+			return
+		}
+
+		tasks, err := taskService.GetActiveByUserID(r.Context(), user.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to get tasks for calendar feed")
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := ical.Encode(&buf, ical.TaskCalendar(tasks, user, time.Now())); err != nil {
+			log.Error().Err(err).Msg("failed to encode calendar feed")
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		w.Write(buf.Bytes())
+	})
+}
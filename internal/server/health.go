@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Check reports an error when a dependency is not usable.
+type Check func(ctx context.Context) error
+
+const checkTimeout = 5 * time.Second
+
+// RegisterHealth serves /healthz, which runs the liveness checks, and
+// /readyz, which runs the liveness and readiness checks. Both answer 200 when
+// every check passes and 503 otherwise, with the result of each check.
+func (s *Server) RegisterHealth(liveness, readiness map[string]Check) {
+	ready := make(map[string]Check, len(liveness)+len(readiness))
+	for name, check := range liveness {
+		ready[name] = check
+	}
+	for name, check := range readiness {
+		ready[name] = check
+	}
+
+	s.Mux.Handle("GET /healthz", healthHandler(liveness))
+	s.Mux.Handle("GET /readyz", healthHandler(ready))
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func healthHandler(checks map[string]Check) http.Handler {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		resp := healthResponse{Status: "ok", Checks: make(map[string]string, len(checks))}
+		for _, name := range names {
+			if err := checks[name](ctx); err != nil {
+				resp.Status = "unavailable"
+				resp.Checks[name] = err.Error()
+				continue
+			}
+			resp.Checks[name] = "ok"
+		}
+
+		status := http.StatusOK
+		if resp.Status != "ok" {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterHealth(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	liveness := map[string]Check{
+		"scheduler": func(context.Context) error { return nil },
+	}
+	readiness := map[string]Check{
+		"database": func(context.Context) error { return dbErr },
+	}
+
+	s := New(":0")
+	s.RegisterHealth(liveness, readiness)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantChecks map[string]string
+	}{
+		{"/healthz", http.StatusOK, map[string]string{"scheduler": "ok"}},
+		{"/readyz", http.StatusServiceUnavailable, map[string]string{"scheduler": "ok", "database": "connection refused"}},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.path, rec.Code, tt.wantStatus)
+		}
+
+		var resp healthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: decode: %v", tt.path, err)
+		}
+		if len(resp.Checks) != len(tt.wantChecks) {
+			t.Errorf("%s: checks = %v, want %v", tt.path, resp.Checks, tt.wantChecks)
+		}
+		for name, want := range tt.wantChecks {
+			if resp.Checks[name] != want {
+				t.Errorf("%s: check %s = %q, want %q", tt.path, name, resp.Checks[name], want)
+			}
+		}
+	}
+
+	readiness["database"] = func(context.Context) error { return nil }
+	s = New(":0")
+	s.RegisterHealth(liveness, readiness)
+	rec := httptest.NewRecorder()
+	s.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/readyz with healthy database: status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Server is the bot's HTTP endpoint. Features register their routes on Mux
+// before Start is called.
+type Server struct {
+	Mux *http.ServeMux
+	srv *http.Server
+}
+
+func New(addr string) *Server {
+	mux := http.NewServeMux()
+	return &Server{
+		Mux: mux,
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start serves in the background until Shutdown is called.
+func (s *Server) Start() {
+	go func() {
+		log.Info().Str("addr", s.srv.Addr).Msg("starting http server")
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("http server failed")
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// AccountExport is everything the bot stores about a user, in the shape it
+// is handed out by /export. Field names are part of the export format.
+type AccountExport struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	Profile    ExportProfile    `json:"profile"`
+	Settings   ExportSettings   `json:"settings"`
+	Tasks      []ExportTask     `json:"tasks"`
+	Reminders  []ExportReminder `json:"reminders"`
+	History    []ExportEvent    `json:"history"`
+	APITokens  []ExportAPIToken `json:"api_tokens"`
+	Webhooks   []ExportWebhook  `json:"webhooks"`
+
+	QuietPeriods []ExportQuietPeriod `json:"quiet_periods"`
+}
+
+type ExportProfile struct {
+	TelegramID int64     `json:"telegram_id"`
+	Username   string    `json:"username"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ExportSettings struct {
+	Timezone        string `json:"timezone"`
+	WorkHoursPerDay int    `json:"work_hours_per_day"`
+	WorkStartHour   int    `json:"work_start_hour"`
+	WorkEndHour     int    `json:"work_end_hour"`
+	Language        string `json:"language"`
+	MessageStyle    string `json:"message_style"`
+	QuietMode       string `json:"quiet_mode"`
+	CalendarFeed    bool   `json:"calendar_feed"`
+}
+
+type ExportTask struct {
+	ID                 int64      `json:"id"`
+	Description        string     `json:"description"`
+	Deadline           string     `json:"deadline"`
+	Importance         int        `json:"importance"`
+	Frequency          string     `json:"frequency"`
+	IsCompleted        bool       `json:"is_completed"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+	LastReminderDate   *time.Time `json:"last_reminder_date,omitempty"`
+	RemindersSentToday int        `json:"reminders_sent_today"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	Attachments []ExportAttachment `json:"attachments,omitempty"`
+}
+
+// ExportAttachment refers to files by their Telegram file ID; forwarded
+// messages are exported with their caption only.
+type ExportAttachment struct {
+	Kind      string    `json:"kind"`
+	FileID    string    `json:"file_id,omitempty"`
+	FileName  string    `json:"file_name,omitempty"`
+	Caption   string    `json:"caption,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportReminder is a reminder sent about a task and what the user did
+// with it.
+type ExportReminder struct {
+	TaskID      int64      `json:"task_id"`
+	Number      int        `json:"number"`
+	Outcome     string     `json:"outcome"`
+	SentAt      time.Time  `json:"sent_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+type ExportEvent struct {
+	TaskID     int64     `json:"task_id"`
+	Type       string    `json:"type"`
+	Importance int       `json:"importance"`
+	Deadline   string    `json:"deadline"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportAPIToken describes an issued token. The token itself is not
+// recoverable and is not exported.
+type ExportAPIToken struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// ExportWebhook omits the signing secret.
+type ExportWebhook struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportQuietPeriod has the times of day in the user's timezone and the
+// days the period starts on, empty for every day.
+type ExportQuietPeriod struct {
+	Times     string    `json:"times"`
+	Weekdays  []string  `json:"weekdays,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AccountService struct {
+	userRepo       repository.UserRepository
+	taskRepo       repository.TaskRepository
+	eventRepo      repository.EventRepository
+	tokenRepo      repository.APITokenRepository
+	webhookRepo    repository.WebhookRepository
+	reminderRepo   repository.ReminderRepository
+	quietRepo      repository.QuietPeriodRepository
+	attachmentRepo repository.AttachmentRepository
+	inviteRepo     repository.InviteRepository
+}
+
+func NewAccountService(userRepo repository.UserRepository, taskRepo repository.TaskRepository, eventRepo repository.EventRepository, tokenRepo repository.APITokenRepository, webhookRepo repository.WebhookRepository, reminderRepo repository.ReminderRepository, quietRepo repository.QuietPeriodRepository, attachmentRepo repository.AttachmentRepository, inviteRepo repository.InviteRepository) *AccountService {
+	return &AccountService{
+		userRepo:       userRepo,
+		taskRepo:       taskRepo,
+		eventRepo:      eventRepo,
+		tokenRepo:      tokenRepo,
+		webhookRepo:    webhookRepo,
+		reminderRepo:   reminderRepo,
+		quietRepo:      quietRepo,
+		attachmentRepo: attachmentRepo,
+		inviteRepo:     inviteRepo,
+	}
+}
+
+// Export collects the user's profile, settings, tasks with their
+// attachments, reminders sent and what became of them, full history, issued
+// API tokens, webhooks and quiet periods.
+func (s *AccountService) Export(ctx context.Context, user *domain.User) (*AccountExport, error) {
+	tasks, err := s.taskRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+
+	reminders, err := s.reminderRepo.ListByUserID(ctx, user.ID, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+
+	events, err := s.eventRepo.ListByUserID(ctx, user.ID, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	tokens, err := s.tokenRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+
+	webhooks, err := s.webhookRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	periods, err := s.quietRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list quiet periods: %w", err)
+	}
+
+	attachments, err := s.attachmentRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	attachmentsByTask := make(map[int64][]ExportAttachment)
+	for _, a := range attachments {
+		attachmentsByTask[a.TaskID] = append(attachmentsByTask[a.TaskID], ExportAttachment{
+			Kind:      string(a.Kind),
+			FileID:    a.FileID,
+			FileName:  a.FileName,
+			Caption:   a.Caption,
+			URL:       a.URL,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+
+	export := &AccountExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: ExportProfile{
+			TelegramID: user.TelegramID,
+			Username:   user.Username,
+			CreatedAt:  user.CreatedAt,
+		},
+		Settings: ExportSettings{
+			Timezone:        user.Timezone,
+			WorkHoursPerDay: user.WorkHoursPerDay,
+			WorkStartHour:   user.WorkStartHour,
+			WorkEndHour:     user.WorkEndHour,
+			Language:        user.Language,
+			MessageStyle:    user.MessageStyle,
+			QuietMode:       user.QuietMode,
+			CalendarFeed:    user.CalendarToken != "",
+		},
+		Tasks:     make([]ExportTask, 0, len(tasks)),
+		Reminders: make([]ExportReminder, 0, len(reminders)),
+		History:   make([]ExportEvent, 0, len(events)),
+		APITokens: make([]ExportAPIToken, 0, len(tokens)),
+		Webhooks:  make([]ExportWebhook, 0, len(webhooks)),
+
+		QuietPeriods: make([]ExportQuietPeriod, 0, len(periods)),
+	}
+
+	for _, t := range tasks {
+		export.Tasks = append(export.Tasks, ExportTask{
+			ID:                 t.ID,
+			Description:        t.Description,
+			Deadline:           t.Deadline.Format(time.DateOnly),
+			Importance:         t.Importance,
+			Frequency:          t.Frequency.String(),
+			IsCompleted:        t.IsCompleted,
+			CompletedAt:        t.CompletedAt,
+			LastReminderDate:   t.LastReminderDate,
+			RemindersSentToday: t.RemindersSentToday,
+			CreatedAt:          t.CreatedAt,
+			UpdatedAt:          t.UpdatedAt,
+			Attachments:        attachmentsByTask[t.ID],
+		})
+	}
+
+	for _, r := range reminders {
+		export.Reminders = append(export.Reminders, ExportReminder{
+			TaskID:      r.TaskID,
+			Number:      r.Number,
+			Outcome:     string(r.Outcome),
+			SentAt:      r.SentAt,
+			RespondedAt: r.RespondedAt,
+		})
+	}
+
+	for _, e := range events {
+		export.History = append(export.History, ExportEvent{
+			TaskID:     e.TaskID,
+			Type:       string(e.Type),
+			Importance: e.Importance,
+			Deadline:   e.Deadline.Format(time.DateOnly),
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	for _, t := range tokens {
+		export.APITokens = append(export.APITokens, ExportAPIToken{
+			CreatedAt:  t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+		})
+	}
+
+	for _, w := range webhooks {
+		export.Webhooks = append(export.Webhooks, ExportWebhook{
+			URL:       w.URL,
+			CreatedAt: w.CreatedAt,
+		})
+	}
+
+	for _, p := range periods {
+		var weekdays []string
+		for _, d := range p.Weekdays.Days() {
+			weekdays = append(weekdays, d.String())
+		}
+		export.QuietPeriods = append(export.QuietPeriods, ExportQuietPeriod{
+			Times:     p.Times(),
+			Weekdays:  weekdays,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+
+	return export, nil
+}
+
+// Delete removes the user and, through ON DELETE CASCADE, every row that
+// belongs to them. Shared tasks they created stay with their groups, and
+// invite codes, which refer to users by Telegram ID, forget them.
+func (s *AccountService) Delete(ctx context.Context, user *domain.User) error {
+	if err := s.taskRepo.DetachShared(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.inviteRepo.Forget(ctx, user.TelegramID); err != nil {
+		return err
+	}
+	return s.userRepo.Delete(ctx, user.ID)
+}
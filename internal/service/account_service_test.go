@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// Stub repositories behind the AccountService under test: they return what
+// they were given and record what was deleted. The embedded interfaces
+// leave every other method unimplemented.
+
+type accountUsers struct {
+	repository.UserRepository
+	deleted []int64
+}
+
+func (r *accountUsers) Delete(_ context.Context, id int64) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type accountTasks struct {
+	repository.TaskRepository
+	tasks    []*domain.Task
+	detached []int64
+}
+
+func (r *accountTasks) ListByUserID(context.Context, int64) ([]*domain.Task, error) {
+	return r.tasks, nil
+}
+
+func (r *accountTasks) DetachShared(_ context.Context, userID int64) error {
+	r.detached = append(r.detached, userID)
+	return nil
+}
+
+type accountEvents struct {
+	repository.EventRepository
+	events []*domain.TaskEvent
+}
+
+func (r *accountEvents) ListByUserID(context.Context, int64, time.Time) ([]*domain.TaskEvent, error) {
+	return r.events, nil
+}
+
+type accountTokens struct {
+	repository.APITokenRepository
+	tokens []*domain.APIToken
+}
+
+func (r *accountTokens) ListByUserID(context.Context, int64) ([]*domain.APIToken, error) {
+	return r.tokens, nil
+}
+
+type accountWebhooks struct {
+	repository.WebhookRepository
+	webhooks []*domain.Webhook
+}
+
+func (r *accountWebhooks) ListByUserID(context.Context, int64) ([]*domain.Webhook, error) {
+	return r.webhooks, nil
+}
+
+type accountReminders struct {
+	repository.ReminderRepository
+	reminders []*domain.Reminder
+}
+
+func (r *accountReminders) ListByUserID(context.Context, int64, time.Time) ([]*domain.Reminder, error) {
+	return r.reminders, nil
+}
+
+type accountQuietPeriods struct {
+	repository.QuietPeriodRepository
+	periods []*domain.QuietPeriod
+}
+
+func (r *accountQuietPeriods) ListByUserID(context.Context, int64) ([]*domain.QuietPeriod, error) {
+	return r.periods, nil
+}
+
+type accountAttachments struct {
+	repository.AttachmentRepository
+	attachments []*domain.Attachment
+}
+
+func (r *accountAttachments) ListByUserID(context.Context, int64) ([]*domain.Attachment, error) {
+	return r.attachments, nil
+}
+
+type accountInvites struct {
+	repository.InviteRepository
+	forgotten []int64
+}
+
+func (r *accountInvites) Forget(_ context.Context, telegramID int64) error {
+	r.forgotten = append(r.forgotten, telegramID)
+	return nil
+}
+
+type accountRepos struct {
+	users       *accountUsers
+	tasks       *accountTasks
+	events      *accountEvents
+	tokens      *accountTokens
+	webhooks    *accountWebhooks
+	reminders   *accountReminders
+	quiet       *accountQuietPeriods
+	attachments *accountAttachments
+	invites     *accountInvites
+}
+
+func newAccountService() (*AccountService, *accountRepos) {
+	r := &accountRepos{
+		users:       &accountUsers{},
+		tasks:       &accountTasks{},
+		events:      &accountEvents{},
+		tokens:      &accountTokens{},
+		webhooks:    &accountWebhooks{},
+		reminders:   &accountReminders{},
+		quiet:       &accountQuietPeriods{},
+		attachments: &accountAttachments{},
+		invites:     &accountInvites{},
+	}
+	return NewAccountService(r.users, r.tasks, r.events, r.tokens, r.webhooks, r.reminders, r.quiet, r.attachments, r.invites), r
+}
+
+func TestAccountService_Export(t *testing.T) {
+	created := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	done := time.Date(2025, 2, 27, 16, 30, 0, 0, time.UTC)
+	user := &domain.User{
+		ID: 7, TelegramID: 1001, Username: "alice", Timezone: "Europe/Moscow",
+		WorkHoursPerDay: 8, WorkStartHour: 9, WorkEndHour: 18, CalendarToken: "calendar-secret", CreatedAt: created,
+	}
+
+	s, r := newAccountService()
+	r.tasks.tasks = []*domain.Task{{
+		ID: 1, UserID: 7, Description: "Report", Deadline: deadline, Importance: 4, Frequency: domain.FrequencyDaily,
+		IsCompleted: true, CompletedAt: &done, CreatedAt: created, UpdatedAt: done,
+	}}
+	r.events.events = []*domain.TaskEvent{
+		{TaskID: 1, UserID: 7, Type: domain.TaskEventCompleted, Importance: 4, Deadline: deadline, CreatedAt: done},
+	}
+	r.tokens.tokens = []*domain.APIToken{{ID: 3, UserID: 7, TokenHash: "token-hash", CreatedAt: created}}
+	r.webhooks.webhooks = []*domain.Webhook{{ID: 4, UserID: 7, URL: "https://example.com/hook", Secret: "hook-secret", CreatedAt: created}}
+	r.quiet.periods = []*domain.QuietPeriod{{ID: 5, UserID: 7, Start: 780, End: 840, Weekdays: 1<<time.Monday | 1<<time.Friday, CreatedAt: created}}
+
+	export, err := s.Export(context.Background(), user)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if export.Profile != (ExportProfile{TelegramID: 1001, Username: "alice", CreatedAt: created}) {
+		t.Errorf("Profile = %+v", export.Profile)
+	}
+	if s := export.Settings; s.Timezone != "Europe/Moscow" || s.WorkHoursPerDay != 8 || !s.CalendarFeed {
+		t.Errorf("Settings = %+v", s)
+	}
+	if len(export.Tasks) != 1 {
+		t.Fatalf("Tasks = %+v, want one", export.Tasks)
+	}
+	if task := export.Tasks[0]; task.Description != "Report" || task.Deadline != "2025-03-01" || task.Frequency != "daily" ||
+		!task.IsCompleted || task.CompletedAt == nil || !task.CompletedAt.Equal(done) {
+		t.Errorf("Tasks[0] = %+v", task)
+	}
+	if len(export.History) != 1 || export.History[0].Type != "completed" || export.History[0].Deadline != "2025-03-01" {
+		t.Errorf("History = %+v", export.History)
+	}
+	if len(export.APITokens) != 1 || !export.APITokens[0].CreatedAt.Equal(created) {
+		t.Errorf("APITokens = %+v", export.APITokens)
+	}
+	if len(export.Webhooks) != 1 || export.Webhooks[0].URL != "https://example.com/hook" {
+		t.Errorf("Webhooks = %+v", export.Webhooks)
+	}
+	if len(export.QuietPeriods) != 1 || export.QuietPeriods[0].Times != "13:00-14:00" ||
+		strings.Join(export.QuietPeriods[0].Weekdays, ",") != "Monday,Friday" {
+		t.Errorf("QuietPeriods = %+v", export.QuietPeriods)
+	}
+
+	// Credentials are never handed out.
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, secret := range []string{"calendar-secret", "token-hash", "hook-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("export contains %q: %s", secret, data)
+		}
+	}
+}
+
+func TestAccountService_ExportReminders(t *testing.T) {
+	sent := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	responded := sent.Add(time.Hour)
+
+	s, r := newAccountService()
+	r.reminders.reminders = []*domain.Reminder{
+		{TaskID: 1, UserID: 7, Number: 1, Outcome: domain.ReminderSnoozed, SentAt: sent, RespondedAt: &responded},
+		{TaskID: 1, UserID: 7, Number: 2, Outcome: domain.ReminderIgnored, SentAt: sent.Add(3 * time.Hour)},
+	}
+
+	export, err := s.Export(context.Background(), &domain.User{ID: 7})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	want := []ExportReminder{
+		{TaskID: 1, Number: 1, Outcome: "snoozed", SentAt: sent, RespondedAt: &responded},
+		{TaskID: 1, Number: 2, Outcome: "ignored", SentAt: sent.Add(3 * time.Hour)},
+	}
+	if !reflect.DeepEqual(export.Reminders, want) {
+		t.Errorf("Reminders = %+v, want %+v", export.Reminders, want)
+	}
+}
+
+func TestAccountService_ExportAttachments(t *testing.T) {
+	deadline := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	s, r := newAccountService()
+	r.tasks.tasks = []*domain.Task{
+		{ID: 1, UserID: 7, Description: "Report", Deadline: deadline, Frequency: domain.FrequencyDaily},
+		{ID: 2, UserID: 7, Description: "Call", Deadline: deadline, Frequency: domain.FrequencyDaily},
+	}
+	r.attachments.attachments = []*domain.Attachment{
+		{ID: 10, TaskID: 1, Kind: domain.AttachmentDocument, FileID: "BQAC", FileName: "q3.pdf"},
+		{ID: 11, TaskID: 1, Kind: domain.AttachmentLink, URL: "https://example.com/q3"},
+	}
+
+	export, err := s.Export(context.Background(), &domain.User{ID: 7})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	got := export.Tasks[0].Attachments
+	if len(got) != 2 || got[0].FileID != "BQAC" || got[0].FileName != "q3.pdf" || got[1].URL != "https://example.com/q3" {
+		t.Errorf("task 1 attachments = %+v", got)
+	}
+	if got := export.Tasks[1].Attachments; got != nil {
+		t.Errorf("task 2 attachments = %+v, want none", got)
+	}
+
+	data, err := json.Marshal(export.Tasks[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"attachments":[{"kind":"document","file_id":"BQAC"`) {
+		t.Errorf("exported task = %s", data)
+	}
+}
+
+func TestAccountService_Delete(t *testing.T) {
+	s, r := newAccountService()
+	if err := s.Delete(context.Background(), &domain.User{ID: 7, TelegramID: 1001}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if len(r.users.deleted) != 1 || r.users.deleted[0] != 7 {
+		t.Errorf("deleted users = %v, want [7]", r.users.deleted)
+	}
+	if len(r.tasks.detached) != 1 || r.tasks.detached[0] != 7 {
+		t.Errorf("detached shared tasks of %v, want [7]", r.tasks.detached)
+	}
+	if len(r.invites.forgotten) != 1 || r.invites.forgotten[0] != 1001 {
+		t.Errorf("forgotten in invites = %v, want [1001]", r.invites.forgotten)
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// inviteTTL is how long an unused invite code stays valid.
+const inviteTTL = 7 * 24 * time.Hour
+
+// AdminService backs the operator commands. It does not check permissions;
+// the bot only routes admins' commands here.
+type AdminService struct {
+	userRepo   repository.UserRepository
+	taskRepo   repository.TaskRepository
+	inviteRepo repository.InviteRepository
+}
+
+func NewAdminService(userRepo repository.UserRepository, taskRepo repository.TaskRepository, inviteRepo repository.InviteRepository) *AdminService {
+	return &AdminService{userRepo: userRepo, taskRepo: taskRepo, inviteRepo: inviteRepo}
+}
+
+type AdminStats struct {
+	Users domain.UserCounts
+	// Tasks counts tasks by active, overdue, completed and deleted.
+	Tasks map[string]int
+}
+
+// Stats counts users, with Recent covering registrations since the given
+// time, and tasks across all users.
+func (s *AdminService) Stats(ctx context.Context, since time.Time) (*AdminStats, error) {
+	users, err := s.userRepo.Count(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("count users: %w", err)
+	}
+
+	tasks, err := s.taskRepo.CountByState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count tasks: %w", err)
+	}
+
+	return &AdminStats{Users: users, Tasks: tasks}, nil
+}
+
+type AdminUserInfo struct {
+	User           *domain.User
+	ActiveTasks    int
+	CompletedTasks int
+}
+
+// UserInfo describes the user with the given Telegram ID, or returns nil
+// if there is none.
+func (s *AdminService) UserInfo(ctx context.Context, telegramID int64) (*AdminUserInfo, error) {
+	user, err := s.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+
+	info := &AdminUserInfo{User: user}
+	for _, task := range tasks {
+		switch {
+		case task.DeletedAt != nil:
+		case task.IsCompleted:
+			info.CompletedTasks++
+		default:
+			info.ActiveTasks++
+		}
+	}
+
+	return info, nil
+}
+
+// SetBanned blocks or unblocks the user with the given Telegram ID. It
+// returns nil if there is no such user.
+func (s *AdminService) SetBanned(ctx context.Context, telegramID int64, banned bool) (*domain.User, error) {
+	user, err := s.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.SetBanned(ctx, user.ID, banned); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// BroadcastRecipients returns the Telegram IDs of all users who are not
+// banned.
+func (s *AdminService) BroadcastRecipients(ctx context.Context) ([]int64, error) {
+	return s.userRepo.ListTelegramIDs(ctx)
+}
+
+// CreateInvite issues a single-use invite code on behalf of the admin with
+// the given Telegram ID.
+func (s *AdminService) CreateInvite(ctx context.Context, createdBy int64) (*domain.Invite, error) {
+	code, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &domain.Invite{
+		// 64 bits are plenty for a single-use code and keep the link short.
+		Code:      code[:16],
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(inviteTTL),
+	}
+	if err := s.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// RedeemInvite uses up an invite code for a new user. It reports whether
+// the code was valid.
+func (s *AdminService) RedeemInvite(ctx context.Context, code string, telegramID int64) (bool, error) {
+	if code == "" {
+		return false, nil
+	}
+	return s.inviteRepo.Redeem(ctx, code, telegramID)
+}
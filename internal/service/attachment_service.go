@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/tracing"
+)
+
+// AttachmentService manages the files, forwarded messages and links
+// attached to tasks.
+type AttachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+}
+
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository) *AttachmentService {
+	return &AttachmentService{attachmentRepo: attachmentRepo}
+}
+
+// Add attaches attachment to task, up to domain.MaxAttachmentsPerTask.
+func (s *AttachmentService) Add(ctx context.Context, task *domain.Task, attachment *domain.Attachment) error {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Add")
+	defer span.End()
+
+	count, err := s.attachmentRepo.CountByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if count >= domain.MaxAttachmentsPerTask {
+		return domain.ErrTooManyAttachments
+	}
+
+	attachment.TaskID = task.ID
+	return s.attachmentRepo.Create(ctx, attachment)
+}
+
+func (s *AttachmentService) GetByID(ctx context.Context, id int64) (*domain.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetByID")
+	defer span.End()
+
+	return s.attachmentRepo.GetByID(ctx, id)
+}
+
+func (s *AttachmentService) List(ctx context.Context, taskID int64) ([]*domain.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.List")
+	defer span.End()
+
+	return s.attachmentRepo.ListByTaskID(ctx, taskID)
+}
+
+func (s *AttachmentService) Count(ctx context.Context, taskID int64) (int, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Count")
+	defer span.End()
+
+	return s.attachmentRepo.CountByTaskID(ctx, taskID)
+}
+
+func (s *AttachmentService) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Delete")
+	defer span.End()
+
+	return s.attachmentRepo.Delete(ctx, id)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+type ChatService struct {
+	chatRepo repository.ChatRepository
+}
+
+func NewChatService(chatRepo repository.ChatRepository) *ChatService {
+	return &ChatService{chatRepo: chatRepo}
+}
+
+// GetOrCreate returns the group chat, registering it on first use with the
+// settings of the member who triggered it. A chat the bot had left is
+// marked active again.
+func (s *ChatService) GetOrCreate(ctx context.Context, telegramID int64, title string, creator *domain.User) (*domain.Chat, error) {
+	chat, err := s.chatRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		chat = domain.NewChat(telegramID, title, creator)
+		if err := s.chatRepo.Create(ctx, chat); err != nil {
+			return nil, err
+		}
+	}
+
+	if chat.LeftAt != nil {
+		if err := s.chatRepo.SetLeft(ctx, chat.ID, false); err != nil {
+			return nil, err
+		}
+		chat.LeftAt = nil
+	}
+
+	return chat, nil
+}
+
+func (s *ChatService) GetByID(ctx context.Context, id int64) (*domain.Chat, error) {
+	return s.chatRepo.GetByID(ctx, id)
+}
+
+func (s *ChatService) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.Chat, error) {
+	return s.chatRepo.GetByTelegramID(ctx, telegramID)
+}
+
+// SetLeft records whether the bot is currently a member of the chat.
+// Reminders for a chat the bot has left are not sent.
+func (s *ChatService) SetLeft(ctx context.Context, chat *domain.Chat, left bool) error {
+	return s.chatRepo.SetLeft(ctx, chat.ID, left)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/tracing"
+)
+
+// DependencyService manages which tasks block which. A blocked task gets no
+// reminders until its blockers are completed; TaskService reports when that
+// happens.
+type DependencyService struct {
+	depRepo  repository.DependencyRepository
+	taskRepo repository.TaskRepository
+}
+
+func NewDependencyService(depRepo repository.DependencyRepository, taskRepo repository.TaskRepository) *DependencyService {
+	return &DependencyService{depRepo: depRepo, taskRepo: taskRepo}
+}
+
+// ForUser returns the dependency graph of the user's personal tasks.
+func (s *DependencyService) ForUser(ctx context.Context, userID int64) (*domain.Dependencies, error) {
+	ctx, span := tracing.Start(ctx, "DependencyService.ForUser")
+	defer span.End()
+
+	deps, err := s.depRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.taskRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDependencies(deps, active), nil
+}
+
+// ForChat returns the dependency graph of the shared tasks of a group chat.
+func (s *DependencyService) ForChat(ctx context.Context, chatID int64) (*domain.Dependencies, error) {
+	ctx, span := tracing.Start(ctx, "DependencyService.ForChat")
+	defer span.End()
+
+	deps, err := s.depRepo.ListByChatID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.taskRepo.GetActiveByChatID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDependencies(deps, active), nil
+}
+
+// ForTask returns the dependency graph task belongs to.
+func (s *DependencyService) ForTask(ctx context.Context, task *domain.Task) (*domain.Dependencies, error) {
+	if task.ChatID != nil {
+		return s.ForChat(ctx, *task.ChatID)
+	}
+	return s.ForUser(ctx, task.UserID)
+}
+
+// Add makes task wait for blocker. Both must be active tasks of the same
+// user or group, and blocker must not already wait for task, directly or
+// through other tasks.
+func (s *DependencyService) Add(ctx context.Context, task, blocker *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "DependencyService.Add")
+	defer span.End()
+
+	if task.ID == blocker.ID || task.IsCompleted || blocker.IsCompleted || !sameOwner(task, blocker) {
+		return domain.ErrInvalidDependency
+	}
+
+	graph, err := s.ForTask(ctx, task)
+	if err != nil {
+		return err
+	}
+	if graph.Has(task.ID, blocker.ID) {
+		return nil
+	}
+	if graph.WouldCycle(task.ID, blocker.ID) {
+		return domain.ErrDependencyCycle
+	}
+
+	return s.depRepo.Create(ctx, &domain.TaskDependency{TaskID: task.ID, BlockerID: blocker.ID})
+}
+
+// Remove stops task waiting for blockerID.
+func (s *DependencyService) Remove(ctx context.Context, task *domain.Task, blockerID int64) error {
+	ctx, span := tracing.Start(ctx, "DependencyService.Remove")
+	defer span.End()
+
+	return s.depRepo.Delete(ctx, task.ID, blockerID)
+}
+
+// sameOwner reports whether a and b are personal tasks of the same user or
+// shared tasks of the same group.
+func sameOwner(a, b *domain.Task) bool {
+	if a.ChatID != nil || b.ChatID != nil {
+		return a.ChatID != nil && b.ChatID != nil && *a.ChatID == *b.ChatID
+	}
+	return a.UserID == b.UserID
+}
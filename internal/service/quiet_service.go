@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// maxQuietPeriodsPerUser limits how many quiet periods one user can set up.
+const maxQuietPeriodsPerUser = 10
+
+var ErrTooManyQuietPeriods = errors.New("too many quiet periods")
+
+// QuietService manages the times users get no reminders: Do Not Disturb
+// and recurring quiet periods. Whenever they change, tasks deferred by the
+// old ones are released for the scheduler to decide about afresh.
+type QuietService struct {
+	quietRepo repository.QuietPeriodRepository
+	userRepo  repository.UserRepository
+	taskRepo  repository.TaskRepository
+}
+
+func NewQuietService(quietRepo repository.QuietPeriodRepository, userRepo repository.UserRepository, taskRepo repository.TaskRepository) *QuietService {
+	return &QuietService{quietRepo: quietRepo, userRepo: userRepo, taskRepo: taskRepo}
+}
+
+// SetDND mutes reminders until the given time, or unmutes them when until
+// is nil.
+func (s *QuietService) SetDND(ctx context.Context, user *domain.User, until *time.Time) error {
+	user.DNDUntil = until
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.taskRepo.ReleaseDeferred(ctx, user.ID)
+}
+
+// SetMode sets how reminders due during quiet hours are delivered.
+func (s *QuietService) SetMode(ctx context.Context, user *domain.User, mode string) error {
+	user.QuietMode = mode
+	return s.userRepo.Update(ctx, user)
+}
+
+func (s *QuietService) Periods(ctx context.Context, user *domain.User) ([]*domain.QuietPeriod, error) {
+	return s.quietRepo.ListByUserID(ctx, user.ID)
+}
+
+// AddPeriod parses and saves a quiet period such as "mon-fri 13:00-14:00".
+func (s *QuietService) AddPeriod(ctx context.Context, user *domain.User, text string) (*domain.QuietPeriod, error) {
+	period, err := domain.ParseQuietPeriod(text)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.quietRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxQuietPeriodsPerUser {
+		return nil, ErrTooManyQuietPeriods
+	}
+
+	period.UserID = user.ID
+	if err := s.quietRepo.Create(ctx, period); err != nil {
+		return nil, err
+	}
+	return period, s.taskRepo.ReleaseDeferred(ctx, user.ID)
+}
+
+// DeletePeriod removes a quiet period owned by user. It reports false if
+// there is no such period.
+func (s *QuietService) DeletePeriod(ctx context.Context, user *domain.User, id int64) (bool, error) {
+	period, err := s.quietRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if period == nil || period.UserID != user.ID {
+		return false, nil
+	}
+	if err := s.quietRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, s.taskRepo.ReleaseDeferred(ctx, user.ID)
+}
+
+// Until returns when the user's quiet hours covering now end; ok is false
+// when reminders may be sent.
+func (s *QuietService) Until(ctx context.Context, user *domain.User, now time.Time) (until time.Time, ok bool, err error) {
+	periods, err := s.quietRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	until, ok = domain.QuietUntil(now.In(user.Location()), user.DNDUntil, periods)
+	return until, ok, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/tracing"
+)
+
+// ReminderHistory is how far back the reminder history goes; older
+// reminders are purged.
+const ReminderHistory = 14 * 24 * time.Hour
+
+// ReminderService records the reminders sent for tasks and what users did
+// about them, the history the adaptive reminder policy learns from.
+type ReminderService struct {
+	reminderRepo repository.ReminderRepository
+	taskRepo     repository.TaskRepository
+}
+
+func NewReminderService(reminderRepo repository.ReminderRepository, taskRepo repository.TaskRepository) *ReminderService {
+	return &ReminderService{reminderRepo: reminderRepo, taskRepo: taskRepo}
+}
+
+// Sent records the next reminder of task. The task's previous reminder, if
+// it is still unanswered, counts as ignored.
+func (s *ReminderService) Sent(ctx context.Context, task *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "ReminderService.Sent")
+	defer span.End()
+
+	reminder := domain.NewReminder(task)
+	if err := s.reminderRepo.IgnorePending(ctx, task.ID, time.Now()); err != nil {
+		return err
+	}
+	return s.reminderRepo.Create(ctx, reminder)
+}
+
+// Respond records the user's response to the task's latest reminder. It
+// does nothing when that reminder was answered or ignored already.
+func (s *ReminderService) Respond(ctx context.Context, taskID int64, outcome domain.ReminderOutcome) error {
+	ctx, span := tracing.Start(ctx, "ReminderService.Respond")
+	defer span.End()
+
+	return s.reminderRepo.Respond(ctx, taskID, outcome)
+}
+
+// Snooze postpones the task's next reminder by d.
+func (s *ReminderService) Snooze(ctx context.Context, id int64, d time.Duration) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.Snooze")
+	defer span.End()
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	until := time.Now().Add(d)
+	if err := s.taskRepo.Snooze(ctx, task.ID, until, false); err != nil {
+		return nil, err
+	}
+	task.SnoozedUntil = &until
+	task.Deferred = false
+	if err := s.reminderRepo.Respond(ctx, task.ID, domain.ReminderSnoozed); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Defer holds the task's due reminder back until the owner's quiet hours
+// end. Unlike Snooze it is not a response to a reminder.
+func (s *ReminderService) Defer(ctx context.Context, task *domain.Task, until time.Time) error {
+	ctx, span := tracing.Start(ctx, "ReminderService.Defer")
+	defer span.End()
+
+	if err := s.taskRepo.Snooze(ctx, task.ID, until, true); err != nil {
+		return err
+	}
+	task.SnoozedUntil = &until
+	task.Deferred = true
+	return nil
+}
+
+// History returns the reminders within ReminderHistory of the task's owner,
+// oldest first: the group's for a shared task, the user's personal ones
+// otherwise.
+func (s *ReminderService) History(ctx context.Context, task *domain.Task) ([]*domain.Reminder, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.History")
+	defer span.End()
+
+	since := time.Now().Add(-ReminderHistory)
+	if task.ChatID != nil {
+		return s.reminderRepo.ListByChatID(ctx, *task.ChatID, since)
+	}
+	return s.reminderRepo.ListByUserID(ctx, task.UserID, since)
+}
+
+// CloseDay marks every reminder still unanswered as ignored and purges
+// reminders older than ReminderHistory.
+func (s *ReminderService) CloseDay(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.CloseDay")
+	defer span.End()
+
+	now := time.Now()
+	if err := s.reminderRepo.IgnorePending(ctx, 0, now); err != nil {
+		return 0, err
+	}
+	return s.reminderRepo.DeleteBefore(ctx, now.Add(-ReminderHistory))
+}
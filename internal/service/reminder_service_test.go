@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// historyReminders returns one reminder for the user or chat it is asked
+// about.
+type historyReminders struct{ repository.ReminderRepository }
+
+func (historyReminders) ListByUserID(_ context.Context, userID int64, _ time.Time) ([]*domain.Reminder, error) {
+	return []*domain.Reminder{{TaskID: 1, UserID: userID}}, nil
+}
+
+func (historyReminders) ListByChatID(_ context.Context, chatID int64, _ time.Time) ([]*domain.Reminder, error) {
+	return []*domain.Reminder{{TaskID: 2, ChatID: &chatID}}, nil
+}
+
+// The reminders of a shared task are the group's: they neither make up nor
+// come from the history of the member who created it.
+func TestReminderService_HistoryOfOwner(t *testing.T) {
+	s := NewReminderService(historyReminders{}, nil)
+	chatID := int64(3)
+
+	personal, err := s.History(context.Background(), &domain.Task{ID: 1, UserID: 7})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(personal) != 1 || personal[0].UserID != 7 || personal[0].ChatID != nil {
+		t.Errorf("History() of a personal task = %+v, want the user's", personal)
+	}
+
+	shared, err := s.History(context.Background(), &domain.Task{ID: 2, UserID: 7, ChatID: &chatID})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(shared) != 1 || shared[0].ChatID == nil || *shared[0].ChatID != 3 {
+		t.Errorf("History() of a shared task = %+v, want the group's", shared)
+	}
+
+	if r := domain.NewReminder(&domain.Task{ID: 2, UserID: 7, ChatID: &chatID}); r.ChatID == nil || *r.ChatID != 3 {
+		t.Errorf("NewReminder() ChatID = %v, want the group", r.ChatID)
+	}
+}
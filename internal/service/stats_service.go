@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// statsHistory is how far back events are loaded for statistics.
+const statsHistory = 365 * 24 * time.Hour
+
+type StatsService struct {
+	eventRepo repository.EventRepository
+	taskRepo  repository.TaskRepository
+}
+
+func NewStatsService(eventRepo repository.EventRepository, taskRepo repository.TaskRepository) *StatsService {
+	return &StatsService{eventRepo: eventRepo, taskRepo: taskRepo}
+}
+
+func (s *StatsService) GetStats(ctx context.Context, user *domain.User) (domain.Stats, error) {
+	now := time.Now().In(user.Location())
+
+	events, err := s.history(ctx, user, now.Add(-statsHistory))
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	return domain.ComputeStats(events, now), nil
+}
+
+// CompletionsPerDay returns completed task counts for the last days, oldest first.
+func (s *StatsService) CompletionsPerDay(ctx context.Context, user *domain.User, days int) ([]int, error) {
+	now := time.Now().In(user.Location())
+
+	events, err := s.history(ctx, user, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CompletionsPerDay(events, now, days), nil
+}
+
+// OpenTasksPerDay returns the number of open tasks at the end of each of the
+// last days, oldest first.
+func (s *StatsService) OpenTasksPerDay(ctx context.Context, user *domain.User, days int) ([]int, error) {
+	now := time.Now().In(user.Location())
+
+	active, err := s.taskRepo.GetActiveByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the full history so deletions know whether the task was completed.
+	events, err := s.history(ctx, user, now.Add(-statsHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.OpenTasksPerDay(events, len(active), now, days), nil
+}
+
+// RemindersPerHour returns reminders sent over the last days grouped by local hour.
+func (s *StatsService) RemindersPerHour(ctx context.Context, user *domain.User, days int) ([24]int, error) {
+	now := time.Now().In(user.Location())
+
+	events, err := s.history(ctx, user, now.AddDate(0, 0, -days))
+	if err != nil {
+		return [24]int{}, err
+	}
+
+	return domain.RemindersPerHour(events, user.Location()), nil
+}
+
+// history returns the user's events since the given time. Events of shared
+// group tasks count for the group, not for the member who created the task.
+func (s *StatsService) history(ctx context.Context, user *domain.User, since time.Time) ([]*domain.TaskEvent, error) {
+	events, err := s.eventRepo.ListByUserID(ctx, user.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	return domain.PersonalEvents(events), nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+type statsTasks struct {
+	repository.TaskRepository
+	active []*domain.Task
+}
+
+func (r statsTasks) GetActiveByUserID(context.Context, int64) ([]*domain.Task, error) {
+	return r.active, nil
+}
+
+// Shared group tasks are recorded for the member who created them; they
+// count neither as the member's open tasks nor as their completions.
+func TestStatsService_LeavesOutGroupTasks(t *testing.T) {
+	user := &domain.User{ID: 7, Timezone: "UTC"}
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	chatID := int64(3)
+	deadline := today.AddDate(0, 0, 7)
+
+	events := &accountEvents{events: []*domain.TaskEvent{
+		// A group task created two days ago and completed today.
+		{TaskID: 2, UserID: 7, ChatID: &chatID, Type: domain.TaskEventCreated, Deadline: deadline, CreatedAt: today.AddDate(0, 0, -2)},
+		// A personal task created yesterday and still open.
+		{TaskID: 1, UserID: 7, Type: domain.TaskEventCreated, Deadline: deadline, CreatedAt: today.AddDate(0, 0, -1)},
+		{TaskID: 2, UserID: 7, ChatID: &chatID, Type: domain.TaskEventReminderSent, Deadline: deadline, CreatedAt: today},
+		{TaskID: 2, UserID: 7, ChatID: &chatID, Type: domain.TaskEventCompleted, Deadline: deadline, CreatedAt: today},
+	}}
+	tasks := statsTasks{active: []*domain.Task{{ID: 1, UserID: 7, Deadline: deadline}}}
+	s := NewStatsService(events, tasks)
+
+	open, err := s.OpenTasksPerDay(context.Background(), user, 3)
+	if err != nil {
+		t.Fatalf("OpenTasksPerDay() error = %v", err)
+	}
+	if want := []int{0, 1, 1}; !reflect.DeepEqual(open, want) {
+		t.Errorf("OpenTasksPerDay() = %v, want %v", open, want)
+	}
+
+	completions, err := s.CompletionsPerDay(context.Background(), user, 3)
+	if err != nil {
+		t.Fatalf("CompletionsPerDay() error = %v", err)
+	}
+	if want := []int{0, 0, 0}; !reflect.DeepEqual(completions, want) {
+		t.Errorf("CompletionsPerDay() = %v, want %v", completions, want)
+	}
+
+	hours, err := s.RemindersPerHour(context.Background(), user, 3)
+	if err != nil {
+		t.Fatalf("RemindersPerHour() error = %v", err)
+	}
+	if hours != [24]int{} {
+		t.Errorf("RemindersPerHour() = %v, want none", hours)
+	}
+
+	stats, err := s.GetStats(context.Background(), user)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if stats.CompletedTotal != 0 {
+		t.Errorf("GetStats() CompletedTotal = %d, want 0", stats.CompletedTotal)
+	}
+}
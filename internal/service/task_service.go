@@ -5,67 +5,459 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/rs/zerolog/log"
+
 	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/eventbus"
 	"telegram-reminder-bot/internal/repository"
+	"telegram-reminder-bot/internal/tracing"
 )
 
+type TaskServiceConfig struct {
+	// ArchiveRetention is how long completed tasks are kept. Zero keeps them forever.
+	ArchiveRetention time.Duration
+	// UndoWindow is how long a completed or deleted task can be restored.
+	// Deleted tasks are purged once it has passed.
+	UndoWindow time.Duration
+}
+
 type TaskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo  repository.TaskRepository
+	eventRepo repository.EventRepository
+	bus       *eventbus.Bus
+	cfg       TaskServiceConfig
 }
 
-func NewTaskService(taskRepo repository.TaskRepository) *TaskService {
-	return &TaskService{taskRepo: taskRepo}
+// NewTaskService creates the service. bus may be nil when nothing
+// subscribes to task events.
+func NewTaskService(taskRepo repository.TaskRepository, eventRepo repository.EventRepository, bus *eventbus.Bus, cfg TaskServiceConfig) *TaskService {
+	return &TaskService{taskRepo: taskRepo, eventRepo: eventRepo, bus: bus, cfg: cfg}
+}
+
+// record stores a history event and publishes it on the bus. History is
+// best effort: a failure is logged and does not fail the operation that
+// triggered it.
+func (s *TaskService) record(ctx context.Context, task *domain.Task, eventType domain.TaskEventType) {
+	event := domain.NewTaskEvent(task, eventType)
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", task.ID).Str("event", string(eventType)).Msg("failed to record task event")
+	}
+
+	occurredAt := event.CreatedAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	s.bus.Publish(ctx, eventbus.Event{Type: eventType, Task: *task, OccurredAt: occurredAt})
+}
+
+// recordUnblocked records an unblocked event for each task that waited for
+// blocker and waits for nothing else now that blocker is completed or
+// deleted. Like record, it is best effort.
+func (s *TaskService) recordUnblocked(ctx context.Context, blocker *domain.Task) {
+	tasks, err := s.taskRepo.ListReadyDependents(ctx, blocker.ID)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Int64("task_id", blocker.ID).Msg("failed to list unblocked tasks")
+		return
+	}
+	for _, task := range tasks {
+		s.record(ctx, task, domain.TaskEventUnblocked)
+	}
 }
 
 func (s *TaskService) Create(ctx context.Context, userID int64, description string, deadline time.Time, importance int, frequency domain.Frequency) (*domain.Task, error) {
-	if importance < 1 || importance > 5 {
+	ctx, span := tracing.Start(ctx, "TaskService.Create")
+	defer span.End()
+
+	return s.create(ctx, domain.NewTask(userID, description, deadline, importance, frequency))
+}
+
+// CreateInChat creates a task shared by the members of a group chat. userID
+// is the member who created it.
+func (s *TaskService) CreateInChat(ctx context.Context, chatID, userID int64, description string, deadline time.Time, importance int, frequency domain.Frequency) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.CreateInChat")
+	defer span.End()
+
+	task := domain.NewTask(userID, description, deadline, importance, frequency)
+	task.ChatID = &chatID
+	return s.create(ctx, task)
+}
+
+// Assign creates a task owned by assigneeID on behalf of assignerID. It
+// stays pending until the assignee accepts or declines it.
+func (s *TaskService) Assign(ctx context.Context, assignerID, assigneeID int64, description string, deadline time.Time, importance int, frequency domain.Frequency) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Assign")
+	defer span.End()
+
+	if assignerID == assigneeID {
+		return nil, fmt.Errorf("cannot assign a task to yourself")
+	}
+
+	task := domain.NewTask(assigneeID, description, deadline, importance, frequency)
+	task.AssignedBy = &assignerID
+	task.AssignmentStatus = domain.AssignmentPending
+	return s.create(ctx, task)
+}
+
+// RespondToAssignment records the assignee's answer to a pending task. A
+// declined task is handed back to the user who assigned it. It returns nil
+// when the task is not pending for assigneeID.
+func (s *TaskService) RespondToAssignment(ctx context.Context, id, assigneeID int64, accept bool) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.RespondToAssignment")
+	defer span.End()
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil || task.UserID != assigneeID || task.AssignedBy == nil || task.AssignmentStatus != domain.AssignmentPending {
+		return nil, nil
+	}
+
+	if accept {
+		task.AssignmentStatus = domain.AssignmentAccepted
+	} else {
+		task.AssignmentStatus = domain.AssignmentDeclined
+		task.UserID = *task.AssignedBy
+		task.RemindersSentToday = 0
+		task.LastReminderDate = nil
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	s.record(ctx, task, domain.TaskEventUpdated)
+
+	return task, nil
+}
+
+func (s *TaskService) create(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	if task.Importance < 1 || task.Importance > 5 {
 		return nil, fmt.Errorf("importance must be between 1 and 5")
 	}
 
-	task := domain.NewTask(userID, description, deadline, importance, frequency)
 	if err := s.taskRepo.Create(ctx, task); err != nil {
 		return nil, err
 	}
+	s.record(ctx, task, domain.TaskEventCreated)
 
 	return task, nil
 }
 
+// Update saves changes to a task's description, deadline, importance or
+// frequency.
+func (s *TaskService) Update(ctx context.Context, task *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Update")
+	defer span.End()
+
+	if task.Importance < 1 || task.Importance > 5 {
+		return fmt.Errorf("importance must be between 1 and 5")
+	}
+	if _, ok := domain.ParseFrequency(string(task.Frequency)); !ok {
+		return fmt.Errorf("unknown frequency %q", task.Frequency)
+	}
+
+	// A new deadline makes the task eligible for another overdue event.
+	stored, err := s.taskRepo.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if stored != nil && !stored.Deadline.Equal(task.Deadline) {
+		task.OverdueAt = nil
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+	s.record(ctx, task, domain.TaskEventUpdated)
+
+	return nil
+}
+
 func (s *TaskService) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetByID")
+	defer span.End()
+
 	return s.taskRepo.GetByID(ctx, id)
 }
 
 func (s *TaskService) GetActiveByUserID(ctx context.Context, userID int64) ([]*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetActiveByUserID")
+	defer span.End()
+
 	return s.taskRepo.GetActiveByUserID(ctx, userID)
 }
 
+func (s *TaskService) ListActive(ctx context.Context, userID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListActive")
+	defer span.End()
+
+	return s.taskRepo.ListActiveByUserID(ctx, userID, opts)
+}
+
+func (s *TaskService) ListActiveInChat(ctx context.Context, chatID int64, opts domain.TaskListOptions) ([]*domain.Task, int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListActiveInChat")
+	defer span.End()
+
+	return s.taskRepo.ListActiveByChatID(ctx, chatID, opts)
+}
+
 func (s *TaskService) Complete(ctx context.Context, id int64) error {
+	_, err := s.CompleteBy(ctx, id, "")
+	return err
+}
+
+// CompleteBy completes a task on behalf of a group member and returns it.
+// by is shown next to shared tasks and may be empty.
+func (s *TaskService) CompleteBy(ctx context.Context, id int64, by string) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Complete")
+	defer span.End()
+
 	task, err := s.taskRepo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if task == nil {
-		return fmt.Errorf("task not found")
+		return nil, fmt.Errorf("task not found")
 	}
 
+	now := time.Now()
 	task.IsCompleted = true
-	return s.taskRepo.Update(ctx, task)
+	task.CompletedAt = &now
+	task.CompletedBy = by
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	s.record(ctx, task, domain.TaskEventCompleted)
+	s.recordUnblocked(ctx, task)
+
+	return task, nil
+}
+
+func (s *TaskService) Reopen(ctx context.Context, id int64) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Reopen")
+	defer span.End()
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	task.IsCompleted = false
+	task.CompletedAt = nil
+	task.CompletedBy = ""
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	s.record(ctx, task, domain.TaskEventReopened)
+
+	return task, nil
+}
+
+func (s *TaskService) ListCompleted(ctx context.Context, userID int64, limit, offset int) ([]*domain.Task, int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListCompleted")
+	defer span.End()
+
+	return s.taskRepo.ListCompletedByUserID(ctx, userID, limit, offset)
+}
+
+// PurgeArchived permanently removes completed tasks older than the
+// configured retention period.
+func (s *TaskService) PurgeArchived(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.PurgeArchived")
+	defer span.End()
+
+	if s.cfg.ArchiveRetention <= 0 {
+		return 0, nil
+	}
+	return s.taskRepo.DeleteCompletedBefore(ctx, time.Now().Add(-s.cfg.ArchiveRetention))
 }
 
 func (s *TaskService) Delete(ctx context.Context, id int64) error {
-	return s.taskRepo.Delete(ctx, id)
+	ctx, span := tracing.Start(ctx, "TaskService.Delete")
+	defer span.End()
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("task not found")
+	}
+
+	if err := s.taskRepo.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+	s.record(ctx, task, domain.TaskEventDeleted)
+	s.recordUnblocked(ctx, task)
+
+	return nil
+}
+
+// GetDeletedByID returns a deleted task that can still be restored, or nil
+// when the undo window has passed.
+func (s *TaskService) GetDeletedByID(ctx context.Context, id int64) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetDeletedByID")
+	defer span.End()
+
+	return s.taskRepo.GetDeletedByID(ctx, id, time.Now().Add(-s.cfg.UndoWindow))
+}
+
+// UndoDelete restores a deleted shared task of chatID, or a personal task of
+// userID when chatID is nil, if the undo window has not passed yet.
+func (s *TaskService) UndoDelete(ctx context.Context, id, userID int64, chatID *int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.UndoDelete")
+	defer span.End()
+
+	restored, err := s.taskRepo.Restore(ctx, id, userID, chatID, time.Now().Add(-s.cfg.UndoWindow))
+	if err != nil || !restored {
+		return restored, err
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return true, err
+	}
+	if task != nil {
+		s.record(ctx, task, domain.TaskEventRestored)
+	}
+
+	return true, nil
+}
+
+// UndoComplete reopens a completed task if the undo window has not passed yet.
+func (s *TaskService) UndoComplete(ctx context.Context, id int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.UndoComplete")
+	defer span.End()
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if task == nil || !task.IsCompleted || task.CompletedAt == nil {
+		return false, nil
+	}
+	if time.Since(*task.CompletedAt) > s.cfg.UndoWindow {
+		return false, nil
+	}
+
+	if _, err := s.Reopen(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PurgeDeleted permanently removes tasks whose undo window has passed.
+func (s *TaskService) PurgeDeleted(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.PurgeDeleted")
+	defer span.End()
+
+	return s.taskRepo.DeleteSoftDeletedBefore(ctx, time.Now().Add(-s.cfg.UndoWindow))
 }
 
 func (s *TaskService) GetTasksForReminder(ctx context.Context) ([]*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetTasksForReminder")
+	defer span.End()
+
 	return s.taskRepo.GetTasksForReminder(ctx)
 }
 
 func (s *TaskService) IncrementReminderCount(ctx context.Context, task *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskService.IncrementReminderCount")
+	defer span.End()
+
 	now := time.Now()
+	if err := s.taskRepo.RecordReminderSent(ctx, task.ID, now); err != nil {
+		return err
+	}
 	task.RemindersSentToday++
 	task.LastReminderDate = &now
-	return s.taskRepo.Update(ctx, task)
+	task.SnoozedUntil = nil
+	task.Deferred = false
+	s.record(ctx, task, domain.TaskEventReminderSent)
+
+	return nil
+}
+
+func (s *TaskService) CountByState(ctx context.Context) (map[string]int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.CountByState")
+	defer span.End()
+
+	return s.taskRepo.CountByState(ctx)
+}
+
+// ListOverdueCandidates returns active tasks not yet marked overdue whose
+// deadline is before the given date. Whether a task is overdue depends on
+// its owner's timezone, so the caller makes the final decision.
+func (s *TaskService) ListOverdueCandidates(ctx context.Context, before time.Time) ([]*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListOverdueCandidates")
+	defer span.End()
+
+	return s.taskRepo.ListOverdue(ctx, before)
+}
+
+// MarkOverdue records that the task's deadline has passed. It emits the
+// overdue event once per deadline.
+func (s *TaskService) MarkOverdue(ctx context.Context, task *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskService.MarkOverdue")
+	defer span.End()
+
+	now := time.Now()
+	marked, err := s.taskRepo.MarkOverdue(ctx, task.ID, now)
+	if err != nil || !marked {
+		return err
+	}
+	task.OverdueAt = &now
+	s.record(ctx, task, domain.TaskEventOverdue)
+
+	return nil
+}
+
+// Pause stops reminders for the task until the given date, or until it is
+// resumed when until is nil.
+func (s *TaskService) Pause(ctx context.Context, task *domain.Task, until *time.Time) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Pause")
+	defer span.End()
+
+	now := time.Now()
+	if err := s.taskRepo.SetPaused(ctx, task.ID, &now, until); err != nil {
+		return err
+	}
+	task.PausedAt = &now
+	task.PausedUntil = until
+	s.record(ctx, task, domain.TaskEventPaused)
+
+	return nil
+}
+
+func (s *TaskService) Resume(ctx context.Context, task *domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Resume")
+	defer span.End()
+
+	if err := s.taskRepo.SetPaused(ctx, task.ID, nil, nil); err != nil {
+		return err
+	}
+	task.PausedAt = nil
+	task.PausedUntil = nil
+	s.record(ctx, task, domain.TaskEventResumed)
+
+	return nil
+}
+
+// ListResumeCandidates returns paused tasks due to resume on or before the
+// given date in some timezone; callers check each owner's date.
+func (s *TaskService) ListResumeCandidates(ctx context.Context, date time.Time) ([]*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListResumeCandidates")
+	defer span.End()
+
+	return s.taskRepo.ListPausedUntil(ctx, date)
 }
 
 func (s *TaskService) ResetDailyReminders(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "TaskService.ResetDailyReminders")
+	defer span.End()
+
 	return s.taskRepo.ResetDailyReminders(ctx)
 }
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// memTasks keeps tasks in memory and applies the cutoffs the service passes
+// the way the repository queries do.
+type memTasks struct {
+	repository.TaskRepository
+	tasks map[int64]*domain.Task
+	// deleted holds when soft-deleted tasks were deleted.
+	deleted map[int64]time.Time
+}
+
+func (r *memTasks) Create(_ context.Context, task *domain.Task) error {
+	task.ID = int64(len(r.tasks) + 1)
+	r.tasks[task.ID] = task
+	return nil
+}
+
+func (r *memTasks) GetByID(_ context.Context, id int64) (*domain.Task, error) {
+	if _, ok := r.deleted[id]; ok {
+		return nil, nil
+	}
+	return r.tasks[id], nil
+}
+
+func (r *memTasks) Update(_ context.Context, task *domain.Task) error {
+	r.tasks[task.ID] = task
+	return nil
+}
+
+func (r *memTasks) ListReadyDependents(context.Context, int64) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memTasks) GetDeletedByID(_ context.Context, id int64, since time.Time) (*domain.Task, error) {
+	if at, ok := r.deleted[id]; ok && !at.Before(since) {
+		return r.tasks[id], nil
+	}
+	return nil, nil
+}
+
+func (r *memTasks) Restore(_ context.Context, id, userID int64, chatID *int64, since time.Time) (bool, error) {
+	task := r.tasks[id]
+	at, ok := r.deleted[id]
+	if !ok || at.Before(since) {
+		return false, nil
+	}
+	if chatID != nil && (task.ChatID == nil || *task.ChatID != *chatID) || chatID == nil && (task.ChatID != nil || task.UserID != userID) {
+		return false, nil
+	}
+	delete(r.deleted, id)
+	return true, nil
+}
+
+func (r *memTasks) DeleteCompletedBefore(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for id, task := range r.tasks {
+		if task.IsCompleted && task.CompletedAt != nil && task.CompletedAt.Before(before) {
+			delete(r.tasks, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// taskEvents records the types of the events stored.
+type taskEvents struct {
+	repository.EventRepository
+	types []domain.TaskEventType
+}
+
+func (r *taskEvents) Create(_ context.Context, event *domain.TaskEvent) error {
+	r.types = append(r.types, event.Type)
+	return nil
+}
+
+// Completed tasks stay in the archive for the retention period, and forever
+// without one.
+func TestTaskService_PurgeArchived(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		retention  time.Duration
+		wantPurged int64
+		wantKept   []int64
+	}{
+		{"no retention", 0, 0, []int64{1, 2, 3}},
+		{"90 days", 90 * day, 1, []int64{2, 3}},
+		{"30 days", 30 * day, 2, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			old, recent := now.Add(-91*day), now.Add(-89*day)
+			repo := &memTasks{tasks: map[int64]*domain.Task{
+				1: {ID: 1, IsCompleted: true, CompletedAt: &old},
+				2: {ID: 2, IsCompleted: true, CompletedAt: &recent},
+				3: {ID: 3},
+			}}
+			s := NewTaskService(repo, nil, nil, TaskServiceConfig{ArchiveRetention: tt.retention})
+
+			purged, err := s.PurgeArchived(context.Background())
+			if err != nil {
+				t.Fatalf("PurgeArchived() error = %v", err)
+			}
+			if purged != tt.wantPurged {
+				t.Errorf("PurgeArchived() = %d, want %d", purged, tt.wantPurged)
+			}
+			for _, id := range tt.wantKept {
+				if repo.tasks[id] == nil {
+					t.Errorf("task %d purged, want kept", id)
+				}
+			}
+			if len(repo.tasks) != len(tt.wantKept) {
+				t.Errorf("%d tasks kept, want %d", len(repo.tasks), len(tt.wantKept))
+			}
+		})
+	}
+}
+
+// A deleted task can be restored by its owner within the undo window: the
+// user for a personal task, the group for a shared one.
+func TestTaskService_UndoDelete(t *testing.T) {
+	chatID, otherChatID := int64(-100), int64(-200)
+
+	tests := []struct {
+		name        string
+		deletedAgo  time.Duration
+		task        domain.Task
+		userID      int64
+		chatID      *int64
+		wantRestore bool
+	}{
+		{"within the window", 10 * time.Second, domain.Task{ID: 1, UserID: 7}, 7, nil, true},
+		{"after the window", 2 * time.Minute, domain.Task{ID: 1, UserID: 7}, 7, nil, false},
+		{"another user's task", 10 * time.Second, domain.Task{ID: 1, UserID: 8}, 7, nil, false},
+		{"shared task in its group", 10 * time.Second, domain.Task{ID: 1, UserID: 8, ChatID: &chatID}, 7, &chatID, true},
+		{"shared task in another group", 10 * time.Second, domain.Task{ID: 1, UserID: 7, ChatID: &chatID}, 7, &otherChatID, false},
+		{"shared task as a personal one", 10 * time.Second, domain.Task{ID: 1, UserID: 7, ChatID: &chatID}, 7, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task
+			repo := &memTasks{
+				tasks:   map[int64]*domain.Task{1: &task},
+				deleted: map[int64]time.Time{1: time.Now().Add(-tt.deletedAgo)},
+			}
+			events := &taskEvents{}
+			s := NewTaskService(repo, events, nil, TaskServiceConfig{UndoWindow: time.Minute})
+
+			deleted, err := s.GetDeletedByID(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetDeletedByID() error = %v", err)
+			}
+			if inWindow := tt.deletedAgo < time.Minute; (deleted != nil) != inWindow {
+				t.Errorf("GetDeletedByID() = %v, want found %v", deleted, inWindow)
+			}
+
+			restored, err := s.UndoDelete(context.Background(), 1, tt.userID, tt.chatID)
+			if err != nil {
+				t.Fatalf("UndoDelete() error = %v", err)
+			}
+			if restored != tt.wantRestore {
+				t.Errorf("UndoDelete() = %v, want %v", restored, tt.wantRestore)
+			}
+
+			if _, stillDeleted := repo.deleted[1]; stillDeleted == tt.wantRestore {
+				t.Errorf("task deleted = %v after undo, want %v", stillDeleted, !tt.wantRestore)
+			}
+			if recorded := len(events.types) == 1 && events.types[0] == domain.TaskEventRestored; recorded != tt.wantRestore || !tt.wantRestore && len(events.types) > 0 {
+				t.Errorf("events = %v, want restored recorded %v", events.types, tt.wantRestore)
+			}
+		})
+	}
+}
+
+// Completing a task can be undone within the undo window.
+func TestTaskService_UndoComplete(t *testing.T) {
+	tests := []struct {
+		name         string
+		completedAgo time.Duration
+		wantReopen   bool
+	}{
+		{"within the window", 10 * time.Second, true},
+		{"after the window", 2 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completedAt := time.Now().Add(-tt.completedAgo)
+			repo := &memTasks{tasks: map[int64]*domain.Task{1: {ID: 1, UserID: 7, IsCompleted: true, CompletedAt: &completedAt}}}
+			s := NewTaskService(repo, &taskEvents{}, nil, TaskServiceConfig{UndoWindow: time.Minute})
+
+			reopened, err := s.UndoComplete(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("UndoComplete() error = %v", err)
+			}
+			if reopened != tt.wantReopen || repo.tasks[1].IsCompleted == tt.wantReopen {
+				t.Errorf("UndoComplete() = %v, completed %v, want reopened %v", reopened, repo.tasks[1].IsCompleted, tt.wantReopen)
+			}
+		})
+	}
+}
+
+func TestTaskService_Assign(t *testing.T) {
+	repo := &memTasks{tasks: map[int64]*domain.Task{}}
+	s := NewTaskService(repo, &taskEvents{}, nil, TaskServiceConfig{})
+	deadline := time.Now().AddDate(0, 0, 3)
+
+	task, err := s.Assign(context.Background(), 7, 8, "Fix the sink", deadline, 3, domain.FrequencyDaily)
+	if err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if task.UserID != 8 || task.AssignedBy == nil || *task.AssignedBy != 7 || task.AssignmentStatus != domain.AssignmentPending {
+		t.Errorf("Assign() = %+v, want a task of 8 pending from 7", task)
+	}
+	if repo.tasks[task.ID] == nil {
+		t.Error("assigned task not stored")
+	}
+
+	if _, err := s.Assign(context.Background(), 7, 7, "Fix the sink", deadline, 3, domain.FrequencyDaily); err == nil {
+		t.Error("Assign() to oneself error = nil, want an error")
+	}
+}
+
+// The assignee accepts a pending task and keeps it, or declines it and it
+// goes back to the user who assigned it.
+func TestTaskService_RespondToAssignment(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.AssignmentStatus
+		responder  int64
+		accept     bool
+		wantStatus domain.AssignmentStatus
+		wantOwner  int64
+		wantNil    bool
+	}{
+		{"accept", domain.AssignmentPending, 8, true, domain.AssignmentAccepted, 8, false},
+		{"decline", domain.AssignmentPending, 8, false, domain.AssignmentDeclined, 7, false},
+		{"someone else", domain.AssignmentPending, 9, true, domain.AssignmentPending, 8, true},
+		{"already accepted", domain.AssignmentAccepted, 8, false, domain.AssignmentAccepted, 8, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigner := int64(7)
+			sentAt := time.Now()
+			repo := &memTasks{tasks: map[int64]*domain.Task{1: {
+				ID: 1, UserID: 8, AssignedBy: &assigner, AssignmentStatus: tt.status,
+				RemindersSentToday: 2, LastReminderDate: &sentAt,
+			}}}
+			s := NewTaskService(repo, &taskEvents{}, nil, TaskServiceConfig{})
+
+			task, err := s.RespondToAssignment(context.Background(), 1, tt.responder, tt.accept)
+			if err != nil {
+				t.Fatalf("RespondToAssignment() error = %v", err)
+			}
+			if (task == nil) != tt.wantNil {
+				t.Errorf("RespondToAssignment() = %+v, want nil %v", task, tt.wantNil)
+			}
+
+			stored := repo.tasks[1]
+			if stored.AssignmentStatus != tt.wantStatus || stored.UserID != tt.wantOwner {
+				t.Errorf("task status %q of %d, want %q of %d", stored.AssignmentStatus, stored.UserID, tt.wantStatus, tt.wantOwner)
+			}
+			if tt.wantStatus == domain.AssignmentDeclined && (stored.RemindersSentToday != 0 || stored.LastReminderDate != nil) {
+				t.Errorf("declined task keeps the assignee's reminders: %d sent", stored.RemindersSentToday)
+			}
+		})
+	}
+}
@@ -2,17 +2,28 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"telegram-reminder-bot/internal/domain"
 	"telegram-reminder-bot/internal/repository"
 )
 
+// apiTokenPrefix makes API tokens recognisable, e.g. by secret scanners.
+const apiTokenPrefix = "rb_"
+
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	tokenRepo repository.APITokenRepository
 }
 
-func NewUserService(userRepo repository.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.APITokenRepository) *UserService {
+	return &UserService{userRepo: userRepo, tokenRepo: tokenRepo}
 }
 
 func (s *UserService) GetOrCreate(ctx context.Context, telegramID int64, username string) (*domain.User, error) {
@@ -21,6 +32,14 @@ func (s *UserService) GetOrCreate(ctx context.Context, telegramID int64, usernam
 		return nil, err
 	}
 
+	// Keep usernames fresh so that /assign @username finds the right user.
+	if user != nil && username != "" && username != user.Username {
+		user.Username = username
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
 	if user != nil {
 		return user, nil
 	}
@@ -33,6 +52,112 @@ func (s *UserService) GetOrCreate(ctx context.Context, telegramID int64, usernam
 	return user, nil
 }
 
+func (s *UserService) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	return s.userRepo.GetByID(ctx, id)
+}
+
+func (s *UserService) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error) {
+	return s.userRepo.GetByTelegramID(ctx, telegramID)
+}
+
+// GetByUsername finds a registered user by Telegram username, with or
+// without the leading "@".
+func (s *UserService) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return s.userRepo.GetByUsername(ctx, strings.TrimPrefix(username, "@"))
+}
+
 func (s *UserService) UpdateSettings(ctx context.Context, user *domain.User) error {
 	return s.userRepo.Update(ctx, user)
 }
+
+// SetLanguage stores the language the bot talks to the user in.
+func (s *UserService) SetLanguage(ctx context.Context, user *domain.User, language string) error {
+	user.Language = language
+	return s.userRepo.Update(ctx, user)
+}
+
+// CalendarToken returns the user's calendar feed token, creating one on first use.
+func (s *UserService) CalendarToken(ctx context.Context, user *domain.User) (string, error) {
+	if user.CalendarToken != "" {
+		return user.CalendarToken, nil
+	}
+	return s.RotateCalendarToken(ctx, user)
+}
+
+// RotateCalendarToken replaces the calendar feed token, invalidating old feed URLs.
+func (s *UserService) RotateCalendarToken(ctx context.Context, user *domain.User) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.userRepo.SetCalendarToken(ctx, user.ID, token); err != nil {
+		return "", err
+	}
+	user.CalendarToken = token
+	return token, nil
+}
+
+func (s *UserService) GetByCalendarToken(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return s.userRepo.GetByCalendarToken(ctx, token)
+}
+
+// IssueAPIToken creates a new API token for the user. The token is returned
+// only here; just its hash is stored.
+func (s *UserService) IssueAPIToken(ctx context.Context, user *domain.User) (string, error) {
+	secret, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	token := apiTokenPrefix + secret
+	if err := s.tokenRepo.Create(ctx, &domain.APIToken{UserID: user.ID, TokenHash: hashToken(token)}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeAPITokens deletes all of the user's API tokens.
+func (s *UserService) RevokeAPITokens(ctx context.Context, user *domain.User) (int64, error) {
+	return s.tokenRepo.DeleteByUserID(ctx, user.ID)
+}
+
+func (s *UserService) ListAPITokens(ctx context.Context, user *domain.User) ([]*domain.APIToken, error) {
+	return s.tokenRepo.ListByUserID(ctx, user.ID)
+}
+
+// GetByAPIToken returns the owner of an API token, or nil if the token is unknown.
+func (s *UserService) GetByAPIToken(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	apiToken, err := s.tokenRepo.GetByHash(ctx, hashToken(token))
+	if err != nil || apiToken == nil {
+		return nil, err
+	}
+
+	// Usage tracking is informational; a failure does not deny access.
+	if apiToken.LastUsedAt == nil || time.Since(*apiToken.LastUsedAt) > time.Minute {
+		if err := s.tokenRepo.Touch(ctx, apiToken.ID); err != nil {
+			log.Error().Ctx(ctx).Err(err).Int64("token_id", apiToken.ID).Msg("failed to update api token usage")
+		}
+	}
+
+	return s.userRepo.GetByID(ctx, apiToken.UserID)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/repository"
+)
+
+// maxWebhooksPerUser limits how many URLs one user can register.
+const maxWebhooksPerUser = 5
+
+var (
+	ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+	ErrTooManyWebhooks   = errors.New("too many webhooks")
+)
+
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo}
+}
+
+// Create registers a webhook with a freshly generated signing secret.
+func (s *WebhookService) Create(ctx context.Context, user *domain.User, rawURL string) (*domain.Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	existing, err := s.webhookRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &domain.Webhook{UserID: user.ID, URL: u.String(), Secret: secret}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) List(ctx context.Context, user *domain.User) ([]*domain.Webhook, error) {
+	return s.webhookRepo.ListByUserID(ctx, user.ID)
+}
+
+// Delete removes a webhook owned by user. It reports false if there is no
+// such webhook.
+func (s *WebhookService) Delete(ctx context.Context, user *domain.User, id int64) (bool, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if webhook == nil || webhook.UserID != user.ID {
+		return false, nil
+	}
+	return true, s.webhookRepo.Delete(ctx, id)
+}
+
+// Deliveries returns the user's most recent delivery attempts, newest first.
+func (s *WebhookService) Deliveries(ctx context.Context, user *domain.User, limit int) ([]*domain.WebhookDelivery, error) {
+	return s.webhookRepo.ListDeliveriesByUserID(ctx, user.ID, limit)
+}
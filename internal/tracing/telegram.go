@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"net/http"
+	"path"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TelegramTransport wraps next so that Bot API calls made while handling an
+// update or a scheduler job are traced. Calls without a parent span, like
+// the getUpdates long poll, are not.
+func TelegramTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &telegramTransport{next: next}
+}
+
+type telegramTransport struct {
+	next http.RoundTripper
+}
+
+func (t *telegramTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The last path element is the API method; the token sits before it.
+	method := path.Base(req.URL.Path)
+	ctx, span := StartChild(req.Context(), "telegram "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("telegram.method", method)),
+	)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if resp.StatusCode >= 300 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	End(span, err)
+	return resp, err
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	return recorder
+}
+
+func TestLogHook(t *testing.T) {
+	setupRecorder(t)
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(LogHook{})
+
+	ctx, span := Start(context.Background(), "test")
+	logger.Info().Ctx(ctx).Msg("with span")
+	span.End()
+	logger.Info().Ctx(context.Background()).Msg("without span")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+
+	var withSpan, withoutSpan map[string]any
+	json.Unmarshal(lines[0], &withSpan)
+	json.Unmarshal(lines[1], &withoutSpan)
+
+	if got, want := withSpan["trace_id"], span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("trace_id = %v, want %s", got, want)
+	}
+	if got, want := withSpan["span_id"], span.SpanContext().SpanID().String(); got != want {
+		t.Errorf("span_id = %v, want %s", got, want)
+	}
+	if _, ok := withoutSpan["trace_id"]; ok {
+		t.Error("trace_id set on a line without a span")
+	}
+}
+
+func TestTelegramTransport(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	client := &http.Client{Transport: TelegramTransport(nil)}
+
+	get := func(ctx context.Context, method string) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/bot1:secret/"+method, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	get(context.Background(), "getUpdates")
+
+	ctx, parent := Start(context.Background(), "update")
+	get(ctx, "sendMessage")
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the parent and one Bot API call", len(spans))
+	}
+	call := spans[0]
+	if call.Name() != "telegram sendMessage" {
+		t.Errorf("span name = %q", call.Name())
+	}
+	if call.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Bot API span is not a child of the update span")
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT id FROM tasks":          "SELECT",
+		"\n\t\tupdate tasks SET x = 1":  "UPDATE",
+		"INSERT INTO tasks VALUES ($1)": "INSERT",
+		"":                              "QUERY",
+	}
+
+	for sql, want := range tests {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
+
+func TestSetup(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	for _, exporter := range []string{ExporterNone, ExporterStdout} {
+		shutdown, err := Setup(context.Background(), Config{Exporter: exporter, SampleRatio: 1})
+		if err != nil {
+			t.Fatalf("Setup(%q) error = %v", exporter, err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Errorf("shutdown(%q) error = %v", exporter, err)
+		}
+	}
+
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Error("Setup() with an unknown exporter succeeded")
+	}
+}
@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/eventbus"
+	"telegram-reminder-bot/internal/repository"
+)
+
+type Config struct {
+	// Timeout limits a single delivery attempt.
+	Timeout time.Duration
+	// RetryDelays are the pauses before the second, third, ... attempts.
+	// A delivery is attempted len(RetryDelays)+1 times at most.
+	RetryDelays []time.Duration
+	// AllowPrivate permits delivery to loopback and private network
+	// addresses. It is off in production so that webhooks cannot be used
+	// to reach internal services.
+	AllowPrivate bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:     10 * time.Second,
+		RetryDelays: []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute},
+	}
+}
+
+var errPrivateAddress = errors.New("address is not publicly routable")
+
+// Dispatcher subscribes to the event bus and delivers every event to the
+// owner's webhooks in the background, retrying failed attempts and logging
+// each one.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	cfg    Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed atomic.Bool
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(repo repository.WebhookRepository, cfg Config) *Dispatcher {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = denyPrivate
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Through a proxy the address check would apply to the proxy instead
+	// of the webhook host.
+	transport.Proxy = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		repo: repo,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// Redirects could point at addresses the dialer would refuse to
+			// hide behind a public one; treat them as failures instead.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Handle is an eventbus.Handler.
+func (d *Dispatcher) Handle(_ context.Context, event eventbus.Event) {
+	if d.closed.Load() {
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatch(event)
+	}()
+}
+
+// Close stops accepting events and waits for pending deliveries, including
+// retries. When ctx is done first, the remaining deliveries are abandoned.
+func (d *Dispatcher) Close(ctx context.Context) {
+	d.closed.Store(true)
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+	}
+}
+
+func (d *Dispatcher) dispatch(event eventbus.Event) {
+	webhooks, err := d.repo.ListByUserID(d.ctx, event.Task.UserID)
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", event.Task.UserID).Msg("failed to list webhooks")
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(NewPayload(event))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to encode webhook payload")
+		return
+	}
+
+	for _, w := range webhooks {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(w, event, body)
+		}()
+	}
+}
+
+func (d *Dispatcher) deliver(w *domain.Webhook, event eventbus.Event, body []byte) {
+	for attempt := 1; ; attempt++ {
+		status, err := d.send(w, event, body)
+
+		delivery := &domain.WebhookDelivery{
+			WebhookID:  w.ID,
+			EventType:  event.Type,
+			TaskID:     event.Task.ID,
+			Attempt:    attempt,
+			StatusCode: status,
+			Success:    err == nil,
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if logErr := d.repo.CreateDelivery(context.WithoutCancel(d.ctx), delivery); logErr != nil {
+			log.Error().Err(logErr).Int64("webhook_id", w.ID).Msg("failed to log webhook delivery")
+		}
+
+		if err == nil || !retryable(status) || attempt > len(d.cfg.RetryDelays) {
+			if err != nil {
+				log.Warn().Err(err).Int64("webhook_id", w.ID).Int("attempts", attempt).Msg("webhook delivery failed")
+			}
+			return
+		}
+
+		select {
+		case <-time.After(d.cfg.RetryDelays[attempt-1]):
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// send makes one attempt and returns the response status, 0 if there was none.
+func (d *Dispatcher) send(w *domain.Webhook, event eventbus.Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "telegram-reminder-bot-webhook/1.0")
+	req.Header.Set(HeaderEvent, EventName(event.Type))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(w.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryable reports whether a failed attempt may succeed later: network
+// errors, rate limiting and server errors are retried, other client errors
+// are not.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+func denyPrivate(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("%s: %w", host, errPrivateAddress)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package webhook delivers task events to user-registered URLs as signed
+// JSON payloads.
+//
+// Each request carries the headers
+//
+//	X-Webhook-Event:     task.completed
+//	X-Webhook-Timestamp: 1736942400
+//	X-Webhook-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+//
+// keyed with the webhook's secret. Receivers should check the signature with
+// Verify and reject old timestamps to prevent replays.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/eventbus"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Payload struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Task       PayloadTask `json:"task"`
+}
+
+type PayloadTask struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	Deadline    string `json:"deadline"`
+	Importance  int    `json:"importance"`
+	Frequency   string `json:"frequency"`
+	IsCompleted bool   `json:"is_completed"`
+}
+
+// EventName returns the name used in payloads, e.g. "task.completed".
+func EventName(t domain.TaskEventType) string {
+	return "task." + string(t)
+}
+
+func NewPayload(e eventbus.Event) Payload {
+	return Payload{
+		Event:      EventName(e.Type),
+		OccurredAt: e.OccurredAt.UTC(),
+		Task: PayloadTask{
+			ID:          e.Task.ID,
+			Description: e.Task.Description,
+			Deadline:    e.Task.Deadline.Format(time.DateOnly),
+			Importance:  e.Task.Importance,
+			Frequency:   e.Task.Frequency.String(),
+			IsCompleted: e.Task.IsCompleted,
+		},
+	}
+}
+
+// Sign returns the X-Webhook-Signature value for a request body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"telegram-reminder-bot/internal/domain"
+	"telegram-reminder-bot/internal/eventbus"
+)
+
+type memRepo struct {
+	mu         sync.Mutex
+	webhooks   []*domain.Webhook
+	deliveries []*domain.WebhookDelivery
+}
+
+func (r *memRepo) Create(_ context.Context, w *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w.ID = int64(len(r.webhooks) + 1)
+	r.webhooks = append(r.webhooks, w)
+	return nil
+}
+
+func (r *memRepo) GetByID(context.Context, int64) (*domain.Webhook, error) {
+	return nil, nil
+}
+
+func (r *memRepo) ListByUserID(_ context.Context, userID int64) ([]*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.Webhook
+	for _, w := range r.webhooks {
+		if w.UserID == userID {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (r *memRepo) Delete(context.Context, int64) error {
+	return nil
+}
+
+func (r *memRepo) CreateDelivery(_ context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, d)
+	return nil
+}
+
+func (r *memRepo) ListDeliveriesByUserID(context.Context, int64, int) ([]*domain.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func testEvent() eventbus.Event {
+	return eventbus.Event{
+		Type: domain.TaskEventCompleted,
+		Task: domain.Task{
+			ID:          42,
+			UserID:      1,
+			Description: "Ship release",
+			Deadline:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			Importance:  4,
+			Frequency:   domain.FrequencyDaily,
+			IsCompleted: true,
+		},
+		OccurredAt: time.Date(2025, 1, 14, 10, 30, 0, 0, time.UTC),
+	}
+}
+
+func testConfig() Config {
+	return Config{
+		Timeout:      time.Second,
+		RetryDelays:  []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond},
+		AllowPrivate: true,
+	}
+}
+
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	const secret = "s3cret"
+
+	var (
+		mu       sync.Mutex
+		received []Payload
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if !Verify(secret, r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature)) {
+			t.Errorf("signature %q does not verify", r.Header.Get(HeaderSignature))
+		}
+		if got := r.Header.Get(HeaderEvent); got != "task.completed" {
+			t.Errorf("%s = %q, want task.completed", HeaderEvent, got)
+		}
+
+		var p Payload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	repo := &memRepo{}
+	repo.Create(context.Background(), &domain.Webhook{UserID: 1, URL: receiver.URL, Secret: secret})
+	repo.Create(context.Background(), &domain.Webhook{UserID: 2, URL: receiver.URL + "/other-user", Secret: "x"})
+
+	d := NewDispatcher(repo, testConfig())
+	d.Handle(context.Background(), testEvent())
+	d.Close(context.Background())
+
+	if len(received) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(received))
+	}
+	want := Payload{
+		Event:      "task.completed",
+		OccurredAt: time.Date(2025, 1, 14, 10, 30, 0, 0, time.UTC),
+		Task: PayloadTask{
+			ID:          42,
+			Description: "Ship release",
+			Deadline:    "2025-01-15",
+			Importance:  4,
+			Frequency:   "daily",
+			IsCompleted: true,
+		},
+	}
+	if received[0] != want {
+		t.Errorf("payload = %+v, want %+v", received[0], want)
+	}
+
+	if len(repo.deliveries) != 1 || !repo.deliveries[0].Success || repo.deliveries[0].StatusCode != http.StatusOK {
+		t.Errorf("delivery log = %+v", repo.deliveries)
+	}
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantSuccess  bool
+	}{
+		{"success after server errors", []int{500, 503, 200}, 3, true},
+		{"rate limited", []int{429, 204}, 2, true},
+		{"client error is final", []int{400}, 1, false},
+		{"gives up", []int{500, 500, 500, 500, 500}, 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+			}))
+			defer receiver.Close()
+
+			repo := &memRepo{}
+			repo.Create(context.Background(), &domain.Webhook{UserID: 1, URL: receiver.URL, Secret: "s"})
+
+			d := NewDispatcher(repo, testConfig())
+			d.Handle(context.Background(), testEvent())
+			d.Close(context.Background())
+
+			if len(repo.deliveries) != tt.wantAttempts {
+				t.Fatalf("logged %d attempts, want %d", len(repo.deliveries), tt.wantAttempts)
+			}
+			for i, d := range repo.deliveries {
+				if d.Attempt != i+1 {
+					t.Errorf("delivery %d has attempt %d", i, d.Attempt)
+				}
+			}
+			last := repo.deliveries[len(repo.deliveries)-1]
+			if last.Success != tt.wantSuccess {
+				t.Errorf("last attempt success = %v, want %v (%+v)", last.Success, tt.wantSuccess, last)
+			}
+		})
+	}
+}
+
+func TestDispatcher_DeniesPrivateAddresses(t *testing.T) {
+	var called atomic.Bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called.Store(true)
+	}))
+	defer receiver.Close()
+
+	repo := &memRepo{}
+	repo.Create(context.Background(), &domain.Webhook{UserID: 1, URL: receiver.URL, Secret: "s"})
+
+	cfg := testConfig()
+	cfg.AllowPrivate = false
+	cfg.RetryDelays = nil
+
+	d := NewDispatcher(repo, cfg)
+	d.Handle(context.Background(), testEvent())
+	d.Close(context.Background())
+
+	if called.Load() {
+		t.Error("request reached a loopback address")
+	}
+	if len(repo.deliveries) != 1 || repo.deliveries[0].Success || !strings.Contains(repo.deliveries[0].Error, "not publicly routable") {
+		t.Errorf("delivery log = %+v", repo.deliveries)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"event":"task.created"}`)
+	sig := Sign("secret", 1736942400, body)
+
+	if !strings.HasPrefix(sig, "sha256=") {
+		t.Errorf("Sign() = %q, want sha256= prefix", sig)
+	}
+	if !Verify("secret", "1736942400", body, sig) {
+		t.Error("Verify() = false for a valid signature")
+	}
+
+	tests := map[string]struct {
+		secret, timestamp string
+		body              []byte
+	}{
+		"wrong secret":    {"other", "1736942400", body},
+		"wrong timestamp": {"secret", "1736942401", body},
+		"bad timestamp":   {"secret", "now", body},
+		"tampered body":   {"secret", "1736942400", []byte(`{"event":"task.deleted"}`)},
+	}
+	for name, tt := range tests {
+		if Verify(tt.secret, tt.timestamp, tt.body, sig) {
+			t.Errorf("%s: Verify() = true", name)
+		}
+	}
+}
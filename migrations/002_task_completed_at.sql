@@ -0,0 +1,4 @@
+-- Completion timestamp for the task archive
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_completed ON tasks(user_id, completed_at DESC) WHERE is_completed = TRUE;
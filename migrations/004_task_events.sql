@@ -0,0 +1,13 @@
+-- Task history for statistics. task_id has no foreign key so that history
+-- outlives purged tasks.
+CREATE TABLE IF NOT EXISTS task_events (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    importance INT NOT NULL,
+    deadline DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_user ON task_events(user_id, created_at);
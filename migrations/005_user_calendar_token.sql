@@ -0,0 +1,2 @@
+-- Secret token for the per-user iCalendar feed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token VARCHAR(64) UNIQUE;
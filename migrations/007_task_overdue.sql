@@ -0,0 +1,2 @@
+-- Set when the task's overdue event has been emitted
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,11 @@
+-- Users blocked by an admin, and single-use invite codes for invite-only mode
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS invite_codes (
+    code VARCHAR(64) PRIMARY KEY,
+    created_by BIGINT NOT NULL,
+    used_by BIGINT,
+    used_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,18 @@
+-- Group chats and the shared tasks that belong to them
+CREATE TABLE IF NOT EXISTS chats (
+    id BIGSERIAL PRIMARY KEY,
+    telegram_id BIGINT UNIQUE NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    timezone VARCHAR(50) DEFAULT 'Europe/Moscow',
+    work_hours_per_day INT DEFAULT 8,
+    work_start_hour INT DEFAULT 9,
+    work_end_hour INT DEFAULT 18,
+    left_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_by VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_chat_id ON tasks(chat_id);
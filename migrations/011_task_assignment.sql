@@ -0,0 +1,5 @@
+-- Tasks assigned by one user to another
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assigned_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignment_status VARCHAR(16) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_users_username ON users(LOWER(username));
@@ -0,0 +1,3 @@
+-- Interface language of users and group chats; empty until it is known
+ALTER TABLE users ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Render style of task messages chosen in the settings; empty means the default
+ALTER TABLE users ADD COLUMN IF NOT EXISTS message_style VARCHAR(16) NOT NULL DEFAULT '';
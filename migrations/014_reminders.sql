@@ -0,0 +1,16 @@
+-- Reminders sent for tasks and what their owners did about them, for the
+-- adaptive reminder policy; snoozed_until postpones a task's next reminder
+CREATE TABLE IF NOT EXISTS reminders (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    outcome VARCHAR(16) NOT NULL DEFAULT 'pending',
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_user_sent ON reminders(user_id, sent_at);
+CREATE INDEX IF NOT EXISTS idx_reminders_task_pending ON reminders(task_id) WHERE outcome = 'pending';
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP WITH TIME ZONE;
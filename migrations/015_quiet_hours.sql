@@ -0,0 +1,16 @@
+-- Quiet periods and Do Not Disturb: reminders that come due while a user
+-- is quiet are deferred to the end of the window, the task marked deferred
+CREATE TABLE IF NOT EXISTS quiet_periods (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_minute INT NOT NULL,
+    end_minute INT NOT NULL,
+    weekdays SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiet_periods_user_id ON quiet_periods(user_id);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS dnd_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS quiet_mode VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deferred BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+-- Paused tasks get no reminders; paused_until is the date they resume on,
+-- NULL for tasks paused indefinitely
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS paused_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS paused_until DATE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_paused_until ON tasks(paused_until) WHERE paused_at IS NOT NULL;
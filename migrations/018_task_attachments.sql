@@ -0,0 +1,16 @@
+-- Files, forwarded messages and links attached to tasks; files stay in
+-- Telegram and are sent back by file_id
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    file_id TEXT NOT NULL DEFAULT '',
+    file_name TEXT NOT NULL DEFAULT '',
+    caption TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    chat_id BIGINT NOT NULL DEFAULT 0,
+    message_id INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments(task_id);
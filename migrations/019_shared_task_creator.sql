@@ -0,0 +1,6 @@
+-- Shared group tasks outlive the account of the member who created them:
+-- deleting the account clears user_id on them instead of cascading, and
+-- their events and reminders are then recorded without a user
+ALTER TABLE tasks ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE task_events ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE reminders ALTER COLUMN user_id DROP NOT NULL;
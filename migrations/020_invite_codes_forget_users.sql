@@ -0,0 +1,3 @@
+-- Deleting an account clears the user's Telegram ID from the invite codes
+-- they created or redeemed
+ALTER TABLE invite_codes ALTER COLUMN created_by DROP NOT NULL;
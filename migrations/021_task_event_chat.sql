@@ -0,0 +1,4 @@
+-- Events of shared group tasks record the group, so that they stay out of
+-- the personal statistics of the member who created the task
+ALTER TABLE task_events ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+UPDATE task_events e SET chat_id = t.chat_id FROM tasks t WHERE e.task_id = t.id AND t.chat_id IS NOT NULL AND e.chat_id IS NULL;
@@ -0,0 +1,7 @@
+-- Reminders of shared group tasks record the group: they make up the
+-- group's reminder history rather than that of the member who created the
+-- task
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS chat_id BIGINT REFERENCES chats(id) ON DELETE CASCADE;
+UPDATE reminders r SET chat_id = t.chat_id FROM tasks t WHERE r.task_id = t.id AND t.chat_id IS NOT NULL AND r.chat_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reminders_chat_sent ON reminders(chat_id, sent_at) WHERE chat_id IS NOT NULL;